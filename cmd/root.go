@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"os"
+	"os/exec"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"k8s.io/kubernetes/pkg/kubelet/cri/streaming"
 
 	"github.com/iximiuz/conman/config"
+	"github.com/iximiuz/conman/pkg/cgroups"
 	"github.com/iximiuz/conman/pkg/cri"
 	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/idmap"
 	"github.com/iximiuz/conman/pkg/oci"
+	"github.com/iximiuz/conman/pkg/rlimit"
 	"github.com/iximiuz/conman/pkg/storage"
 	"github.com/iximiuz/conman/server"
 )
@@ -18,38 +22,77 @@ import (
 var cfg config.Config
 
 func init() {
+	defaults := config.Defaults()
+	cfg.Rootless = defaults.Rootless
+
 	rootCmd.Flags().StringVarP(&cfg.Listen,
 		"listen", "l",
-		config.DefaultListen,
+		defaults.Listen,
 		"Daemon listen address")
 	rootCmd.Flags().StringVarP(&cfg.LibRoot,
 		"lib-root", "b",
-		config.DefaultLibRoot,
+		defaults.LibRoot,
 		"Root directory for persistent data, like container bundles, etc.")
 	rootCmd.Flags().StringVarP(&cfg.RunRoot,
 		"run-root", "n",
-		config.DefaultRunRoot,
+		defaults.RunRoot,
 		"Root directory for runtime-only data, like sock & pid files.")
 	rootCmd.Flags().StringVarP(&cfg.ContainerLogRoot,
 		"container-logs", "L",
-		config.DefaultContainerLogRoot,
+		defaults.ContainerLogRoot,
 		"Root directory for container logs.")
 	rootCmd.Flags().StringVarP(&cfg.StreamingAddr,
 		"streaming-addr", "S",
-		config.DefaultStreamingAddr,
+		defaults.StreamingAddr,
 		"Network address (host:port) for streaming server (powers attach, exec, port-forwarding capabilities)")
 	rootCmd.Flags().StringVarP(&cfg.ShimmyPath,
 		"shimmy-path", "s",
-		config.DefaultShimmyPath,
+		defaults.ShimmyPath,
 		"Path to OCI runtime shime executable (shimmy)")
 	rootCmd.Flags().StringVarP(&cfg.RuntimePath,
 		"runtime-path", "r",
-		config.DefaultRuntimePath,
+		defaults.RuntimePath,
 		"Path to OCI-compatible runtime executable")
 	rootCmd.Flags().StringVarP(&cfg.RuntimeRoot,
 		"runtime-root", "t",
-		config.DefaultRuntimeRoot,
+		defaults.RuntimeRoot,
 		"OCI runtime root directory")
+	rootCmd.Flags().StringSliceVarP(&cfg.MountDenylist,
+		"mount-denylist", "",
+		defaults.MountDenylist,
+		"Host paths (and everything beneath them) that can't be bind mounted into containers, neither can their parent directories. The lib root, the run root, the runtime root, and the daemon socket are always denied.")
+	rootCmd.Flags().StringSliceVarP(&cfg.DefaultCapabilities,
+		"default-capabilities", "",
+		defaults.DefaultCapabilities,
+		"Capabilities granted to the non-privileged containers by default")
+	rootCmd.Flags().StringSliceVarP(&cfg.MaskedPaths,
+		"masked-paths", "",
+		defaults.MaskedPaths,
+		"Paths masked in the containers by default")
+	rootCmd.Flags().StringSliceVarP(&cfg.ReadonlyPaths,
+		"readonly-paths", "",
+		defaults.ReadonlyPaths,
+		"Paths mounted read-only in the containers by default")
+	rootCmd.Flags().StringSliceVarP(&cfg.AllowedUnsafeSysctls,
+		"allowed-unsafe-sysctls", "",
+		defaults.AllowedUnsafeSysctls,
+		"Namespaced sysctls (or patterns like net.core.*) the containers can set on top of the safe ones")
+	rootCmd.Flags().StringSliceVarP(&cfg.DefaultRlimits,
+		"default-ulimits", "",
+		defaults.DefaultRlimits,
+		"Resource limits (<type>=<soft>[:<hard>], e.g. nofile=1024:65536) of the container processes by default")
+	rootCmd.Flags().StringSliceVarP(&cfg.CDISpecDirs,
+		"cdi-spec-dirs", "",
+		defaults.CDISpecDirs,
+		"Directories with the CDI spec files (the latter ones take precedence)")
+	rootCmd.Flags().StringVarP(&cfg.SubIDUser,
+		"subid-user", "",
+		defaults.SubIDUser,
+		"User whose /etc/subuid and /etc/subgid ranges are allocated to the user namespace containers")
+	rootCmd.Flags().StringVarP(&cfg.RootlessNetwork,
+		"rootless-network", "",
+		defaults.RootlessNetwork,
+		"Network helper of the rootless containers (auto, pasta, slirp4netns, or none)")
 
 	// TODO: configure it
 	logrus.SetLevel(logrus.TraceLevel)
@@ -63,16 +106,54 @@ like CRI-O or containerd, but for edu purposes.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logrus.Info("Conman's here!")
 
+		istore := storage.NewImageStore(fsutil.EnsureExists(cfg.LibRoot))
+
+		subUIDs := loadSubIDs("/etc/subuid", cfg.SubIDUser)
+		subGIDs := loadSubIDs("/etc/subgid", cfg.SubIDUser)
+
+		cgroupsDelegated := cgroups.Delegated()
+		if cfg.Rootless {
+			logrus.Infof("Running rootless, lib root %s", cfg.LibRoot)
+			if !rootlessMappingTools() {
+				subUIDs, subGIDs = nil, nil
+			}
+			if !cgroupsDelegated {
+				logrus.Warn("Cgroups aren't delegated to the user, container resource limits are ignored")
+			}
+		}
+
+		defaultRlimits, err := rlimit.ParseList(cfg.DefaultRlimits)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad default ulimits")
+		}
+
 		rs, err := cri.NewRuntimeService(
 			oci.NewRuntime(
 				fsutil.AssertExists(cfg.ShimmyPath),
 				fsutil.AssertExists(cfg.RuntimePath),
 				fsutil.EnsureExists(cfg.RuntimeRoot),
+				cfg.Rootless,
 			),
 			storage.NewContainerStore(fsutil.EnsureExists(cfg.LibRoot)),
+			istore,
+			storage.NewVolumeStore(fsutil.EnsureExists(cfg.LibRoot)),
 			fsutil.EnsureExists(cfg.ContainerLogRoot),
 			fsutil.EnsureExists(cfg.RunRoot, "exits"),
 			fsutil.EnsureExists(cfg.RunRoot, "attach"),
+			cri.RuntimeConfig{
+				MountDenylist:        append(cfg.MountDenylist, cfg.LibRoot, cfg.RunRoot, cfg.RuntimeRoot, cfg.Listen),
+				DefaultCapabilities:  cfg.DefaultCapabilities,
+				DefaultRlimits:       defaultRlimits,
+				MaskedPaths:          cfg.MaskedPaths,
+				ReadonlyPaths:        cfg.ReadonlyPaths,
+				AllowedUnsafeSysctls: cfg.AllowedUnsafeSysctls,
+				SubUIDs:              subUIDs,
+				SubGIDs:              subGIDs,
+				Rootless:             cfg.Rootless,
+				CgroupsDelegated:     cgroupsDelegated,
+				RootlessNetwork:      cfg.RootlessNetwork,
+				CDISpecDirs:          cfg.CDISpecDirs,
+			},
 		)
 		if err != nil {
 			logrus.Fatal(err)
@@ -86,7 +167,7 @@ like CRI-O or containerd, but for edu purposes.`,
 		}
 		go ss.Start(true)
 
-		conman := server.New(rs, ss)
+		conman := server.New(rs, cri.NewImageService(istore), ss)
 		if err := conman.Serve("unix", cfg.Listen); err != nil {
 			logrus.Fatal(err)
 		}
@@ -95,6 +176,32 @@ like CRI-O or containerd, but for edu purposes.`,
 	},
 }
 
+// loadSubIDs doesn't fail the daemon start, only the user
+// namespace containers need the subordinate IDs.
+func loadSubIDs(path, user string) []idmap.Range {
+	ranges, err := idmap.LoadSubIDs(path, user)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.WithError(err).Warnf("Failed to load %s", path)
+	}
+	if len(ranges) == 0 {
+		logrus.Warnf("No subordinate IDs for user %s in %s, user namespaces are disabled", user, path)
+	}
+	return ranges
+}
+
+// rootlessMappingTools tells if the OCI runtime can map the subordinate
+// IDs into the rootless containers. Otherwise, only the daemon user is
+// mapped (to the container root).
+func rootlessMappingTools() bool {
+	for _, tool := range []string{"newuidmap", "newgidmap"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			logrus.Warnf("No %s, rootless containers get only the root user and group", tool)
+			return false
+		}
+	}
+	return true
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		logrus.Error(err)
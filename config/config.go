@@ -13,8 +13,70 @@ const (
 	DefaultShimmyPath       = "/usr/local/bin/shimmy"
 	DefaultRuntimePath      = "/usr/bin/runc"
 	DefaultRuntimeRoot      = "/var/run/conman-runc"
+	// The user owning the subordinate IDs for
+	// the user namespace containers.
+	DefaultSubIDUser = "containers"
+	// The first one of pasta and slirp4netns found in PATH.
+	DefaultRootlessNetwork = "auto"
 )
 
+// Host paths that can't be bind mounted into containers by default.
+// LibRoot, RunRoot, RuntimeRoot, and the Listen socket are
+// always denylisted on top of it.
+var DefaultMountDenylist = []string{"/", "/proc", "/sys"}
+
+// Capabilities granted to the non-privileged containers by default.
+// Same as the Docker ones.
+var DefaultCapabilities = []string{
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_FSETID",
+	"CAP_FOWNER",
+	"CAP_MKNOD",
+	"CAP_NET_RAW",
+	"CAP_SETGID",
+	"CAP_SETUID",
+	"CAP_SETFCAP",
+	"CAP_SETPCAP",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_SYS_CHROOT",
+	"CAP_KILL",
+	"CAP_AUDIT_WRITE",
+}
+
+// The /proc and /sys paths masked in the containers by default.
+// Same as the Kubernetes ones.
+var DefaultMaskedPaths = []string{
+	"/proc/acpi",
+	"/proc/asound",
+	"/proc/kcore",
+	"/proc/keys",
+	"/proc/latency_stats",
+	"/proc/timer_list",
+	"/proc/timer_stats",
+	"/proc/sched_debug",
+	"/proc/scsi",
+	"/sys/firmware",
+	"/sys/devices/virtual/powercap",
+}
+
+// The /proc paths mounted read-only in the containers by default.
+var DefaultReadonlyPaths = []string{
+	"/proc/bus",
+	"/proc/fs",
+	"/proc/irq",
+	"/proc/sys",
+	"/proc/sysrq-trigger",
+}
+
+// Resource limits of the container processes, see setrlimit(2).
+// Same as the runtime-tools ones.
+var DefaultRlimits = []string{"nofile=1024:1024"}
+
+// Container Device Interface spec dirs,
+// the latter ones take precedence.
+var DefaultCDISpecDirs = []string{"/etc/cdi", "/var/run/cdi"}
+
 type Config struct {
 	Listen string
 
@@ -36,6 +98,40 @@ type Config struct {
 	RuntimePath string
 
 	RuntimeRoot string
+
+	// Host paths that can't be bind mounted into containers.
+	MountDenylist []string
+
+	// Capabilities granted to the non-privileged containers
+	// (on top of them, --cap-add and --cap-drop are applied).
+	DefaultCapabilities []string
+
+	// Paths masked and mounted read-only in the containers
+	// unless the container asks for the unmasked /proc.
+	MaskedPaths   []string
+	ReadonlyPaths []string
+
+	// Namespaced sysctls (or prefixes ending with *) the containers
+	// can set on top of the safe ones.
+	AllowedUnsafeSysctls []string
+
+	// Resource limits (<type>=<soft>[:<hard>]) of the container
+	// processes, unless overridden by the containers.
+	DefaultRlimits []string
+
+	// Directories with the Container Device Interface spec files.
+	CDISpecDirs []string
+
+	// The /etc/subuid and /etc/subgid entries of the user are
+	// allocated to the user namespace containers.
+	SubIDUser string
+
+	// The daemon runs as an unprivileged user (see IsRootless).
+	Rootless bool
+
+	// Network helper of the rootless containers: pasta,
+	// slirp4netns, auto, or none.
+	RootlessNetwork string
 }
 
 func TestConfigFromFlags() *Config {
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+)
+
+// IsRootless tells if conman runs as an unprivileged user.
+func IsRootless() bool {
+	return os.Geteuid() != 0
+}
+
+// Defaults returns the default configuration. The rootless daemon
+// keeps its data in the XDG base directories of the user.
+func Defaults() Config {
+	cfg := Config{
+		Listen:              DefaultListen,
+		LibRoot:             DefaultLibRoot,
+		RunRoot:             DefaultRunRoot,
+		ContainerLogRoot:    DefaultContainerLogRoot,
+		StreamingAddr:       DefaultStreamingAddr,
+		ShimmyPath:          DefaultShimmyPath,
+		RuntimePath:         DefaultRuntimePath,
+		RuntimeRoot:         DefaultRuntimeRoot,
+		MountDenylist:       DefaultMountDenylist,
+		DefaultCapabilities: DefaultCapabilities,
+		MaskedPaths:         DefaultMaskedPaths,
+		ReadonlyPaths:       DefaultReadonlyPaths,
+		SubIDUser:           DefaultSubIDUser,
+		RootlessNetwork:     DefaultRootlessNetwork,
+		DefaultRlimits:      DefaultRlimits,
+		CDISpecDirs:         DefaultCDISpecDirs,
+	}
+	if !IsRootless() {
+		return cfg
+	}
+
+	runtimeDir := xdgRuntimeDir()
+	cfg.Rootless = true
+	cfg.Listen = filepath.Join(runtimeDir, "conmand.sock")
+	cfg.LibRoot = filepath.Join(xdgDir("XDG_DATA_HOME", ".local/share"), "conman")
+	cfg.RunRoot = filepath.Join(runtimeDir, "conman")
+	cfg.ContainerLogRoot = filepath.Join(xdgDir("XDG_STATE_HOME", ".local/state"), "conman", "containers")
+	cfg.RuntimeRoot = filepath.Join(runtimeDir, "conman-runc")
+	if u, err := user.Current(); err == nil {
+		cfg.SubIDUser = u.Username
+	}
+	return cfg
+}
+
+func xdgDir(env, fallback string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = filepath.Join(os.TempDir(), fmt.Sprintf("conman-%d", os.Geteuid()))
+	}
+	return filepath.Join(home, fallback)
+}
+
+func xdgRuntimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(dir) {
+		return dir
+	}
+	dir := fmt.Sprintf("/run/user/%d", os.Geteuid())
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("conman-%d", os.Geteuid()))
+}
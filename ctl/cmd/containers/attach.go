@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -24,7 +25,7 @@ func init() {
 }
 
 var attachCmd = &cobra.Command{
-	Use:   "attach <container-id>",
+	Use:   "attach <container-id|name>",
 	Short: "",
 	Long:  "",
 	Args:  cobra.ExactArgs(1),
@@ -47,30 +48,38 @@ var attachCmd = &cobra.Command{
 				Fatal("Command failed (see conmand logs for details)")
 		}
 
-		url, err := url.Parse(resp.Url)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to parse stream URL")
+		if err := stream(resp.Url, true); err != nil {
+			logrus.WithError(err).Fatal("Attach failed")
 		}
+	},
+}
 
-		executor, err := remotecommand.NewSPDYExecutor(
-			&rest.Config{
-				TLSClientConfig: rest.TLSClientConfig{Insecure: true},
-			},
-			"POST",
-			url,
-		)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to create stream executor")
-		}
+// stream connects the std streams of the current process to the
+// container's ones using an attach URL from the streaming server.
+func stream(streamURL string, stdin bool) error {
+	url, err := url.Parse(streamURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse stream URL")
+	}
 
-		streamOptions := remotecommand.StreamOptions{
-			Stdin:  os.Stdin,
-			Stdout: os.Stdout,
-			Stderr: os.Stderr,
-			Tty:    false,
-		}
-		if err := executor.Stream(streamOptions); err != nil {
-			logrus.WithError(err).Fatal("executor.Stream() failed")
-		}
-	},
+	executor, err := remotecommand.NewSPDYExecutor(
+		&rest.Config{
+			TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+		},
+		"POST",
+		url,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create stream executor")
+	}
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Tty:    false,
+	}
+	if stdin {
+		streamOptions.Stdin = os.Stdin
+	}
+	return errors.Wrap(executor.Stream(streamOptions), "executor.Stream() failed")
 }
@@ -2,10 +2,16 @@ package containers
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/pkg/rlimit"
+	"github.com/iximiuz/conman/server"
 )
 
 func init() {
@@ -13,11 +19,36 @@ func init() {
 }
 
 type Options struct {
-	Rootfs         string
-	RootfsReadonly bool
-	Command        string
-	Stdin          bool
-	LeaveStdinOpen bool
+	Rootfs          string
+	RootfsReadonly  bool
+	Command         string
+	Stdin           bool
+	LeaveStdinOpen  bool
+	Labels          []string
+	Annotations     []string
+	Volumes         []string
+	Tmpfs           []string
+	Seccomp         string
+	CapAdd          []string
+	CapDrop         []string
+	Privileged      bool
+	NoNewPrivileges bool
+	UserNamespace   bool
+	UIDMappings     []string
+	GIDMappings     []string
+	Network         string
+	PID             string
+	IPC             string
+	UTS             string
+	ProcMount       string
+	MaskedPaths     []string
+	ReadonlyPaths   []string
+	Sysctls         []string
+	Devices         []string
+	Ulimits         []string
+	OOMScoreAdj     int
+
+	TerminationMessagePath string
 }
 
 var opts Options
@@ -31,3 +62,311 @@ var baseCmd = &cobra.Command{
 		cmd.Help()
 	},
 }
+
+// parseContainerState accepts only the states containers can be in,
+// i.e. not UNKNOWN.
+func parseContainerState(s string) (server.ContainerState, error) {
+	state, ok := server.ContainerState_value[strings.ToUpper(s)]
+	if !ok || server.ContainerState(state) == server.ContainerState_UNKNOWN {
+		return 0, fmt.Errorf("unknown container state %q", s)
+	}
+	return server.ContainerState(state), nil
+}
+
+// splitImageFlag tells a stored image reference from a rootfs directory.
+// Existing directories are always treated as rootfs.
+func splitImageFlag(s string) (image, rootfs string) {
+	if fi, err := os.Stat(s); err == nil && fi.IsDir() {
+		return "", s
+	}
+	return s, ""
+}
+
+var mountPropagations = map[string]server.MountPropagation{
+	"rprivate": server.MountPropagation_PROPAGATION_PRIVATE,
+	"rslave":   server.MountPropagation_PROPAGATION_HOST_TO_CONTAINER,
+	"rshared":  server.MountPropagation_PROPAGATION_BIDIRECTIONAL,
+}
+
+// parseMounts parses the --volume and --tmpfs flag values:
+//
+//	--volume <host-path|volume-name>:<container-path>[:ro|rro|rw,rprivate|rslave|rshared]
+//	--tmpfs <container-path>[:ro|rw,size=<bytes>[k|m|g]]
+func parseMounts(volumes, tmpfs []string) ([]*server.Mount, error) {
+	var mounts []*server.Mount
+
+	for _, v := range volumes {
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("bad volume %q", v)
+		}
+
+		m := &server.Mount{
+			Type:        server.MountType_VOLUME,
+			Source:      parts[0],
+			Destination: parts[1],
+		}
+		if strings.HasPrefix(m.Source, "/") {
+			m.Type = server.MountType_BIND
+		}
+		if len(parts) == 3 {
+			for _, o := range strings.Split(parts[2], ",") {
+				if p, ok := mountPropagations[o]; ok {
+					m.Propagation = p
+				} else if o == "ro" || o == "rro" || o == "rw" {
+					m.Readonly = o != "rw"
+					m.RecursiveReadOnly = o == "rro"
+				} else {
+					return nil, fmt.Errorf("bad volume %q: unknown option %q", v, o)
+				}
+			}
+		}
+		mounts = append(mounts, m)
+	}
+
+	for _, t := range tmpfs {
+		parts := strings.SplitN(t, ":", 2)
+		if parts[0] == "" {
+			return nil, fmt.Errorf("bad tmpfs %q", t)
+		}
+
+		m := &server.Mount{
+			Type:        server.MountType_TMPFS,
+			Destination: parts[0],
+		}
+		if len(parts) == 2 {
+			for _, o := range strings.Split(parts[1], ",") {
+				if strings.HasPrefix(o, "size=") {
+					size, err := parseSize(strings.TrimPrefix(o, "size="))
+					if err != nil {
+						return nil, fmt.Errorf("bad tmpfs %q: %v", t, err)
+					}
+					m.Size = size
+				} else if o == "ro" || o == "rw" {
+					m.Readonly = o == "ro"
+				} else {
+					return nil, fmt.Errorf("bad tmpfs %q: unknown option %q", t, o)
+				}
+			}
+		}
+		mounts = append(mounts, m)
+	}
+
+	return mounts, nil
+}
+
+// parseSize parses a size in bytes with an optional
+// binary unit suffix (k, m, or g), e.g. 64m.
+func parseSize(s string) (int64, error) {
+	mult := int64(1)
+	if n := len(s); n > 0 {
+		switch strings.ToLower(s[n-1:]) {
+		case "k":
+			mult = 1 << 10
+		case "m":
+			mult = 1 << 20
+		case "g":
+			mult = 1 << 30
+		}
+		if mult > 1 {
+			s = s[:n-1]
+		}
+	}
+
+	size, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("bad size %q", s)
+	}
+	return size * mult, nil
+}
+
+func formatMounts(mounts []*server.Mount) string {
+	var ss []string
+	for _, m := range mounts {
+		s := m.Source + ":" + m.Destination
+		if m.Type == server.MountType_TMPFS {
+			s = "tmpfs:" + m.Destination
+		}
+		if m.RecursiveReadOnly {
+			s += ":rro"
+		} else if m.Readonly {
+			s += ":ro"
+		}
+		ss = append(ss, s)
+	}
+	return strings.Join(ss, ",")
+}
+
+// seccompProfile turns the --seccomp flag value into a profile name.
+// A path to a JSON profile becomes localhost/<absolute path>, since
+// the daemon may have a different working directory.
+func seccompProfile(v string) (string, error) {
+	switch {
+	case v == "", v == "unconfined", v == "runtime/default",
+		strings.HasPrefix(v, "localhost/"):
+		return v, nil
+	}
+	path, err := filepath.Abs(v)
+	if err != nil {
+		return "", err
+	}
+	return "localhost/" + path, nil
+}
+
+// parseIDMappings parses the --uidmap and --gidmap flag values:
+//
+//	<container-id>:<host-id-offset>:<size>
+func parseIDMappings(values []string) ([]*server.IDMapping, error) {
+	var mappings []*server.IDMapping
+	for _, v := range values {
+		parts := strings.Split(v, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("bad ID mapping %q", v)
+		}
+		var ids [3]uint32
+		for i, p := range parts {
+			id, err := strconv.ParseUint(p, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("bad ID mapping %q", v)
+			}
+			ids[i] = uint32(id)
+		}
+		mappings = append(mappings, &server.IDMapping{
+			ContainerId: ids[0],
+			HostId:      ids[1],
+			Size:        ids[2],
+		})
+	}
+	return mappings, nil
+}
+
+func formatIDMappings(mappings []*server.IDMapping) string {
+	var parts []string
+	for _, m := range mappings {
+		parts = append(parts, fmt.Sprintf("%d:%d:%d", m.ContainerId, m.HostId, m.Size))
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseNamespaceOptions parses the --network, --pid, --ipc, and --uts
+// flag values: private (default), host, or container:<container-id|name>.
+// All the shared namespaces must belong to the same container.
+func parseNamespaceOptions(network, pid, ipc, uts string) (*server.NamespaceOption, error) {
+	var opts server.NamespaceOption
+	for _, ns := range []struct {
+		name  string
+		value string
+		mode  *server.NamespaceMode
+	}{
+		{"network", network, &opts.Network},
+		{"pid", pid, &opts.Pid},
+		{"ipc", ipc, &opts.Ipc},
+		{"uts", uts, &opts.Uts},
+	} {
+		switch {
+		case ns.value == "", ns.value == "private":
+			*ns.mode = server.NamespaceMode_CONTAINER
+		case ns.value == "host":
+			*ns.mode = server.NamespaceMode_NODE
+		case strings.HasPrefix(ns.value, "container:") && len(ns.value) > len("container:"):
+			target := strings.TrimPrefix(ns.value, "container:")
+			if opts.TargetId != "" && opts.TargetId != target {
+				return nil, fmt.Errorf("%s namespace: all namespaces must be shared with the same container", ns.name)
+			}
+			opts.TargetId = target
+			*ns.mode = server.NamespaceMode_TARGET
+		default:
+			return nil, fmt.Errorf("bad %s namespace %q, expected private, host, or container:<container-id|name>", ns.name, ns.value)
+		}
+	}
+	return &opts, nil
+}
+
+func formatNamespaceOptions(opts *server.NamespaceOption) string {
+	if opts == nil {
+		return ""
+	}
+	var parts []string
+	for _, ns := range []struct {
+		name string
+		mode server.NamespaceMode
+	}{
+		{"network", opts.Network},
+		{"pid", opts.Pid},
+		{"ipc", opts.Ipc},
+		{"uts", opts.Uts},
+	} {
+		switch ns.mode {
+		case server.NamespaceMode_NODE:
+			parts = append(parts, ns.name+"=host")
+		case server.NamespaceMode_TARGET:
+			parts = append(parts, ns.name+"=container:"+cmd.ShortID(opts.TargetId))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseProcMount(s string) (server.ProcMount, error) {
+	mount, ok := server.ProcMount_value[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown proc mount type %q, expected default or unmasked", s)
+	}
+	return server.ProcMount(mount), nil
+}
+
+// parseDevices parses the --device flag values: a CDI device name
+// (vendor.com/class=name) or <host-path>[:<container-path>][:<permissions>],
+// with the container path defaulting to the host path and the
+// permissions to rwm.
+func parseDevices(values []string) (devices []*server.Device, cdiDevices []string, err error) {
+	for _, v := range values {
+		if !strings.HasPrefix(v, "/") && strings.Contains(v, "=") {
+			cdiDevices = append(cdiDevices, v)
+			continue
+		}
+
+		parts := strings.Split(v, ":")
+		d := &server.Device{HostPath: parts[0], ContainerPath: parts[0], Permissions: "rwm"}
+		switch {
+		case len(parts) == 2 && !strings.HasPrefix(parts[1], "/"):
+			d.Permissions = parts[1]
+		case len(parts) == 2:
+			d.ContainerPath = parts[1]
+		case len(parts) == 3:
+			d.ContainerPath, d.Permissions = parts[1], parts[2]
+		case len(parts) > 3:
+			return nil, nil, fmt.Errorf("bad device %q, expected <host-path>[:<container-path>][:<permissions>]", v)
+		}
+		devices = append(devices, d)
+	}
+	return devices, cdiDevices, nil
+}
+
+func formatDevices(devices []*server.Device, cdiDevices []string) string {
+	var parts []string
+	for _, d := range devices {
+		parts = append(parts, d.HostPath+":"+d.ContainerPath+":"+d.Permissions)
+	}
+	return strings.Join(append(parts, cdiDevices...), ",")
+}
+
+// parseUlimits parses the --ulimit flag values: <type>=<soft>[:<hard>].
+func parseUlimits(values []string) ([]*server.Rlimit, error) {
+	rlimits, err := rlimit.ParseList(values)
+	if err != nil {
+		return nil, err
+	}
+	var rv []*server.Rlimit
+	for _, r := range rlimits {
+		rv = append(rv, &server.Rlimit{Type: r.Type, Soft: r.Soft, Hard: r.Hard})
+	}
+	return rv, nil
+}
+
+func formatUlimits(rlimits []*server.Rlimit) string {
+	var parts []string
+	for _, r := range rlimits {
+		parts = append(parts, rlimit.Rlimit{Type: r.Type, Soft: r.Soft, Hard: r.Hard}.String())
+	}
+	return strings.Join(parts, ",")
+}
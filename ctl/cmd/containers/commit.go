@@ -0,0 +1,77 @@
+package containers
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+type commitOptions struct {
+	Labels  []string
+	Author  string
+	Message string
+}
+
+var commitOpts commitOptions
+
+func init() {
+	commitCmd.Flags().StringArrayVarP(&commitOpts.Labels,
+		"label", "l",
+		nil,
+		"Add image label (key=value, can be repeated)")
+
+	commitCmd.Flags().StringVarP(&commitOpts.Author,
+		"author", "",
+		"",
+		"Image author")
+
+	commitCmd.Flags().StringVarP(&commitOpts.Message,
+		"message", "m",
+		"",
+		"Image history comment")
+
+	cmdutil.AddOutputFlags(commitCmd)
+
+	baseCmd.AddCommand(commitCmd)
+}
+
+var commitCmd = &cobra.Command{
+	Use:   "commit <container-id|name> [<image-name:tag>]",
+	Short: "Create a new image from the container rootfs changes",
+	Long: `Create a new image from the container rootfs changes.
+The container command, environment, and labels become the image defaults.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		labels, err := cmdutil.ParseKeyValues(commitOpts.Labels)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad label")
+		}
+
+		var name string
+		if len(args) > 1 {
+			name = args[1]
+		}
+
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.CommitContainer(
+			context.Background(),
+			&server.CommitContainerRequest{
+				ContainerId: args[0],
+				ImageName:   name,
+				Labels:      labels,
+				Author:      commitOpts.Author,
+				Message:     commitOpts.Message,
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+		cmdutil.Print(resp)
+	},
+}
@@ -0,0 +1,151 @@
+package containers
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	baseCmd.AddCommand(cpCmd)
+}
+
+var cpCmd = &cobra.Command{
+	Use:   "cp <container-id|name>:<src-path> <dst-path>|- | <src-path>|- <container-id|name>:<dst-path>",
+	Short: "Copy files between a container and the local filesystem",
+	Long: `Copy files or directories between a container and the local filesystem.
+If the destination is an existing directory, the source is copied inside of it.
+Otherwise, the source is copied to the destination path. Use - to write a tar
+archive to STDOUT or to read it from STDIN.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		srcRef, srcPath := splitCopyPath(args[0])
+		dstRef, dstPath := splitCopyPath(args[1])
+
+		var err error
+		switch {
+		case srcRef != "" && dstRef == "":
+			err = copyFromContainer(srcRef, srcPath, dstPath)
+		case srcRef == "" && dstRef != "":
+			err = copyToContainer(srcPath, dstRef, dstPath)
+		default:
+			err = errors.New("exactly one of the paths must be a container path")
+		}
+		if err != nil {
+			logrus.WithError(err).Fatal("Copying failed")
+		}
+	},
+}
+
+// splitCopyPath parses <container>:<path> arguments. Paths starting
+// with / or . are always local, even if they contain a colon.
+func splitCopyPath(arg string) (ref, path string) {
+	if strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 {
+		return "", arg
+	}
+	return parts[0], parts[1]
+}
+
+func copyFromContainer(ref, src, dst string) error {
+	client, conn := cmdutil.Connect()
+	defer conn.Close()
+
+	stream, err := client.CopyFromContainer(
+		context.Background(),
+		&server.CopyFromContainerRequest{
+			ContainerId: ref,
+			Path:        src,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	r := cmdutil.Download(func() ([]byte, error) {
+		chunk, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return chunk.Data, nil
+	})
+
+	if dst == "-" {
+		_, err := io.Copy(os.Stdout, r)
+		return err
+	}
+
+	abs, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(dst, "/") {
+		abs += "/"
+	}
+
+	dir, rename, err := archive.Target("/", abs)
+	if err != nil {
+		return err
+	}
+	return archive.Untar(r, "/", dir, rename, nil)
+}
+
+func copyToContainer(src, ref, dst string) error {
+	var r io.Reader = os.Stdin
+	if src != "-" {
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Lstat(abs); err != nil {
+			return err
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(archive.Tar(pw, "/", abs, filepath.Base(abs), nil))
+		}()
+		defer pr.Close()
+		r = pr
+	}
+
+	client, conn := cmdutil.Connect()
+	defer conn.Close()
+
+	stream, err := client.CopyToContainer(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&server.CopyToContainerRequest{
+		ContainerId: ref,
+		Path:        dst,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.Upload(
+		r,
+		func(data []byte) error {
+			return stream.Send(&server.CopyToContainerRequest{Data: data})
+		},
+		func() error {
+			_, err := stream.CloseAndRecv()
+			return err
+		},
+	)
+}
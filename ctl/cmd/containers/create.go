@@ -10,27 +10,20 @@ import (
 )
 
 func init() {
-	createCmd.PersistentFlags().StringVarP(&opts.Rootfs,
-		"image", "I",
-		"",
-		"Container rootfs image (required)")
-	createCmd.MarkPersistentFlagRequired("image")
-
-	createCmd.PersistentFlags().BoolVarP(&opts.RootfsReadonly,
-		"rootfs-readonly", "R",
-		true,
-		"Wether container can modify its rootfs")
+	addContainerFlags(createCmd)
 
-	createCmd.PersistentFlags().BoolVarP(&opts.Stdin,
+	createCmd.Flags().BoolVarP(&opts.Stdin,
 		"stdin", "i",
 		false,
 		"Keep container's STDIN open (interactive mode)")
 
-	createCmd.PersistentFlags().BoolVarP(&opts.LeaveStdinOpen,
+	createCmd.Flags().BoolVarP(&opts.LeaveStdinOpen,
 		"leave-stdin-open", "",
 		false,
 		"Leave container's STDIN open after first attach session completes")
 
+	cmdutil.AddOutputFlags(createCmd)
+
 	baseCmd.AddCommand(createCmd)
 }
 
@@ -45,15 +38,7 @@ var createCmd = &cobra.Command{
 
 		resp, err := client.CreateContainer(
 			context.Background(),
-			&server.CreateContainerRequest{
-				Name:           args[0],
-				RootfsPath:     opts.Rootfs,
-				RootfsReadonly: opts.RootfsReadonly,
-				Command:        args[1],
-				Args:           args[2:],
-				Stdin:          opts.Stdin,
-				StdinOnce:      !opts.LeaveStdinOpen,
-			},
+			containerRequest(args),
 		)
 		if err != nil {
 			logrus.WithError(err).
@@ -62,3 +47,230 @@ var createCmd = &cobra.Command{
 		cmdutil.Print(resp)
 	},
 }
+
+// addContainerFlags registers the container config flags
+// shared by the create and run commands.
+func addContainerFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.Rootfs,
+		"image", "I",
+		"",
+		"Stored image (name:tag or ID) or rootfs directory (required)")
+	cmd.MarkFlagRequired("image")
+
+	flags.BoolVarP(&opts.RootfsReadonly,
+		"rootfs-readonly", "R",
+		true,
+		"Wether container can modify its rootfs")
+
+	flags.StringArrayVarP(&opts.Labels,
+		"label", "l",
+		nil,
+		"Set container label (key=value, can be repeated)")
+
+	flags.StringArrayVarP(&opts.Annotations,
+		"annotation", "a",
+		nil,
+		"Set container annotation (key=value, can be repeated)")
+
+	flags.StringArrayVarP(&opts.Volumes,
+		"volume", "v",
+		nil,
+		"Mount a host path or a named volume (<host-path|volume>:<path>[:ro|rro|rw,rprivate|rslave|rshared], can be repeated)")
+
+	flags.StringArrayVarP(&opts.Tmpfs,
+		"tmpfs", "",
+		nil,
+		"Mount a tmpfs (<path>[:ro,size=64m], can be repeated)")
+
+	flags.StringVarP(&opts.Seccomp,
+		"seccomp", "",
+		"",
+		"Seccomp profile: runtime/default (default), unconfined, or a path to a JSON profile")
+
+	flags.StringSliceVarP(&opts.CapAdd,
+		"cap-add", "",
+		nil,
+		"Add Linux capabilities (e.g. NET_ADMIN, or ALL)")
+
+	flags.StringSliceVarP(&opts.CapDrop,
+		"cap-drop", "",
+		nil,
+		"Drop Linux capabilities (e.g. NET_RAW, or ALL)")
+
+	flags.BoolVarP(&opts.Privileged,
+		"privileged", "",
+		false,
+		"Grant all capabilities and host devices, unmask /proc and /sys, and disable seccomp (unless --seccomp is given)")
+
+	flags.BoolVarP(&opts.NoNewPrivileges,
+		"no-new-privileges", "",
+		false,
+		"Prevent the container processes from gaining new privileges (e.g. via setuid binaries)")
+
+	flags.BoolVarP(&opts.UserNamespace,
+		"userns", "",
+		false,
+		"Run the container in its own user namespace (with IDs from the daemon subordinate ranges)")
+
+	flags.StringArrayVarP(&opts.UIDMappings,
+		"uidmap", "",
+		nil,
+		"User namespace UID mapping (<container-uid>:<host-uid-offset>:<size>, can be repeated, implies --userns)")
+
+	flags.StringArrayVarP(&opts.GIDMappings,
+		"gidmap", "",
+		nil,
+		"User namespace GID mapping (<container-gid>:<host-gid-offset>:<size>, can be repeated, implies --userns)")
+
+	flags.StringVarP(&opts.Network,
+		"network", "",
+		"",
+		"Network namespace: private (default), host, or container:<container-id|name>")
+
+	flags.StringVarP(&opts.PID,
+		"pid", "",
+		"",
+		"PID namespace: private (default), host, or container:<container-id|name>")
+
+	flags.StringVarP(&opts.IPC,
+		"ipc", "",
+		"",
+		"IPC namespace: private (default), host, or container:<container-id|name>")
+
+	flags.StringVarP(&opts.UTS,
+		"uts", "",
+		"",
+		"UTS namespace: private (default), host, or container:<container-id|name>")
+
+	flags.StringVarP(&opts.ProcMount,
+		"proc-mount", "",
+		"default",
+		"Proc mount type: default (with the daemon masked and read-only paths) or unmasked")
+
+	flags.StringArrayVarP(&opts.MaskedPaths,
+		"masked-path", "",
+		nil,
+		"Path masked in the container (can be repeated, replaces the daemon defaults)")
+
+	flags.StringArrayVarP(&opts.ReadonlyPaths,
+		"readonly-path", "",
+		nil,
+		"Path mounted read-only in the container (can be repeated, replaces the daemon defaults)")
+
+	flags.StringArrayVarP(&opts.Sysctls,
+		"sysctl", "",
+		nil,
+		"Namespaced kernel parameter (key=value, can be repeated), e.g. net.core.somaxconn=1024")
+
+	flags.StringArrayVarP(&opts.Devices,
+		"device", "",
+		nil,
+		"Host device (<host-path>[:<container-path>][:<permissions>]) or CDI device (vendor.com/class=name), can be repeated")
+
+	flags.StringArrayVarP(&opts.Ulimits,
+		"ulimit", "",
+		nil,
+		"Resource limit (<type>=<soft>[:<hard>], e.g. nofile=65536, can be repeated), overrides the daemon default of the same type")
+
+	flags.IntVarP(&opts.OOMScoreAdj,
+		"oom-score-adj", "",
+		0,
+		"Container process oom_score_adj [-1000, 1000]")
+
+	flags.StringVarP(&opts.TerminationMessagePath,
+		"termination-message-path", "",
+		"",
+		"Container path of the termination message file (default /dev/termination-log)")
+}
+
+// containerRequest builds the container config from the flags
+// and the <container-name> -- <command> [args...] arguments.
+func containerRequest(args []string) *server.CreateContainerRequest {
+	labels, err := cmdutil.ParseKeyValues(opts.Labels)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad label")
+	}
+	annotations, err := cmdutil.ParseKeyValues(opts.Annotations)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad annotation")
+	}
+
+	mounts, err := parseMounts(opts.Volumes, opts.Tmpfs)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad mount")
+	}
+
+	seccomp, err := seccompProfile(opts.Seccomp)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad seccomp profile")
+	}
+
+	uidMappings, err := parseIDMappings(opts.UIDMappings)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad UID mapping")
+	}
+	gidMappings, err := parseIDMappings(opts.GIDMappings)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad GID mapping")
+	}
+	userns := opts.UserNamespace || len(uidMappings) > 0 || len(gidMappings) > 0
+
+	namespaces, err := parseNamespaceOptions(opts.Network, opts.PID, opts.IPC, opts.UTS)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad namespace option")
+	}
+
+	procMount, err := parseProcMount(opts.ProcMount)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad proc mount")
+	}
+	sysctls, err := cmdutil.ParseKeyValues(opts.Sysctls)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad sysctl")
+	}
+	devices, cdiDevices, err := parseDevices(opts.Devices)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad device")
+	}
+	ulimits, err := parseUlimits(opts.Ulimits)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad ulimit")
+	}
+
+	image, rootfs := splitImageFlag(opts.Rootfs)
+
+	return &server.CreateContainerRequest{
+		Name:             args[0],
+		Image:            image,
+		RootfsPath:       rootfs,
+		RootfsReadonly:   opts.RootfsReadonly,
+		Command:          args[1],
+		Args:             args[2:],
+		Stdin:            opts.Stdin,
+		StdinOnce:        !opts.LeaveStdinOpen,
+		Labels:           labels,
+		Annotations:      annotations,
+		Mounts:           mounts,
+		SeccompProfile:   seccomp,
+		CapAdd:           opts.CapAdd,
+		CapDrop:          opts.CapDrop,
+		Privileged:       opts.Privileged,
+		NoNewPrivileges:  opts.NoNewPrivileges,
+		UserNamespace:    userns,
+		UidMappings:      uidMappings,
+		GidMappings:      gidMappings,
+		NamespaceOptions: namespaces,
+		MaskedPaths:      opts.MaskedPaths,
+		ReadonlyPaths:    opts.ReadonlyPaths,
+		ProcMount:        procMount,
+		Sysctls:          sysctls,
+		Devices:          devices,
+		CdiDevices:       cdiDevices,
+		Rlimits:          ulimits,
+		OomScoreAdj:      int32(opts.OOMScoreAdj),
+
+		TerminationMessagePath: opts.TerminationMessagePath,
+	}
+}
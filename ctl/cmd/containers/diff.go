@@ -0,0 +1,66 @@
+package containers
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	cmdutil.AddOutputFlags(diffCmd)
+	baseCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <container-id|name>",
+	Short: "List changed paths in the container rootfs",
+	Long: `List paths added (A), changed (C), or deleted (D) in the container
+rootfs since the container's been created from its image (or rootfs).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.ContainerChanges(
+			context.Background(),
+			&server.ContainerChangesRequest{
+				ContainerId: args[0],
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		var items []interface{}
+		for _, c := range resp.Changes {
+			items = append(items, c)
+		}
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: items,
+			Table: changesTable(resp.Changes),
+		})
+	},
+}
+
+var changeKindShort = map[server.ChangeKind]string{
+	server.ChangeKind_MODIFIED: "C",
+	server.ChangeKind_ADDED:    "A",
+	server.ChangeKind_DELETED:  "D",
+}
+
+func changesTable(changes []*server.ContainerChange) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		header := []string{"KIND", "PATH"}
+
+		var rows [][]string
+		for _, c := range changes {
+			rows = append(rows, []string{changeKindShort[c.Kind], c.Path})
+		}
+		return header, rows
+	}
+}
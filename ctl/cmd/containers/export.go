@@ -0,0 +1,67 @@
+package containers
+
+import (
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+var exportFile string
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFile,
+		"file", "f",
+		"-",
+		"Write the archive to a file instead of STDOUT")
+
+	baseCmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <container-id|name>",
+	Short: "Export the container rootfs as a tar archive",
+	Long:  "Export the container rootfs as a tar archive.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		stream, err := client.ExportContainer(
+			context.Background(),
+			&server.ExportContainerRequest{
+				ContainerId: args[0],
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		var out io.Writer = os.Stdout
+		if exportFile != "-" {
+			f, err := os.Create(exportFile)
+			if err != nil {
+				logrus.WithError(err).Fatal("Can't create output file")
+			}
+			defer f.Close()
+			out = f
+		}
+
+		r := cmdutil.Download(func() ([]byte, error) {
+			chunk, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			return chunk.Data, nil
+		})
+		if _, err := io.Copy(out, r); err != nil {
+			logrus.WithError(err).Fatal("Export failed")
+		}
+	},
+}
@@ -1,6 +1,10 @@
 package containers
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -9,7 +13,38 @@ import (
 	"github.com/iximiuz/conman/server"
 )
 
+type listOptions struct {
+	State  string
+	Name   string
+	Labels []string
+	Quiet  bool
+}
+
+var listOpts listOptions
+
 func init() {
+	listCmd.Flags().StringVarP(&listOpts.State,
+		"state", "s",
+		"",
+		"Show only containers in this state (created, running, exited)")
+
+	listCmd.Flags().StringVarP(&listOpts.Name,
+		"name", "n",
+		"",
+		"Show only the container with this name")
+
+	listCmd.Flags().StringArrayVarP(&listOpts.Labels,
+		"label", "l",
+		nil,
+		"Show only containers having this label (key=value, can be repeated)")
+
+	listCmd.Flags().BoolVarP(&listOpts.Quiet,
+		"quiet", "q",
+		false,
+		"Print only container IDs")
+
+	cmdutil.AddOutputFlags(listCmd)
+
 	baseCmd.AddCommand(listCmd)
 }
 
@@ -19,17 +54,97 @@ var listCmd = &cobra.Command{
 	Long:  "",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		filter, err := listFilter(listOpts)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad filter")
+		}
+
 		client, conn := cmdutil.Connect()
 		defer conn.Close()
 
 		resp, err := client.ListContainers(
 			context.Background(),
-			&server.ListContainersRequest{},
+			&server.ListContainersRequest{
+				Filter: filter,
+			},
 		)
 		if err != nil {
 			logrus.WithError(err).
 				Fatal("Command failed (see conmand logs for details)")
 		}
-		cmdutil.Print(resp)
+
+		if listOpts.Quiet {
+			for _, c := range resp.Containers {
+				fmt.Println(c.Id)
+			}
+			return
+		}
+		var items []interface{}
+		for _, c := range resp.Containers {
+			items = append(items, c)
+		}
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: items,
+			Table: containersTable(resp.Containers),
+		})
 	},
 }
+
+func containersTable(cs []*server.Container) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		header := []string{"ID", "NAME", "STATE", "CREATED", "EXIT", "IMAGE"}
+		if wide {
+			header = append(header, "LABELS")
+		}
+
+		var rows [][]string
+		for _, c := range cs {
+			id := cmdutil.ShortID(c.Id)
+			if wide {
+				id = c.Id
+			}
+
+			exit := ""
+			if c.State == server.ContainerState_EXITED {
+				exit = strconv.Itoa(int(c.ExitCode))
+			}
+
+			row := []string{
+				id,
+				c.Name,
+				strings.ToLower(c.State.String()),
+				cmdutil.HumanTime(c.CreatedAt),
+				exit,
+				c.Image,
+			}
+			if wide {
+				row = append(row, cmdutil.FormatKeyValues(c.Labels))
+			}
+			rows = append(rows, row)
+		}
+		return header, rows
+	}
+}
+
+func listFilter(opts listOptions) (*server.ContainerFilter, error) {
+	filter := &server.ContainerFilter{
+		Name: opts.Name,
+	}
+
+	if opts.State != "" {
+		state, err := parseContainerState(opts.State)
+		if err != nil {
+			return nil, err
+		}
+		filter.State = &server.ContainerStateValue{State: state}
+	}
+
+	labels, err := cmdutil.ParseKeyValues(opts.Labels)
+	if err != nil {
+		return nil, err
+	}
+	filter.LabelSelector = labels
+
+	return filter, nil
+}
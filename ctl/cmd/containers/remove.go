@@ -10,11 +10,12 @@ import (
 )
 
 func init() {
+	cmdutil.AddOutputFlags(removeCmd)
 	baseCmd.AddCommand(removeCmd)
 }
 
 var removeCmd = &cobra.Command{
-	Use:   "remove <container-id>",
+	Use:   "remove <container-id|name>",
 	Short: "",
 	Long:  "",
 	Args:  cobra.ExactArgs(1),
@@ -0,0 +1,92 @@
+package containers
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+type runOptions struct {
+	AutoRemove bool
+	Detach     bool
+}
+
+var runOpts runOptions
+
+func init() {
+	addContainerFlags(runCmd)
+
+	runCmd.Flags().BoolVarP(&opts.Stdin,
+		"interactive", "i",
+		false,
+		"Pass stdin to the container")
+
+	runCmd.Flags().BoolVarP(&runOpts.AutoRemove,
+		"rm", "",
+		false,
+		"Remove the container when it exits")
+
+	runCmd.Flags().BoolVarP(&runOpts.Detach,
+		"detach", "d",
+		false,
+		"Don't attach to the container, just print its ID")
+
+	cmdutil.AddOutputFlags(runCmd)
+
+	cmdutil.RootCmd.AddCommand(runCmd)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [command options] <container-name> -- <command> [args...]",
+	Short: "Create, start, and attach to a container",
+	Long: `Create, start, and attach to a container.
+Unless detached, the exit code of the container becomes the exit code of the command.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.RunContainer(
+			context.Background(),
+			&server.RunContainerRequest{
+				Container:  containerRequest(args),
+				Attach:     !runOpts.Detach,
+				AutoRemove: runOpts.AutoRemove,
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		if runOpts.Detach {
+			cmdutil.Print(resp)
+			return
+		}
+
+		if err := stream(resp.Url, opts.Stdin); err != nil {
+			logrus.WithError(err).Error("Attach failed")
+		}
+
+		wresp, err := client.WaitContainer(
+			context.Background(),
+			&server.WaitContainerRequest{
+				ContainerId: resp.ContainerId,
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to get container exit code")
+		}
+		if wresp.Reason == "OOMKilled" {
+			logrus.Warn("Container has been killed by the OOM killer")
+		}
+
+		conn.Close()
+		os.Exit(int(wresp.ExitCode))
+	},
+}
@@ -10,11 +10,12 @@ import (
 )
 
 func init() {
+	cmdutil.AddOutputFlags(startCmd)
 	baseCmd.AddCommand(startCmd)
 }
 
 var startCmd = &cobra.Command{
-	Use:   "start <container-id>",
+	Use:   "start <container-id|name>",
 	Short: "",
 	Long:  "",
 	Args:  cobra.ExactArgs(1),
@@ -1,6 +1,9 @@
 package containers
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -10,11 +13,12 @@ import (
 )
 
 func init() {
+	cmdutil.AddOutputFlags(statusCmd)
 	baseCmd.AddCommand(statusCmd)
 }
 
 var statusCmd = &cobra.Command{
-	Use:   "status <container-id>",
+	Use:   "status <container-id|name>",
 	Short: "",
 	Long:  "",
 	Args:  cobra.ExactArgs(1),
@@ -32,6 +36,84 @@ var statusCmd = &cobra.Command{
 			logrus.WithError(err).
 				Fatal("Command failed (see conmand logs for details)")
 		}
-		cmdutil.Print(resp)
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: []interface{}{resp.Status},
+			Table: statusTable(resp.Status),
+		})
 	},
 }
+
+func statusTable(st *server.ContainerStatus) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		id := cmdutil.ShortID(st.ContainerId)
+		if wide {
+			id = st.ContainerId
+		}
+
+		rows := [][]string{
+			{"ID", id},
+			{"NAME", st.ContainerName},
+			{"STATE", strings.ToLower(st.State.String())},
+			{"IMAGE", st.Image},
+			{"CREATED", cmdutil.FullTime(st.CreatedAt)},
+			{"STARTED", cmdutil.FullTime(st.StartedAt)},
+			{"FINISHED", cmdutil.FullTime(st.FinishedAt)},
+		}
+		if st.State == server.ContainerState_EXITED {
+			rows = append(rows, []string{"EXIT", strconv.Itoa(int(st.ExitCode))})
+			if st.Reason != "" {
+				rows = append(rows, []string{"REASON", st.Reason})
+			}
+			if st.Signal != 0 {
+				rows = append(rows, []string{"SIGNAL", strconv.Itoa(int(st.Signal))})
+			}
+			if st.Message != "" {
+				rows = append(rows, []string{"MESSAGE", strings.TrimSpace(st.Message)})
+			}
+		}
+		if len(st.Labels) > 0 || wide {
+			rows = append(rows, []string{"LABELS", cmdutil.FormatKeyValues(st.Labels)})
+		}
+		if len(st.Mounts) > 0 || wide {
+			rows = append(rows, []string{"MOUNTS", formatMounts(st.Mounts)})
+		}
+		if len(st.UidMappings) > 0 {
+			rows = append(rows,
+				[]string{"UIDMAP", formatIDMappings(st.UidMappings)},
+				[]string{"GIDMAP", formatIDMappings(st.GidMappings)},
+			)
+		}
+		if ns := formatNamespaceOptions(st.NamespaceOptions); ns != "" {
+			rows = append(rows, []string{"NAMESPACES", ns})
+		}
+		if st.Privileged {
+			rows = append(rows, []string{"PRIVILEGED", "true"})
+		}
+		if len(st.Devices) > 0 || len(st.CdiDevices) > 0 || wide {
+			rows = append(rows, []string{"DEVICES", formatDevices(st.Devices, st.CdiDevices)})
+		}
+		if len(st.Rlimits) > 0 || wide {
+			rows = append(rows, []string{"ULIMITS", formatUlimits(st.Rlimits)})
+		}
+		if st.OomScoreAdj != 0 || wide {
+			rows = append(rows, []string{"OOM SCORE ADJ", strconv.Itoa(int(st.OomScoreAdj))})
+		}
+		if len(st.Sysctls) > 0 || wide {
+			rows = append(rows, []string{"SYSCTLS", cmdutil.FormatKeyValues(st.Sysctls)})
+		}
+		if wide {
+			rows = append(rows,
+				[]string{"CAPABILITIES", strings.Join(st.Capabilities, ",")},
+				[]string{"NO NEW PRIVS", strconv.FormatBool(st.NoNewPrivileges)},
+				[]string{"SECCOMP", st.SeccompProfile},
+				[]string{"MASKED PATHS", strings.Join(st.MaskedPaths, ",")},
+				[]string{"READONLY PATHS", strings.Join(st.ReadonlyPaths, ",")},
+				[]string{"TERMINATION LOG", st.TerminationMessagePath},
+				[]string{"ANNOTATIONS", cmdutil.FormatKeyValues(st.Annotations)},
+				[]string{"LOG", st.LogPath},
+			)
+		}
+		return nil, rows
+	}
+}
@@ -10,11 +10,12 @@ import (
 )
 
 func init() {
+	cmdutil.AddOutputFlags(stopCmd)
 	baseCmd.AddCommand(stopCmd)
 }
 
 var stopCmd = &cobra.Command{
-	Use:   "stop <container-id>",
+	Use:   "stop <container-id|name>",
 	Short: "",
 	Long:  "",
 	Args:  cobra.ExactArgs(1),
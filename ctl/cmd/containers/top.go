@@ -0,0 +1,82 @@
+package containers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	cmdutil.AddOutputFlags(topCmd)
+	baseCmd.AddCommand(topCmd)
+}
+
+var topCmd = &cobra.Command{
+	Use:   "top <container-id|name>",
+	Short: "List processes of a running container",
+	Long: `List processes of a running container. PID is the host one,
+while NSPID is the one seen from inside of the container.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.ContainerTop(
+			context.Background(),
+			&server.ContainerTopRequest{
+				ContainerId: args[0],
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		var items []interface{}
+		for _, p := range resp.Processes {
+			items = append(items, p)
+		}
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: items,
+			Table: processesTable(resp.Processes),
+		})
+	},
+}
+
+func processesTable(procs []*server.Process) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		header := []string{"PID", "NSPID", "USER", "TIME", "RSS", "CMD"}
+
+		var rows [][]string
+		for _, p := range procs {
+			rows = append(rows, []string{
+				strconv.Itoa(int(p.Pid)),
+				strconv.Itoa(int(p.NsPid)),
+				p.User,
+				cpuTime(p.CpuTime),
+				cmdutil.HumanSize(p.Rss),
+				p.Command,
+			})
+		}
+		return header, rows
+	}
+}
+
+// cpuTime renders the CPU time like ps does, i.e. [DD-]hh:mm:ss.
+func cpuTime(nanos int64) string {
+	secs := int64(time.Duration(nanos) / time.Second)
+	days, secs := secs/86400, secs%86400
+	s := fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs%3600/60, secs%60)
+	if days > 0 {
+		s = fmt.Sprintf("%d-%s", days, s)
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package containers
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+var waitCondition string
+
+func init() {
+	waitCmd.Flags().StringVarP(&waitCondition,
+		"condition", "c",
+		"exited",
+		"Container state to wait for (created, running, exited)")
+
+	cmdutil.AddOutputFlags(waitCmd)
+
+	baseCmd.AddCommand(waitCmd)
+}
+
+var waitCmd = &cobra.Command{
+	Use:   "wait <container-id|name> [<container-id|name>...]",
+	Short: "Block until the containers reach the condition state",
+	Long: `Block until the containers reach the condition state (or any later one).
+Prints the termination status of every container.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		condition, err := parseContainerState(waitCondition)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad condition")
+		}
+
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		for _, id := range args {
+			resp, err := client.WaitContainer(
+				context.Background(),
+				&server.WaitContainerRequest{
+					ContainerId: id,
+					Condition: &server.ContainerStateValue{
+						State: condition,
+					},
+				},
+			)
+			if err != nil {
+				logrus.WithError(err).
+					Fatal("Command failed (see conmand logs for details)")
+			}
+			cmdutil.Print(resp)
+		}
+	},
+}
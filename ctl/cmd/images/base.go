@@ -0,0 +1,23 @@
+package images
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iximiuz/conman/ctl/cmd"
+)
+
+func init() {
+	cmd.RootCmd.AddCommand(baseCmd)
+}
+
+var baseCmd = &cobra.Command{
+	Use:   "image",
+	Short: "",
+	Long:  "",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("Missed or unknown image command.\n\n")
+		cmd.Help()
+	},
+}
@@ -0,0 +1,71 @@
+package images
+
+import (
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	cmdutil.AddOutputFlags(importRootfsCmd)
+	baseCmd.AddCommand(importRootfsCmd)
+}
+
+var importRootfsCmd = &cobra.Command{
+	Use:   "import-rootfs <rootfs.tar|-> [<image-name:tag>]",
+	Short: "Create a single-layer image from a rootfs tar archive",
+	Long: `Create a single-layer image from a rootfs tar archive.
+The archive can be gzip-ed. Use - to read it from STDIN.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				logrus.WithError(err).Fatal("Can't open rootfs archive")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var name string
+		if len(args) > 1 {
+			name = args[1]
+		}
+
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		stream, err := client.ImportImage(context.Background())
+		if err == nil {
+			err = stream.Send(&server.ImportImageRequest{ImageName: name})
+		}
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		var resp *server.ImportImageResponse
+		err = cmdutil.Upload(
+			r,
+			func(data []byte) error {
+				return stream.Send(&server.ImportImageRequest{Data: data})
+			},
+			func() (err error) {
+				resp, err = stream.CloseAndRecv()
+				return err
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+		cmdutil.Print(resp)
+	},
+}
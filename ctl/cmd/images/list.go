@@ -0,0 +1,84 @@
+package images
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+var listQuiet bool
+
+func init() {
+	listCmd.Flags().BoolVarP(&listQuiet,
+		"quiet", "q",
+		false,
+		"Print only image IDs")
+
+	cmdutil.AddOutputFlags(listCmd)
+
+	baseCmd.AddCommand(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored images",
+	Long:  "",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.ListImages(
+			context.Background(),
+			&server.ListImagesRequest{},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		if listQuiet {
+			for _, img := range resp.Images {
+				fmt.Println(img.Id)
+			}
+			return
+		}
+
+		var items []interface{}
+		for _, img := range resp.Images {
+			items = append(items, img)
+		}
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: items,
+			Table: imagesTable(resp.Images),
+		})
+	},
+}
+
+func imagesTable(imgs []*server.Image) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		header := []string{"ID", "NAMES", "CREATED", "SIZE"}
+
+		var rows [][]string
+		for _, img := range imgs {
+			id := cmdutil.ShortID(img.Id)
+			if wide {
+				id = img.Id
+			}
+			rows = append(rows, []string{
+				id,
+				strings.Join(img.Names, ","),
+				cmdutil.HumanTime(img.CreatedAt),
+				cmdutil.HumanSize(img.Size),
+			})
+		}
+		return header, rows
+	}
+}
@@ -5,17 +5,42 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/iximiuz/conman/config"
 )
 
-var OptHost string
+var (
+	OptHost   string
+	OptOutput string
+	OptFormat string
+)
 
 func init() {
+	// The rootless daemon listens in the user runtime dir.
+	host := "/run/conmand.sock"
+	if config.IsRootless() {
+		host = config.Defaults().Listen
+	}
+
 	RootCmd.PersistentFlags().StringVarP(&OptHost,
 		"host", "H",
-		"/run/conmand.sock",
+		host,
 		"Daemon socket to connect")
 }
 
+// AddOutputFlags registers the output format flags. Only the
+// commands printing their results have them.
+func AddOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&OptOutput,
+		"output", "o",
+		OutputJSON,
+		"Output format (json, yaml, table, wide)")
+	cmd.Flags().StringVarP(&OptFormat,
+		"format", "",
+		"",
+		"Go template to render the output with, e.g. '{{.Name}}' (overrides --output)")
+}
+
 var RootCmd = &cobra.Command{
 	Use:   "conmanctl",
 	Short: "conmanctl - CLI tool to communicate with conmand",
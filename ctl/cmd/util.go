@@ -3,17 +3,125 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
 
 	"github.com/iximiuz/conman/server"
 )
 
+const (
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputTable = "table"
+	OutputWide  = "wide"
+)
+
+// TableFunc renders a command result as a table. Wide tables
+// are allowed to have extra columns.
+type TableFunc func(wide bool) (header []string, rows [][]string)
+
+// Output describes a command result for Print().
+type Output struct {
+	// Value is printed as is in the json and yaml formats.
+	Value interface{}
+
+	// Items, if set, are rendered one by one with the --format
+	// template. Otherwise, the template is applied to Value.
+	Items []interface{}
+
+	// Table is used for the table and wide formats. If it's not
+	// set, Value is printed as a two-column FIELD VALUE table.
+	Table TableFunc
+}
+
 func Print(v interface{}) {
-	fmt.Println(toString(v))
+	PrintOutput(Output{Value: v})
+}
+
+func PrintOutput(out Output) {
+	if OptFormat != "" {
+		printTemplate(out)
+		return
+	}
+
+	switch OptOutput {
+	case OutputJSON:
+		fmt.Println(toString(out.Value))
+	case OutputYAML:
+		y, err := yaml.JSONToYAML([]byte(toString(out.Value)))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Print(string(y))
+	case OutputTable, OutputWide:
+		table := out.Table
+		if table == nil {
+			table = fieldsTable(out.Value)
+		}
+		printTable(table(OptOutput == OutputWide))
+	default:
+		logrus.Fatalf("Unknown output format %q", OptOutput)
+	}
+}
+
+// HumanTime renders a Unix time in nanoseconds relative to now.
+func HumanTime(nanos int64) string {
+	if nanos == 0 {
+		return ""
+	}
+	return duration.HumanDuration(time.Since(time.Unix(0, nanos))) + " ago"
+}
+
+// FullTime renders a Unix time in nanoseconds as an RFC 3339 string.
+func FullTime(nanos int64) string {
+	if nanos == 0 {
+		return ""
+	}
+	return time.Unix(0, nanos).Format(time.RFC3339)
+}
+
+// HumanSize renders a size in bytes using the binary units.
+func HumanSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// ShortID truncates a container ID the same way Docker does.
+func ShortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// FormatKeyValues renders a map as sorted comma-separated key=value pairs.
+func FormatKeyValues(kv map[string]string) string {
+	var pairs []string
+	for _, k := range sortedKeys(kv) {
+		pairs = append(pairs, k+"="+kv[k])
+	}
+	return strings.Join(pairs, ",")
 }
 
 func Connect() (server.ConmanClient, *grpc.ClientConn) {
@@ -24,6 +132,108 @@ func Connect() (server.ConmanClient, *grpc.ClientConn) {
 	return server.NewConmanClient(conn), conn
 }
 
+// Upload sends the content of r to a client-side stream chunk by chunk.
+// The closeAndRecv func must finish the stream and return its error.
+func Upload(r io.Reader, send func([]byte) error, closeAndRecv func() error) error {
+	_, err := io.Copy(server.NewChunkWriter(send), r)
+
+	// If the server has aborted the call, send() returns io.EOF
+	// and the actual error comes from closeAndRecv().
+	rerr := closeAndRecv()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return rerr
+}
+
+// Download returns a reader of a server-side stream of chunks.
+func Download(recv func() ([]byte, error)) io.Reader {
+	return server.NewChunkReader(recv)
+}
+
+// ParseKeyValues turns a list of "key=value" strings into a map.
+func ParseKeyValues(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	kv := make(map[string]string)
+	for _, p := range pairs {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("bad key=value pair %q", p)
+		}
+		kv[parts[0]] = parts[1]
+	}
+	return kv, nil
+}
+
+func printTemplate(out Output) {
+	tmpl, err := template.New("format").Parse(OptFormat)
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad --format template")
+	}
+
+	items := out.Items
+	if items == nil {
+		items = []interface{}{out.Value}
+	}
+	for _, item := range items {
+		if err := tmpl.Execute(os.Stdout, item); err != nil {
+			logrus.WithError(err).Fatal("Failed to render --format template")
+		}
+		fmt.Println()
+	}
+}
+
+func printTable(header []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 3, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// fieldsTable is a fallback table representation of an arbitrary value.
+func fieldsTable(v interface{}) TableFunc {
+	return func(_ bool) ([]string, [][]string) {
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(toString(v)), &fields); err != nil {
+			return nil, [][]string{{toString(v)}}
+		}
+
+		var rows [][]string
+		for _, k := range sortedKeys(fields) {
+			val := fields[k]
+			if s, ok := val.(string); ok {
+				rows = append(rows, []string{k, s})
+			} else {
+				rows = append(rows, []string{k, toString(val)})
+			}
+		}
+		return []string{"FIELD", "VALUE"}, rows
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch mm := m.(type) {
+	case map[string]string:
+		for k := range mm {
+			keys = append(keys, k)
+		}
+	case map[string]interface{}:
+		for k := range mm {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func toString(v interface{}) string {
 	switch i := v.(type) {
 	case proto.Message:
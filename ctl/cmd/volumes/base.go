@@ -0,0 +1,23 @@
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iximiuz/conman/ctl/cmd"
+)
+
+func init() {
+	cmd.RootCmd.AddCommand(baseCmd)
+}
+
+var baseCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "",
+	Long:  "",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("Missed or unknown volume command.\n\n")
+		cmd.Help()
+	},
+}
@@ -0,0 +1,52 @@
+package volumes
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+var createLabels []string
+
+func init() {
+	createCmd.Flags().StringArrayVarP(&createLabels,
+		"label", "l",
+		nil,
+		"Set volume label (key=value, can be repeated)")
+
+	cmdutil.AddOutputFlags(createCmd)
+
+	baseCmd.AddCommand(createCmd)
+}
+
+var createCmd = &cobra.Command{
+	Use:   "create <volume-name>",
+	Short: "Create a named volume",
+	Long:  "",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		labels, err := cmdutil.ParseKeyValues(createLabels)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad label")
+		}
+
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.CreateVolume(
+			context.Background(),
+			&server.CreateVolumeRequest{
+				Name:   args[0],
+				Labels: labels,
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+		cmdutil.Print(resp)
+	},
+}
@@ -0,0 +1,38 @@
+package volumes
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	cmdutil.AddOutputFlags(inspectCmd)
+	baseCmd.AddCommand(inspectCmd)
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <volume-name>",
+	Short: "Show volume details",
+	Long:  "",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.InspectVolume(
+			context.Background(),
+			&server.InspectVolumeRequest{
+				Name: args[0],
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+		cmdutil.Print(resp)
+	},
+}
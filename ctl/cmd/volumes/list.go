@@ -0,0 +1,86 @@
+package volumes
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+var listQuiet bool
+
+func init() {
+	listCmd.Flags().BoolVarP(&listQuiet,
+		"quiet", "q",
+		false,
+		"Print only volume names")
+
+	cmdutil.AddOutputFlags(listCmd)
+
+	baseCmd.AddCommand(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List volumes",
+	Long:  "",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.ListVolumes(
+			context.Background(),
+			&server.ListVolumesRequest{},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+
+		if listQuiet {
+			for _, v := range resp.Volumes {
+				fmt.Println(v.Name)
+			}
+			return
+		}
+
+		var items []interface{}
+		for _, v := range resp.Volumes {
+			items = append(items, v)
+		}
+		cmdutil.PrintOutput(cmdutil.Output{
+			Value: resp,
+			Items: items,
+			Table: volumesTable(resp.Volumes),
+		})
+	},
+}
+
+func volumesTable(vols []*server.Volume) cmdutil.TableFunc {
+	return func(wide bool) ([]string, [][]string) {
+		header := []string{"NAME", "CREATED", "CONTAINERS"}
+		if wide {
+			header = append(header, "LABELS", "PATH")
+		}
+
+		var rows [][]string
+		for _, v := range vols {
+			row := []string{
+				v.Name,
+				cmdutil.HumanTime(v.CreatedAt),
+				strconv.Itoa(len(v.ContainerIds)),
+			}
+			if wide {
+				row = append(row, cmdutil.FormatKeyValues(v.Labels), v.Path)
+			}
+			rows = append(rows, row)
+		}
+		return header, rows
+	}
+}
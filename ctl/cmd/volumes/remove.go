@@ -0,0 +1,39 @@
+package volumes
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	cmdutil "github.com/iximiuz/conman/ctl/cmd"
+	"github.com/iximiuz/conman/server"
+)
+
+func init() {
+	cmdutil.AddOutputFlags(removeCmd)
+	baseCmd.AddCommand(removeCmd)
+}
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <volume-name>",
+	Short: "Remove a volume with its content",
+	Long: `Remove a volume with its content. Volumes referenced by
+containers (in any state) can't be removed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, conn := cmdutil.Connect()
+		defer conn.Close()
+
+		resp, err := client.RemoveVolume(
+			context.Background(),
+			&server.RemoveVolumeRequest{
+				Name: args[0],
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).
+				Fatal("Command failed (see conmand logs for details)")
+		}
+		cmdutil.Print(resp)
+	},
+}
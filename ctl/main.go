@@ -3,6 +3,8 @@ package main
 import (
 	"github.com/iximiuz/conman/ctl/cmd"
 	_ "github.com/iximiuz/conman/ctl/cmd/containers" // for init()
+	_ "github.com/iximiuz/conman/ctl/cmd/images"     // for init()
+	_ "github.com/iximiuz/conman/ctl/cmd/volumes"    // for init()
 )
 
 func main() {
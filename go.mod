@@ -5,6 +5,9 @@ go 1.16
 require (
 	github.com/emicklei/go-restful v2.11.1+incompatible // indirect
 	github.com/golang/protobuf v1.5.2
+	github.com/opencontainers/go-digest v1.0.0
+	github.com/opencontainers/image-spec v1.0.1
+	github.com/opencontainers/runtime-spec v1.0.3-0.20210326190908-1c3f411f0417
 	github.com/opencontainers/runtime-tools v0.9.0
 	github.com/pkg/errors v0.9.1
 	github.com/satori/go.uuid v1.2.0
@@ -16,9 +19,11 @@ require (
 	golang.org/x/net v0.0.0-20210520170846-37e1c6afe023
 	google.golang.org/grpc v1.38.0
 	k8s.io/api v0.22.2 // indirect
+	k8s.io/apimachinery v0.22.2
 	k8s.io/client-go v0.22.2
 	k8s.io/cri-api v0.22.2
 	k8s.io/kubernetes v1.22.2
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
@@ -0,0 +1,468 @@
+// Package archive moves files in and out of container root filesystems
+// as tar streams.
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/idmap"
+)
+
+// IDMappings are the user namespace ID mappings of a tree owned by
+// the host IDs (see idmap.Shift()). The archive entries (and the
+// changes) carry the container IDs instead, i.e. the host IDs are
+// mapped back on archiving and forth on extraction. The IDs not
+// covered by the mappings are left as is. A nil *IDMappings
+// keeps the IDs intact.
+type IDMappings struct {
+	UIDs []idmap.Mapping
+	GIDs []idmap.Mapping
+}
+
+func (m *IDMappings) containerIDs(uid, gid uint32) (uint32, uint32) {
+	if m == nil {
+		return uid, gid
+	}
+	if id, ok := idmap.ContainerID(m.UIDs, uid); ok {
+		uid = id
+	}
+	if id, ok := idmap.ContainerID(m.GIDs, gid); ok {
+		gid = id
+	}
+	return uid, gid
+}
+
+func (m *IDMappings) hostIDs(uid, gid uint32) (uint32, uint32) {
+	if m == nil {
+		return uid, gid
+	}
+	if id, ok := idmap.HostID(m.UIDs, uid); ok {
+		uid = id
+	}
+	if id, ok := idmap.HostID(m.GIDs, gid); ok {
+		gid = id
+	}
+	return uid, gid
+}
+
+// Tar writes the file or the directory tree at path (resolved within
+// root, see fsutil.OpenDirInRoot()) to w. The entries are named
+// relative to name, i.e. the path itself becomes name and its children
+// become name/<child>. Symlinks are archived as is, never followed.
+// The entries ownership is mapped back to the container IDs with ids.
+//
+// The tree is walked via the file descriptors opened with O_NOFOLLOW,
+// so a concurrent symlink swap (e.g. by a running container) can't
+// make it read the files outside of root.
+func Tar(w io.Writer, root, path, name string, ids *IDMappings) error {
+	rootf, err := os.Open(root)
+	if err != nil {
+		return err
+	}
+	defer rootf.Close()
+
+	path = filepath.Clean("/" + path)
+	parent, err := fsutil.OpenDirInRoot(rootf, filepath.Dir(path), false)
+	if err != nil {
+		return err
+	}
+	defer parent.Close()
+	base := filepath.Base(path)
+	if path == "/" {
+		base = "."
+	}
+
+	tw := tar.NewWriter(w)
+	hardlinks := make(map[uint64]string)
+
+	err = walk(parent, base, name, func(entry string, fi os.FileInfo, dir *os.File, file string) error {
+		return writeEntry(tw, dir, file, entry, hardlinks, ids)
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// walkFunc is called for every file of the walked tree. The name is
+// the file path relative to the tree (see walk()), while parent is the
+// opened parent directory of the file and base is the file name in it.
+type walkFunc func(name string, fi os.FileInfo, parent *os.File, base string) error
+
+// walk calls fn for the file base in parent and, if it's a directory,
+// for its children (in lexical order) named name/<child> recursively.
+// The directories are opened relative to their parents with O_NOFOLLOW,
+// so the walk can't leave the tree even if it's modified concurrently.
+func walk(parent *os.File, base, name string, fn walkFunc) error {
+	fi, err := fsutil.LstatatInfo(parent, base)
+	if err != nil {
+		return err
+	}
+	if err := fn(name, fi, parent, base); err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return nil
+	}
+
+	dir, err := fsutil.OpenAt(parent, base, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		if err := walk(dir, n, name+"/"+n, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkTree walks the dir tree naming the files by their absolute
+// paths with dir being "" (see walk()).
+func walkTree(dir string, fn walkFunc) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return walk(f, ".", "", fn)
+}
+
+// writeEntry writes a single (non-recursive) entry for the file base
+// in dir. If hardlinks is set, it's used to archive the hardlinks as
+// such.
+func writeEntry(
+	tw *tar.Writer,
+	dir *os.File,
+	base, name string,
+	hardlinks map[uint64]string,
+	ids *IDMappings,
+) error {
+	fi, err := fsutil.LstatatInfo(dir, base)
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket != 0 {
+		return nil // not representable in tar
+	}
+
+	var link string
+	var f *os.File
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		if link, err = fsutil.Readlinkat(dir, base); err != nil {
+			return err
+		}
+	case fi.Mode().IsRegular():
+		// Fails if the file has been swapped for a symlink.
+		if f, err = fsutil.OpenAt(dir, base, syscall.O_RDONLY|syscall.O_NOFOLLOW, 0); err != nil {
+			return err
+		}
+		defer f.Close()
+		if fi, err = f.Stat(); err != nil {
+			return err
+		}
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if fi.IsDir() {
+		hdr.Name += "/"
+	}
+	hdr.Uname, hdr.Gname = "", ""
+	uid, gid := ids.containerIDs(uint32(hdr.Uid), uint32(hdr.Gid))
+	hdr.Uid, hdr.Gid = int(uid), int(gid)
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if ok && hardlinks != nil && fi.Mode().IsRegular() && st.Nlink > 1 {
+		if first, ok := hardlinks[st.Ino]; ok {
+			hdr.Typeflag = tar.TypeLink
+			hdr.Linkname = first
+			hdr.Size = 0
+		} else {
+			hardlinks[st.Ino] = name
+		}
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	_, err = io.CopyN(tw, f, hdr.Size)
+	return err
+}
+
+// Untar extracts the tar stream from r into dir resolved within root.
+// If rename is set, the top-level entry of the archive is extracted
+// under this name instead. Every entry is resolved within dir (see
+// fsutil.OpenDirInRoot()) and created relative to its opened parent
+// directory without following symlinks, hence neither .. components
+// nor symlinks (including the ones from the archive itself and the
+// ones swapped in concurrently, e.g. by a running container) can make
+// it write outside of dir. The entries ownership is mapped to the host
+// IDs with ids (if run as root).
+func Untar(r io.Reader, root, dir, rename string, ids *IDMappings) error {
+	rootf, err := os.Open(root)
+	if err != nil {
+		return err
+	}
+	defer rootf.Close()
+
+	dirf, err := fsutil.OpenDirInRoot(rootf, dir, false)
+	if err != nil {
+		return err
+	}
+	defer dirf.Close()
+
+	tr := tar.NewReader(r)
+	chown := os.Geteuid() == 0
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Unprivileged users can't create device nodes (the
+		// rootless containers get the devices from the runtime).
+		if !chown && (hdr.Typeflag == tar.TypeChar || hdr.Typeflag == tar.TypeBlock) {
+			continue
+		}
+
+		name := entryName(hdr.Name, rename)
+		if err := untarEntry(tr, hdr, dirf, name, rename, chown, ids); err != nil {
+			return errors.Wrapf(err, "can't extract %s", hdr.Name)
+		}
+	}
+}
+
+func untarEntry(
+	tr *tar.Reader,
+	hdr *tar.Header,
+	dir *os.File,
+	name, rename string,
+	chown bool,
+	ids *IDMappings,
+) error {
+	// Only the parent is resolved, the entry itself is
+	// replaced or created but never followed.
+	parent, err := fsutil.OpenDirInRoot(dir, filepath.Dir(name), true)
+	if err != nil {
+		return err
+	}
+	defer parent.Close()
+
+	base := filepath.Base(name)
+	if name == "." {
+		if hdr.Typeflag != tar.TypeDir {
+			return errors.New("can't replace the target directory")
+		}
+		base = "."
+	}
+
+	if err := extractEntry(tr, hdr, dir, parent, base, rename); err != nil {
+		return err
+	}
+
+	if chown {
+		uid, gid := ids.hostIDs(uint32(hdr.Uid), uint32(hdr.Gid))
+		if err := fsutil.Lchownat(parent, base, int(uid), int(gid)); err != nil {
+			return err
+		}
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	// The mode and the times are set via the file descriptor
+	// (see chmod(2) on /proc/self/fd/<O_PATH fd>), so a file
+	// swapped for a symlink meanwhile isn't followed.
+	f, err := fsutil.OpenPathAt(parent, base)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return errors.New("replaced with a symlink")
+	}
+
+	proc := fmt.Sprintf("/proc/self/fd/%d", f.Fd())
+	if err := os.Chmod(proc, hdr.FileInfo().Mode()); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeDir {
+		// Best effort, the content matters more.
+		_ = os.Chtimes(proc, hdr.ModTime, hdr.ModTime)
+	}
+	return nil
+}
+
+// Target figures out where a copied path ends up. If dst is an existing
+// directory (with symlinks resolved within root), the copy is placed inside
+// of it. Otherwise, the copy is placed into the parent directory of dst
+// and renamed to the dst base name. The returned dir is the resolved
+// path within root (i.e. with root being "/"), it's resolved within
+// root once again on extraction (see Untar()).
+func Target(root, dst string) (dir, rename string, err error) {
+	resolved, err := fsutil.SecureJoin(root, dst)
+	if err != nil {
+		return "", "", err
+	}
+	if fi, err := os.Stat(resolved); err == nil && fi.IsDir() {
+		return inRoot(root, resolved), "", nil
+	}
+	if strings.HasSuffix(dst, "/") {
+		return "", "", errors.Errorf("%s: no such directory", dst)
+	}
+
+	resolved, err = fsutil.SecureJoin(root, filepath.Dir(dst))
+	if err != nil {
+		return "", "", err
+	}
+	if fi, err := os.Stat(resolved); err != nil || !fi.IsDir() {
+		return "", "", errors.Errorf("%s: no such directory", filepath.Dir(dst))
+	}
+	return inRoot(root, resolved), filepath.Base(dst), nil
+}
+
+// inRoot turns the path joined to root back into the path within root.
+func inRoot(root, path string) string {
+	rel, err := filepath.Rel(filepath.Clean(root), path)
+	if err != nil {
+		return "/" // never happens for the SecureJoin() results
+	}
+	return filepath.Join("/", rel)
+}
+
+// entryName replaces the top-level component of the archive entry name
+// if rename is set and cleans up the result making it relative.
+func entryName(name, rename string) string {
+	if rename != "" {
+		name = strings.TrimPrefix(name, "/")
+		if i := strings.IndexByte(name, '/'); i != -1 {
+			name = rename + "/" + name[i+1:]
+		} else {
+			name = rename
+		}
+	}
+
+	name = strings.TrimPrefix(filepath.Clean("/"+name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// extractEntry creates the file base in parent replacing the existing
+// one (if any). The hard links are resolved within dir.
+func extractEntry(
+	tr *tar.Reader,
+	hdr *tar.Header,
+	dir, parent *os.File,
+	base, rename string,
+) error {
+	st, err := fsutil.Lstatat(parent, base)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	exists := err == nil
+	isDir := exists && st.Mode&syscall.S_IFMT == syscall.S_IFDIR
+
+	if hdr.Typeflag == tar.TypeDir {
+		if isDir {
+			return nil
+		}
+		if exists {
+			if err := fsutil.Unlinkat(parent, base, false); err != nil {
+				return err
+			}
+		}
+		return fsutil.Mkdirat(parent, base, 0700)
+	}
+
+	if exists {
+		if err := fsutil.RemoveAllAt(parent, base); err != nil {
+			return err
+		}
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeReg:
+		f, err := fsutil.OpenAt(
+			parent,
+			base,
+			os.O_CREATE|os.O_EXCL|os.O_WRONLY|syscall.O_NOFOLLOW,
+			0600,
+		)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+
+	case tar.TypeSymlink:
+		return fsutil.Symlinkat(hdr.Linkname, parent, base)
+
+	case tar.TypeLink:
+		src := entryName(hdr.Linkname, rename)
+		srcParent, err := fsutil.OpenDirInRoot(dir, filepath.Dir(src), false)
+		if err != nil {
+			return err
+		}
+		defer srcParent.Close()
+		return fsutil.Linkat(srcParent, filepath.Base(src), parent, base)
+
+	case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+		mode := uint32(hdr.Mode & 07777)
+		switch hdr.Typeflag {
+		case tar.TypeChar:
+			mode |= syscall.S_IFCHR
+		case tar.TypeBlock:
+			mode |= syscall.S_IFBLK
+		case tar.TypeFifo:
+			mode |= syscall.S_IFIFO
+		}
+		dev := int(mkdev(hdr.Devmajor, hdr.Devminor))
+		return fsutil.Mknodat(parent, base, mode, dev)
+	}
+
+	return errors.Errorf("unsupported entry type %q", hdr.Typeflag)
+}
+
+func mkdev(major, minor int64) uint64 {
+	return uint64(minor&0xff) | uint64(major&0xfff)<<8 |
+		uint64(minor&^0xff)<<12 | uint64(major&^0xfff)<<32
+}
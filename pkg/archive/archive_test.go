@@ -0,0 +1,338 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestTarUntar(t *testing.T) {
+	src := testutil.TempDir(t, "src")
+	defer os.RemoveAll(src)
+	dst := testutil.TempDir(t, "dst")
+	defer os.RemoveAll(dst)
+
+	must(t, os.MkdirAll(filepath.Join(src, "out", "sub"), 0755))
+	must(t, ioutil.WriteFile(filepath.Join(src, "out", "a.txt"), []byte("foo"), 0644))
+	must(t, os.Link(filepath.Join(src, "out", "a.txt"), filepath.Join(src, "out", "sub", "b.txt")))
+	must(t, os.Symlink("/etc/passwd", filepath.Join(src, "out", "passwd")))
+
+	var buf bytes.Buffer
+	if err := archive.Tar(&buf, src, "out", "out", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Untar(&buf, dst, "/", "artifacts", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dst, "artifacts", "sub", "b.txt"))
+	if err != nil || string(data) != "foo" {
+		t.Fatalf("Unexpected hardlink content %q: %v", data, err)
+	}
+	link, err := os.Readlink(filepath.Join(dst, "artifacts", "passwd"))
+	if err != nil || link != "/etc/passwd" {
+		t.Fatalf("Unexpected symlink %q: %v", link, err)
+	}
+}
+
+func TestUntarNoEscape(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	root := filepath.Join(dir, "root")
+	must(t, os.Mkdir(root, 0755))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	must(t, tw.WriteHeader(&tar.Header{
+		Name:     "up",
+		Typeflag: tar.TypeSymlink,
+		Linkname: "..",
+	}))
+	for _, name := range []string{"../evil", "up/evil"} {
+		must(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     4,
+		}))
+		_, err := tw.Write([]byte("evil"))
+		must(t, err)
+	}
+	must(t, tw.Close())
+
+	if err := archive.Untar(&buf, root, "/", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil")); !os.IsNotExist(err) {
+		t.Fatal("Untar() wrote outside of the target directory")
+	}
+	if _, err := os.Stat(filepath.Join(root, "evil")); err != nil {
+		t.Fatal("Untar() must have written the entry into the target directory")
+	}
+}
+
+// A running container can swap the directories for symlinks
+// while copying (see CVE-2018-15664).
+func TestTarUntarSymlinkSwap(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	root := filepath.Join(dir, "root")
+	outside := filepath.Join(dir, "outside")
+	must(t, os.MkdirAll(filepath.Join(root, "data"), 0755))
+	must(t, os.Mkdir(outside, 0755))
+	must(t, ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644))
+
+	var layer bytes.Buffer
+	tw := tar.NewWriter(&layer)
+	must(t, tw.WriteHeader(&tar.Header{Name: "data/evil", Mode: 0644, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	must(t, err)
+	must(t, tw.Close())
+
+	done := make(chan struct{})
+	swapped := make(chan struct{})
+	go func() {
+		defer close(swapped)
+		data, tmp := filepath.Join(root, "data"), filepath.Join(root, "data.tmp")
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			// Untar() can recreate the dir meanwhile.
+			_ = os.Rename(data, tmp)
+			_ = os.Symlink(outside, data)
+			_ = os.RemoveAll(data)
+			_ = os.Rename(tmp, data)
+		}
+	}()
+
+	for i := 0; i < 2000; i++ {
+		// Both can fail, but never escape.
+		_ = archive.Untar(bytes.NewReader(layer.Bytes()), root, "/", "", nil)
+
+		var buf bytes.Buffer
+		if err := archive.Tar(&buf, root, "/data", "data", nil); err == nil {
+			if strings.Contains(buf.String(), "secret") {
+				t.Fatal("Tar() read outside of the root")
+			}
+		}
+	}
+	close(done)
+	<-swapped
+
+	if _, err := os.Stat(filepath.Join(outside, "evil")); !os.IsNotExist(err) {
+		t.Fatal("Untar() wrote outside of the root")
+	}
+}
+
+func TestTarget(t *testing.T) {
+	root := testutil.TempDir(t)
+	defer os.RemoveAll(root)
+	must(t, os.Mkdir(filepath.Join(root, "tmp"), 0755))
+	must(t, os.Symlink("/tmp", filepath.Join(root, "link")))
+
+	dir, rename, err := archive.Target(root, "/link")
+	if err != nil || dir != "/tmp" || rename != "" {
+		t.Fatalf("Unexpected target %q %q: %v", dir, rename, err)
+	}
+
+	dir, rename, err = archive.Target(root, "/link/new.txt")
+	if err != nil || dir != "/tmp" || rename != "new.txt" {
+		t.Fatalf("Unexpected target %q %q: %v", dir, rename, err)
+	}
+
+	if _, _, err := archive.Target(root, "/missing/new.txt"); err == nil {
+		t.Fatal("Target() must fail if the parent directory doesn't exist")
+	}
+}
+
+func TestTarUntarIDMappings(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+
+	src := testutil.TempDir(t, "src")
+	defer os.RemoveAll(src)
+	dst := testutil.TempDir(t, "dst")
+	defer os.RemoveAll(dst)
+
+	ids := &archive.IDMappings{
+		UIDs: []idmap.Mapping{{ContainerID: 0, HostID: 100000, Size: 1000}},
+		GIDs: []idmap.Mapping{{ContainerID: 0, HostID: 200000, Size: 1000}},
+	}
+
+	must(t, ioutil.WriteFile(filepath.Join(src, "mapped"), nil, 0644))
+	must(t, os.Chown(filepath.Join(src, "mapped"), 100005, 200005))
+	must(t, ioutil.WriteFile(filepath.Join(src, "unmapped"), nil, 0644))
+	must(t, os.Chown(filepath.Join(src, "unmapped"), 5000, 5000))
+
+	var buf bytes.Buffer
+	must(t, archive.Tar(&buf, src, "/", ".", ids))
+
+	var raw bytes.Buffer
+	tr := tar.NewReader(io.TeeReader(&buf, &raw))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		must(t, err)
+		switch hdr.Name {
+		case "./mapped":
+			if hdr.Uid != 5 || hdr.Gid != 5 {
+				t.Fatalf("unexpected %s owner %d:%d", hdr.Name, hdr.Uid, hdr.Gid)
+			}
+		case "./unmapped":
+			if hdr.Uid != 5000 || hdr.Gid != 5000 {
+				t.Fatalf("unexpected %s owner %d:%d", hdr.Name, hdr.Uid, hdr.Gid)
+			}
+		}
+	}
+
+	must(t, archive.Untar(&raw, dst, "/", "", ids))
+	for name, owner := range map[string][2]uint32{
+		"mapped":   {100005, 200005},
+		"unmapped": {5000, 5000},
+	} {
+		fi, err := os.Lstat(filepath.Join(dst, name))
+		must(t, err)
+		st := fi.Sys().(*syscall.Stat_t)
+		if st.Uid != owner[0] || st.Gid != owner[1] {
+			t.Fatalf("unexpected %s owner %d:%d", name, st.Uid, st.Gid)
+		}
+	}
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	base := testutil.TempDir(t, "base")
+	defer os.RemoveAll(base)
+	dir := testutil.TempDir(t, "dir")
+	defer os.RemoveAll(dir)
+
+	must(t, os.MkdirAll(filepath.Join(base, "etc"), 0755))
+	must(t, os.MkdirAll(filepath.Join(base, "var", "cache"), 0755))
+	must(t, ioutil.WriteFile(filepath.Join(base, "etc", "hosts"), []byte("foo"), 0644))
+	must(t, ioutil.WriteFile(filepath.Join(base, "etc", "passwd"), []byte("bar"), 0644))
+	must(t, ioutil.WriteFile(filepath.Join(base, "var", "cache", "x"), []byte("baz"), 0644))
+	must(t, exec.Command("cp", "-a", base+"/.", dir).Run())
+
+	must(t, ioutil.WriteFile(filepath.Join(dir, "etc", "hosts"), []byte("changed"), 0644))
+	must(t, os.MkdirAll(filepath.Join(dir, "out", "sub"), 0755))
+	must(t, os.RemoveAll(filepath.Join(dir, "var", "cache")))
+	must(t, os.Remove(filepath.Join(dir, "etc", "passwd")))
+
+	changes, err := archive.Changes(base, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, c := range changes {
+		actual = append(actual, c.String())
+	}
+	expected := []string{
+		"C /etc/hosts",
+		"D /etc/passwd",
+		"A /out",
+		"A /out/sub",
+		"D /var/cache",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected changes %v, expected %v", actual, expected)
+	}
+
+	var buf bytes.Buffer
+	if err := archive.ChangesTar(&buf, dir, changes, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		must(t, err)
+		names = append(names, hdr.Name)
+	}
+	expected = []string{"etc/hosts", "etc/.wh.passwd", "out/", "out/sub/", "var/.wh.cache"}
+	if strings.Join(names, " ") != strings.Join(expected, " ") {
+		t.Fatalf("Unexpected layer entries %v, expected %v", names, expected)
+	}
+}
+
+func TestSnapshotChanges(t *testing.T) {
+	dir := testutil.TempDir(t, "dir")
+	defer os.RemoveAll(dir)
+
+	must(t, os.MkdirAll(filepath.Join(dir, "etc"), 0755))
+	must(t, os.MkdirAll(filepath.Join(dir, "var", "cache"), 0755))
+	must(t, ioutil.WriteFile(filepath.Join(dir, "etc", "hosts"), []byte("foo"), 0644))
+	must(t, ioutil.WriteFile(filepath.Join(dir, "var", "cache", "x"), []byte("baz"), 0644))
+	must(t, os.Symlink("hosts", filepath.Join(dir, "etc", "link")))
+
+	snap, err := archive.TakeSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "..", filepath.Base(dir)+".snapshot.json")
+	defer os.Remove(file)
+	must(t, snap.Write(file))
+	snap, err = archive.ReadSnapshot(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := snap.Changes(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Unexpected changes %v", changes)
+	}
+
+	// A dir replaced by a file hides the deleted dir content.
+	must(t, os.RemoveAll(filepath.Join(dir, "var", "cache")))
+	must(t, ioutil.WriteFile(filepath.Join(dir, "var", "cache"), nil, 0644))
+	must(t, os.Remove(filepath.Join(dir, "etc", "link")))
+	must(t, os.Symlink("passwd", filepath.Join(dir, "etc", "link")))
+	must(t, os.Chmod(filepath.Join(dir, "etc", "hosts"), 0600))
+
+	changes, err = snap.Changes(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, c := range changes {
+		actual = append(actual, c.String())
+	}
+	expected := []string{
+		"C /etc/hosts",
+		"C /etc/link",
+		"C /var/cache",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected changes %v, expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,277 @@
+package archive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+)
+
+type ChangeKind int
+
+const (
+	ChangeModify ChangeKind = iota
+	ChangeAdd
+	ChangeDelete
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeModify:
+		return "C"
+	case ChangeAdd:
+		return "A"
+	case ChangeDelete:
+		return "D"
+	}
+	return "?"
+}
+
+// Change is a filesystem change. Path is absolute with the
+// root of the compared trees being "/".
+type Change struct {
+	Path string
+	Kind ChangeKind
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s", c.Kind, c.Path)
+}
+
+// WhiteoutPrefix marks deleted files in OCI image layers.
+const WhiteoutPrefix = ".wh."
+
+// Changes compares the dir tree with the base tree it's been copied
+// from (the copy must preserve the metadata, e.g. cp -a). See
+// Snapshot.Changes() for the details.
+func Changes(base, dir string) ([]Change, error) {
+	snap, err := TakeSnapshot(base)
+	if err != nil {
+		return nil, err
+	}
+	return snap.Changes(dir, nil)
+}
+
+// Meta is the file metadata the changes are told by.
+type Meta struct {
+	Mode    os.FileMode `json:"mode"`
+	UID     uint32      `json:"uid"`
+	GID     uint32      `json:"gid"`
+	Rdev    uint64      `json:"rdev,omitempty"`
+	Size    int64       `json:"size"`
+	ModTime time.Time   `json:"mtime"`
+	// Symlink target.
+	Link string `json:"link,omitempty"`
+}
+
+// Snapshot is the metadata of a tree. The keys are absolute paths
+// with the root of the tree being "/" (the root itself isn't included).
+type Snapshot map[string]Meta
+
+// TakeSnapshot records the metadata of the dir tree.
+func TakeSnapshot(dir string) (Snapshot, error) {
+	snap := make(Snapshot)
+	err := walkTree(dir, func(name string, fi os.FileInfo, parent *os.File, base string) error {
+		if name == "" {
+			return nil
+		}
+
+		meta, err := fileMeta(parent, base, fi)
+		if err != nil {
+			return err
+		}
+		snap[name] = meta
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// ReadSnapshot reads the snapshot written by Snapshot.Write().
+func ReadSnapshot(file string) (Snapshot, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("bad snapshot %s: %v", file, err)
+	}
+	return snap, nil
+}
+
+func (s Snapshot) Write(file string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// Changes compares the dir tree with the snapshot of the tree it's
+// been copied from. Files are compared by their metadata only: type,
+// mode, ownership, size, mtime and symlink target. If a directory is
+// added, every path in it is reported as added too, while a deleted
+// directory is reported alone. The result is sorted by path, hence
+// the parent directories always precede their children. The dir tree
+// ownership is mapped back with ids (see Tar()) before comparing.
+func (s Snapshot) Changes(dir string, ids *IDMappings) ([]Change, error) {
+	var changes []Change
+	// The modes of the existing files.
+	seen := make(map[string]os.FileMode)
+
+	err := walkTree(dir, func(name string, fi os.FileInfo, parent *os.File, base string) error {
+		if name == "" {
+			return nil
+		}
+
+		old, ok := s[name]
+		if !ok {
+			changes = append(changes, Change{Path: name, Kind: ChangeAdd})
+			return nil
+		}
+		seen[name] = fi.Mode()
+
+		meta, err := fileMeta(parent, base, fi)
+		if err != nil {
+			return err
+		}
+		meta.UID, meta.GID = ids.containerIDs(meta.UID, meta.GID)
+		if old.Mode.IsDir() != fi.IsDir() {
+			// A file replaced a dir or vice versa: the old
+			// content is gone as a whole.
+			changes = append(changes, Change{Path: name, Kind: ChangeModify})
+			return nil
+		}
+		if metaChanged(old, meta) {
+			changes = append(changes, Change{Path: name, Kind: ChangeModify})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The dirs deleted or replaced as a whole (see above).
+	gone := make(map[string]bool)
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if goneParent(gone, name) {
+			continue
+		}
+		mode, ok := seen[name]
+		if !ok {
+			changes = append(changes, Change{Path: name, Kind: ChangeDelete})
+			gone[name] = true
+		} else if s[name].Mode.IsDir() && !mode.IsDir() {
+			gone[name] = true
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+func goneParent(gone map[string]bool, name string) bool {
+	for p := filepath.Dir(name); p != "/"; p = filepath.Dir(p) {
+		if gone[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangesTar writes the changes of the dir tree as an OCI image layer,
+// i.e. the added and modified paths are archived as is (directories
+// non-recursively) and the deleted paths become whiteout files. The
+// ownership is mapped back with ids (see Tar()).
+func ChangesTar(w io.Writer, dir string, changes []Change, ids *IDMappings) error {
+	root, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	tw := tar.NewWriter(w)
+
+	for _, c := range changes {
+		name := strings.TrimPrefix(c.Path, "/")
+
+		if c.Kind == ChangeDelete {
+			wh := filepath.Join(filepath.Dir(name), WhiteoutPrefix+filepath.Base(name))
+			if err := tw.WriteHeader(&tar.Header{
+				Name:     wh,
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The running container can modify the tree meanwhile.
+		parent, err := fsutil.OpenDirInRoot(root, filepath.Dir(name), false)
+		if err != nil {
+			return err
+		}
+		err = writeEntry(tw, parent, filepath.Base(name), name, nil, ids)
+		parent.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+func fileMeta(parent *os.File, base string, fi os.FileInfo) (Meta, error) {
+	meta := Meta{
+		Mode:    fi.Mode(),
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		meta.UID, meta.GID, meta.Rdev = st.Uid, st.Gid, uint64(st.Rdev)
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		link, err := fsutil.Readlinkat(parent, base)
+		if err != nil {
+			return Meta{}, err
+		}
+		meta.Link = link
+	}
+	return meta, nil
+}
+
+func metaChanged(base, meta Meta) bool {
+	if base.Mode != meta.Mode ||
+		base.UID != meta.UID || base.GID != meta.GID || base.Rdev != meta.Rdev {
+		return true
+	}
+	if meta.Mode&os.ModeSymlink != 0 {
+		return base.Link != meta.Link
+	}
+	if meta.Mode.IsDir() {
+		// Directory mtime changes every time an entry is added or
+		// removed. These are reported on their own.
+		return false
+	}
+	return base.Size != meta.Size || !base.ModTime.Equal(meta.ModTime)
+}
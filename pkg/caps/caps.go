@@ -0,0 +1,190 @@
+// Package caps validates and combines Linux capability sets.
+package caps
+
+import (
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// All stands for every capability supported by the host.
+const All = "ALL"
+
+// Indexed by the capability number (see capabilities(7)).
+var names = []string{
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_DAC_READ_SEARCH",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_KILL",
+	"CAP_SETGID",
+	"CAP_SETUID",
+	"CAP_SETPCAP",
+	"CAP_LINUX_IMMUTABLE",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_NET_BROADCAST",
+	"CAP_NET_ADMIN",
+	"CAP_NET_RAW",
+	"CAP_IPC_LOCK",
+	"CAP_IPC_OWNER",
+	"CAP_SYS_MODULE",
+	"CAP_SYS_RAWIO",
+	"CAP_SYS_CHROOT",
+	"CAP_SYS_PTRACE",
+	"CAP_SYS_PACCT",
+	"CAP_SYS_ADMIN",
+	"CAP_SYS_BOOT",
+	"CAP_SYS_NICE",
+	"CAP_SYS_RESOURCE",
+	"CAP_SYS_TIME",
+	"CAP_SYS_TTY_CONFIG",
+	"CAP_MKNOD",
+	"CAP_LEASE",
+	"CAP_AUDIT_WRITE",
+	"CAP_AUDIT_CONTROL",
+	"CAP_SETFCAP",
+	"CAP_MAC_OVERRIDE",
+	"CAP_MAC_ADMIN",
+	"CAP_SYSLOG",
+	"CAP_WAKE_ALARM",
+	"CAP_BLOCK_SUSPEND",
+	"CAP_AUDIT_READ",
+	"CAP_PERFMON",
+	"CAP_BPF",
+	"CAP_CHECKPOINT_RESTORE",
+}
+
+var numbers = func() map[string]int {
+	m := make(map[string]int)
+	for i, name := range names {
+		m[name] = i
+	}
+	return m
+}()
+
+var (
+	lastCapOnce sync.Once
+	lastCap     int
+)
+
+// hostLastCap returns the number of the last capability known
+// to both the host kernel and this package.
+func hostLastCap() int {
+	lastCapOnce.Do(func() {
+		lastCap = len(names) - 1
+		data, err := ioutil.ReadFile("/proc/sys/kernel/cap_last_cap")
+		if err != nil {
+			return
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && n < lastCap {
+			lastCap = n
+		}
+	})
+	return lastCap
+}
+
+// Host returns all the capabilities supported by the host.
+func Host() []string {
+	return append([]string(nil), names[:hostLastCap()+1]...)
+}
+
+// Normalize turns net_admin, NET_ADMIN, or cap_net_admin into
+// CAP_NET_ADMIN. ALL is returned as is.
+func Normalize(name string) (string, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == All {
+		return name, nil
+	}
+	if !strings.HasPrefix(name, "CAP_") {
+		name = "CAP_" + name
+	}
+
+	n, ok := numbers[name]
+	if !ok {
+		return "", errors.Errorf("unknown capability %s", name)
+	}
+	if n > hostLastCap() {
+		return "", errors.Errorf("capability %s is not supported by the host", name)
+	}
+	return name, nil
+}
+
+// NormalizeList normalizes the capabilities, dropping duplicates.
+func NormalizeList(list []string) ([]string, error) {
+	var rv []string
+	seen := make(map[string]bool)
+	for _, name := range list {
+		name, err := Normalize(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			rv = append(rv, name)
+		}
+	}
+	return rv, nil
+}
+
+// Merge adds and drops the capabilities from the base set. ALL in
+// the add list grants everything supported by the host, ALL in the
+// drop list starts from scratch. The result is ordered by the
+// capability number.
+func Merge(base, add, drop []string) ([]string, error) {
+	base, err := NormalizeList(base)
+	if err != nil {
+		return nil, err
+	}
+	add, err = NormalizeList(add)
+	if err != nil {
+		return nil, err
+	}
+	drop, err = NormalizeList(drop)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped := make(map[string]bool)
+	for _, name := range drop {
+		dropped[name] = true
+	}
+	for _, name := range add {
+		if name != All && dropped[name] {
+			return nil, errors.Errorf("capability %s is both added and dropped", name)
+		}
+	}
+
+	set := make(map[string]bool)
+	if !dropped[All] {
+		for _, name := range base {
+			set[name] = true
+		}
+	}
+	for _, name := range add {
+		if name == All {
+			for _, name := range Host() {
+				set[name] = true
+			}
+		} else {
+			set[name] = true
+		}
+	}
+	for _, name := range drop {
+		delete(set, name)
+	}
+	delete(set, All)
+
+	rv := make([]string, 0, len(set))
+	for name := range set {
+		rv = append(rv, name)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return numbers[rv[i]] < numbers[rv[j]]
+	})
+	return rv, nil
+}
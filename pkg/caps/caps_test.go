@@ -0,0 +1,89 @@
+package caps_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/caps"
+)
+
+func TestNormalize(t *testing.T) {
+	for _, name := range []string{"net_admin", "NET_ADMIN", "cap_net_admin", " CAP_NET_ADMIN"} {
+		if n, err := caps.Normalize(name); err != nil || n != "CAP_NET_ADMIN" {
+			t.Errorf("unexpected %q normalized to %q, error %v", name, n, err)
+		}
+	}
+	if n, err := caps.Normalize("all"); err != nil || n != caps.All {
+		t.Errorf("unexpected all normalized to %q, error %v", n, err)
+	}
+	if _, err := caps.Normalize("NET_FOO"); err == nil {
+		t.Error("expected error for unknown capability")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := []string{"CAP_CHOWN", "CAP_KILL", "CAP_SETUID"}
+
+	cases := []struct {
+		add      []string
+		drop     []string
+		expected []string
+		err      string
+	}{
+		{
+			expected: base,
+		},
+		{
+			add:      []string{"sys_admin", "chown"},
+			drop:     []string{"kill"},
+			expected: []string{"CAP_CHOWN", "CAP_SETUID", "CAP_SYS_ADMIN"},
+		},
+		{
+			add:      []string{"NET_ADMIN"},
+			drop:     []string{"ALL"},
+			expected: []string{"CAP_NET_ADMIN"},
+		},
+		{
+			drop:     []string{"all"},
+			expected: []string{},
+		},
+		{
+			add:  []string{"CHOWN"},
+			drop: []string{"cap_chown"},
+			err:  "capability CAP_CHOWN is both added and dropped",
+		},
+		{
+			add: []string{"FOO"},
+			err: "unknown capability CAP_FOO",
+		},
+	}
+
+	for i, c := range cases {
+		merged, err := caps.Merge(base, c.add, c.drop)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("case %d: expected error %q, got %v", i, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: unexpected error %v", i, err)
+		} else if !reflect.DeepEqual(merged, c.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, c.expected, merged)
+		}
+	}
+
+	all, err := caps.Merge(nil, []string{"ALL"}, []string{"SYS_ADMIN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(caps.Host())-1 {
+		t.Errorf("unexpected number of capabilities %d", len(all))
+	}
+	for _, name := range all {
+		if name == "CAP_SYS_ADMIN" {
+			t.Error("CAP_SYS_ADMIN is not dropped")
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package cdi implements the Container Device Interface. The vendors
+// describe their devices in the spec files, and the containers request
+// them by the fully-qualified names, e.g. vendor.com/gpu=gpu0. Every
+// device comes with the edits of the container spec (device nodes,
+// mounts, environment, and hooks) exposing the device.
+package cdi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+type Spec struct {
+	Version        string         `json:"cdiVersion"`
+	Kind           string         `json:"kind"`
+	Devices        []Device       `json:"devices"`
+	ContainerEdits ContainerEdits `json:"containerEdits,omitempty"`
+}
+
+type Device struct {
+	Name           string         `json:"name"`
+	ContainerEdits ContainerEdits `json:"containerEdits"`
+}
+
+type ContainerEdits struct {
+	Env         []string      `json:"env,omitempty"`
+	DeviceNodes []*DeviceNode `json:"deviceNodes,omitempty"`
+	Hooks       []*Hook       `json:"hooks,omitempty"`
+	Mounts      []*Mount      `json:"mounts,omitempty"`
+}
+
+// DeviceNode is a device file created in the container. The type and
+// the numbers are taken from the host device if omitted.
+type DeviceNode struct {
+	Path string `json:"path"`
+	// Defaults to Path.
+	HostPath    string       `json:"hostPath,omitempty"`
+	Type        string       `json:"type,omitempty"`
+	Major       int64        `json:"major,omitempty"`
+	Minor       int64        `json:"minor,omitempty"`
+	FileMode    *os.FileMode `json:"fileMode,omitempty"`
+	Permissions string       `json:"permissions,omitempty"`
+	UID         *uint32      `json:"uid,omitempty"`
+	GID         *uint32      `json:"gid,omitempty"`
+}
+
+type Mount struct {
+	HostPath      string   `json:"hostPath"`
+	ContainerPath string   `json:"containerPath"`
+	Options       []string `json:"options,omitempty"`
+	Type          string   `json:"type,omitempty"`
+}
+
+// Hook is an OCI hook, HookName is the OCI hook type
+// (prestart, createRuntime, createContainer, etc).
+type Hook struct {
+	HookName string   `json:"hookName"`
+	Path     string   `json:"path"`
+	Args     []string `json:"args,omitempty"`
+	Env      []string `json:"env,omitempty"`
+	Timeout  *int     `json:"timeout,omitempty"`
+}
+
+var (
+	kindRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9])?$`)
+	nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.:-]*[a-zA-Z0-9])?$`)
+)
+
+// IsQualifiedName tells if the name looks like vendor.com/class=name.
+func IsQualifiedName(name string) bool {
+	_, _, err := ParseQualifiedName(name)
+	return err == nil
+}
+
+// ParseQualifiedName splits vendor.com/class=name into
+// the kind (vendor.com/class) and the device name.
+func ParseQualifiedName(name string) (kind, device string, err error) {
+	parts := strings.SplitN(name, "=", 2)
+	if len(parts) != 2 || !kindRegexp.MatchString(parts[0]) || !nameRegexp.MatchString(parts[1]) {
+		return "", "", errors.Errorf("bad CDI device name %q, expected vendor.com/class=name", name)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Registry holds the devices of the spec files found in the spec dirs.
+type Registry struct {
+	// Qualified name -> device.
+	devices map[string]*device
+}
+
+type device struct {
+	Device
+	spec *Spec
+}
+
+// Load reads the *.json and *.yaml spec files. The broken files are
+// skipped, so that a single vendor can't break all the CDI devices.
+// The devices defined in the latter directories override the former
+// ones. The missing directories are ignored.
+func Load(dirs []string) (*Registry, error) {
+	r := &Registry{devices: make(map[string]*device)}
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "read CDI spec dir")
+		}
+
+		for _, fi := range files {
+			ext := filepath.Ext(fi.Name())
+			if fi.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+
+			path := filepath.Join(dir, fi.Name())
+			spec, err := readSpec(path)
+			if err != nil {
+				logrus.WithError(err).Warnf("Skipping CDI spec %s", path)
+				continue
+			}
+			for _, d := range spec.Devices {
+				r.devices[spec.Kind+"="+d.Name] = &device{Device: d, spec: spec}
+			}
+		}
+	}
+	return r, nil
+}
+
+func readSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if spec.Version == "" {
+		return nil, errors.New("missing cdiVersion")
+	}
+	if !kindRegexp.MatchString(spec.Kind) {
+		return nil, errors.Errorf("bad kind %q", spec.Kind)
+	}
+	for _, d := range spec.Devices {
+		if !nameRegexp.MatchString(d.Name) {
+			return nil, errors.Errorf("bad device name %q", d.Name)
+		}
+		if err := d.ContainerEdits.validate(); err != nil {
+			return nil, errors.Wrapf(err, "device %s", d.Name)
+		}
+	}
+	if err := spec.ContainerEdits.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (e ContainerEdits) validate() error {
+	for _, env := range e.Env {
+		if !strings.Contains(env, "=") {
+			return errors.Errorf("bad environment variable %q", env)
+		}
+	}
+	for _, n := range e.DeviceNodes {
+		if n == nil || !filepath.IsAbs(n.Path) {
+			return errors.New("device node path must be absolute")
+		}
+		if strings.Trim(n.Permissions, "rwm") != "" {
+			return errors.Errorf("bad device node %s permissions %q", n.Path, n.Permissions)
+		}
+	}
+	for _, m := range e.Mounts {
+		if m == nil || !filepath.IsAbs(m.HostPath) || !filepath.IsAbs(m.ContainerPath) {
+			return errors.New("mount paths must be absolute")
+		}
+	}
+	for _, h := range e.Hooks {
+		if h == nil || !filepath.IsAbs(h.Path) {
+			return errors.New("hook path must be absolute")
+		}
+	}
+	return nil
+}
+
+// Devices returns the sorted qualified names of the known devices.
+func (r *Registry) Devices() []string {
+	var names []string
+	for name := range r.devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Edits merges the edits of the requested devices. The edits common
+// to all the devices of a spec file are applied once.
+func (r *Registry) Edits(names []string) (ContainerEdits, error) {
+	var edits ContainerEdits
+	specs := make(map[*Spec]bool)
+	for _, name := range names {
+		if _, _, err := ParseQualifiedName(name); err != nil {
+			return ContainerEdits{}, err
+		}
+		d, ok := r.devices[name]
+		if !ok {
+			return ContainerEdits{}, errors.Errorf("unresolvable CDI device %s", name)
+		}
+		if !specs[d.spec] {
+			specs[d.spec] = true
+			edits.append(d.spec.ContainerEdits)
+		}
+		edits.append(d.ContainerEdits)
+	}
+	return edits, nil
+}
+
+func (e *ContainerEdits) append(o ContainerEdits) {
+	e.Env = append(e.Env, o.Env...)
+	e.DeviceNodes = append(e.DeviceNodes, o.DeviceNodes...)
+	e.Hooks = append(e.Hooks, o.Hooks...)
+	e.Mounts = append(e.Mounts, o.Mounts...)
+}
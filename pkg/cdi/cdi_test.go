@@ -0,0 +1,115 @@
+package cdi_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/cdi"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+const vendorSpec = `
+cdiVersion: "0.5.0"
+kind: vendor.com/device
+devices:
+  - name: dev0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/vendor0
+          hostPath: /dev/null
+      env:
+        - VENDOR_DEVICE=0
+  - name: dev1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/vendor1
+          hostPath: /dev/zero
+containerEdits:
+  env:
+    - VENDOR_DRIVER=1
+  mounts:
+    - hostPath: /usr/lib/vendor
+      containerPath: /usr/lib/vendor
+      options: [ro]
+`
+
+const overrideSpec = `{
+  "cdiVersion": "0.5.0",
+  "kind": "vendor.com/device",
+  "devices": [{
+    "name": "dev1",
+    "containerEdits": {"deviceNodes": [{"path": "/dev/vendor1", "hostPath": "/dev/full"}]}
+  }]
+}`
+
+func writeSpec(t *testing.T, dir, name, content string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	etc := testutil.TempDir(t, "etc")
+	defer os.RemoveAll(etc)
+	run := testutil.TempDir(t, "run")
+	defer os.RemoveAll(run)
+
+	writeSpec(t, etc, "vendor.yaml", vendorSpec)
+	writeSpec(t, etc, "broken.json", `{"cdiVersion": "0.5.0", "kind": "bad kind"}`)
+	writeSpec(t, etc, "README", "not a spec")
+	writeSpec(t, run, "vendor.json", overrideSpec)
+
+	registry, err := cdi.Load([]string{etc, run, "/does/not/exist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"vendor.com/device=dev0", "vendor.com/device=dev1"}
+	if !reflect.DeepEqual(registry.Devices(), expected) {
+		t.Fatalf("unexpected devices %v", registry.Devices())
+	}
+
+	edits, err := registry.Edits([]string{"vendor.com/device=dev0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(edits.Env, []string{"VENDOR_DRIVER=1", "VENDOR_DEVICE=0"}) {
+		t.Errorf("unexpected env %v", edits.Env)
+	}
+	if len(edits.DeviceNodes) != 1 || edits.DeviceNodes[0].HostPath != "/dev/null" {
+		t.Errorf("unexpected device nodes %+v", edits.DeviceNodes)
+	}
+	if len(edits.Mounts) != 1 || edits.Mounts[0].ContainerPath != "/usr/lib/vendor" {
+		t.Errorf("unexpected mounts %+v", edits.Mounts)
+	}
+
+	// Overridden by the latter spec dir (with no common edits).
+	edits, err = registry.Edits([]string{"vendor.com/device=dev1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits.DeviceNodes) != 1 || edits.DeviceNodes[0].HostPath != "/dev/full" || len(edits.Env) != 0 {
+		t.Errorf("unexpected edits %+v", edits)
+	}
+
+	if _, err := registry.Edits([]string{"vendor.com/device=dev2"}); err == nil {
+		t.Error("unknown device is expected to fail")
+	}
+	if _, err := registry.Edits([]string{"dev0"}); err == nil {
+		t.Error("unqualified device name is expected to fail")
+	}
+}
+
+func TestParseQualifiedName(t *testing.T) {
+	kind, name, err := cdi.ParseQualifiedName("nvidia.com/gpu=all")
+	if err != nil || kind != "nvidia.com/gpu" || name != "all" {
+		t.Errorf("unexpected result %q, %q, %v", kind, name, err)
+	}
+	for _, n := range []string{"", "/dev/fuse", "nvidia.com/gpu", "nvidia.com=0", "nvidia.com/gpu=", "vendor/cl ass=x"} {
+		if cdi.IsQualifiedName(n) {
+			t.Errorf("%q is not expected to be a qualified name", n)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package cgroups inspects the cgroup hierarchy of the host.
+package cgroups
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+const mountPoint = "/sys/fs/cgroup"
+
+// IsV2 tells if the host uses the unified (v2) hierarchy.
+func IsV2() bool {
+	_, err := os.Stat(filepath.Join(mountPoint, "cgroup.controllers"))
+	return err == nil
+}
+
+// Delegated tells if the current user can create the container
+// cgroups. The OCI runtime creates them next to its own cgroup,
+// so the parent of the current cgroup has to be writable. Only
+// the unified hierarchy supports delegation to unprivileged users.
+func Delegated() bool {
+	if os.Geteuid() == 0 {
+		return true
+	}
+	if !IsV2() {
+		return false
+	}
+
+	own, err := procCgroup("/proc/self/cgroup", "")
+	if err != nil {
+		return false
+	}
+	dir := filepath.Join(mountPoint, filepath.Dir(own))
+	return syscall.Access(dir, 0x2 /* W_OK */) == nil
+}
+
+// procCgroup returns the cgroup path of the process in the hierarchy
+// of the (v1) controller, or in the unified hierarchy if empty.
+func procCgroup(path, controller string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if controller == "" && parts[0] == "0" && parts[1] == "" {
+			return parts[2], nil
+		}
+		for _, c := range strings.Split(parts[1], ",") {
+			if controller != "" && c == controller {
+				return parts[2], nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if controller == "" {
+		return "", errors.Errorf("no unified hierarchy entry in %s", path)
+	}
+	return "", errors.Errorf("no %s hierarchy entry in %s", controller, path)
+}
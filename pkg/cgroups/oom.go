@@ -0,0 +1,110 @@
+package cgroups
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// MemoryDir returns the memory cgroup directory of the process,
+// i.e. its unified hierarchy cgroup on v2.
+func MemoryDir(pid int) (string, error) {
+	procFile := fmt.Sprintf("/proc/%d/cgroup", pid)
+	if IsV2() {
+		path, err := procCgroup(procFile, "")
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(mountPoint, path), nil
+	}
+
+	path, err := procCgroup(procFile, "memory")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mountPoint, "memory", path), nil
+}
+
+// OOMKillCount returns the number of the processes killed by the OOM
+// killer in the memory cgroup: the oom_kill counter of memory.events
+// on v2 and of memory.oom_control on v1 (Linux 4.13+).
+func OOMKillCount(dir string) (uint64, error) {
+	file := filepath.Join(dir, "memory.events")
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		file = filepath.Join(dir, "memory.oom_control")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// NotifyOOM subscribes to the OOM events of the v1 memory cgroup via
+// an eventfd registered in cgroup.event_control. The channel receives
+// a value on every event and gets closed once the cgroup is removed.
+// The v2 cgroups keep the counters in memory.events instead.
+func NotifyOOM(dir string) (<-chan struct{}, error) {
+	control, err := os.Open(filepath.Join(dir, "memory.oom_control"))
+	if err != nil {
+		return nil, err
+	}
+
+	efd, _, errno := syscall.RawSyscall(syscall.SYS_EVENTFD2, 0, syscall.O_CLOEXEC, 0)
+	if errno != 0 {
+		control.Close()
+		return nil, errors.Wrap(errno, "eventfd")
+	}
+	event := os.NewFile(efd, "eventfd")
+
+	err = ioutil.WriteFile(
+		filepath.Join(dir, "cgroup.event_control"),
+		[]byte(fmt.Sprintf("%d %d", event.Fd(), control.Fd())),
+		0,
+	)
+	if err != nil {
+		event.Close()
+		control.Close()
+		return nil, errors.Wrap(err, "register OOM eventfd")
+	}
+
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		defer control.Close()
+		defer event.Close()
+
+		buf := make([]byte, 8)
+		for {
+			if _, err := event.Read(buf); err != nil {
+				return
+			}
+			// The cgroup removal is notified too.
+			if _, err := os.Stat(filepath.Join(dir, "cgroup.event_control")); err != nil {
+				return
+			}
+			ch <- struct{}{}
+		}
+	}()
+	return ch, nil
+}
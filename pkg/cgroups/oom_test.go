@@ -0,0 +1,43 @@
+package cgroups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/cgroups"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestOOMKillCount(t *testing.T) {
+	cases := []struct {
+		file     string
+		content  string
+		expected uint64
+	}{
+		{"memory.events", "low 0\nhigh 0\nmax 12\noom 3\noom_kill 2\n", 2},
+		{"memory.events", "low 0\nhigh 0\nmax 0\noom 0\n", 0},
+		{"memory.oom_control", "oom_kill_disable 0\nunder_oom 0\noom_kill 1\n", 1},
+	}
+
+	for _, c := range cases {
+		dir := testutil.TempDir(t)
+		defer os.RemoveAll(dir)
+		if err := ioutil.WriteFile(filepath.Join(dir, c.file), []byte(c.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := cgroups.OOMKillCount(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != c.expected {
+			t.Errorf("%s: expected %d, got %d", c.file, c.expected, count)
+		}
+	}
+
+	if _, err := cgroups.OOMKillCount("/does/not/exist"); err == nil {
+		t.Error("missing cgroup is expected to fail")
+	}
+}
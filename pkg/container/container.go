@@ -3,13 +3,24 @@ package container
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/rlimit"
 )
 
 const timeFormat = time.RFC3339
 
+// Container is safe for concurrent use. Every modification produces
+// a new immutable snapshot of the container attributes, so readers
+// never block, even while a (slow) lifecycle operation is in progress.
 type Container struct {
-	impl
+	mu    sync.Mutex   // serializes writers
+	state atomic.Value // holds the current impl snapshot
 }
 
 type impl struct {
@@ -17,6 +28,9 @@ type impl struct {
 	Name_     string `json:"name"`
 	Status_   Status `json:"status"`
 	ExitCode_ int32  `json:"exitCode"`
+	Signal_   int32  `json:"signal,omitempty"`
+	Reason_   string `json:"reason,omitempty"`
+	Message_  string `json:"message,omitempty"`
 
 	CreatedAt_  string `json:"createdAt"`
 	StartedAt_  string `json:"startedAt,omitempty"`
@@ -27,37 +41,87 @@ type impl struct {
 
 	Rootfs_ string `json:"rootfs"`
 
+	// Set only if the container has been created from a stored image.
+	Image_   string `json:"image,omitempty"`
+	ImageID_ string `json:"imageId,omitempty"`
+
+	Mounts_ []Mount `json:"mounts,omitempty"`
+
+	SeccompProfile_  string   `json:"seccompProfile,omitempty"`
+	Capabilities_    []string `json:"capabilities,omitempty"`
+	Privileged_      bool     `json:"privileged,omitempty"`
+	NoNewPrivileges_ bool     `json:"noNewPrivileges,omitempty"`
+
+	// Set only if the container has its own user namespace.
+	UIDMappings_ []idmap.Mapping `json:"uidMappings,omitempty"`
+	GIDMappings_ []idmap.Mapping `json:"gidMappings,omitempty"`
+
+	// Nil if all the namespaces are private.
+	Namespaces_ *NamespaceOptions `json:"namespaces,omitempty"`
+
+	MaskedPaths_   []string          `json:"maskedPaths,omitempty"`
+	ReadonlyPaths_ []string          `json:"readonlyPaths,omitempty"`
+	Sysctls_       map[string]string `json:"sysctls,omitempty"`
+
+	Devices_ []Device `json:"devices,omitempty"`
+	// Qualified CDI device names, e.g. vendor.com/class=name.
+	CDIDevices_ []string `json:"cdiDevices,omitempty"`
+
+	Rlimits_     []rlimit.Rlimit `json:"rlimits,omitempty"`
+	OOMScoreAdj_ int             `json:"oomScoreAdj,omitempty"`
+
+	// Where the container process can leave the termination message.
+	TerminationMessagePath_ string `json:"terminationMessagePath,omitempty"`
+
+	Labels_      map[string]string `json:"labels,omitempty"`
+	Annotations_ map[string]string `json:"annotations,omitempty"`
+
 	LogPath_ string `json:"logPath,omitempty"`
+
+	// Remove the container once it has stopped.
+	AutoRemove_ bool `json:"autoRemove,omitempty"`
+
+	CgroupDir_ string `json:"cgroupDir,omitempty"`
 }
 
 func New(
 	id ID,
 	name string,
 	logPath string,
+	labels map[string]string,
+	annotations map[string]string,
 ) (*Container, error) {
 	if !isValidName(name) {
 		return nil, errors.New("Invalid container name")
 	}
+	if err := validateKeys(labels); err != nil {
+		return nil, errors.New("Invalid container labels: " + err.Error())
+	}
+	if err := validateKeys(annotations); err != nil {
+		return nil, errors.New("Invalid container annotations: " + err.Error())
+	}
 
-	return &Container{
-		impl{
-			ID_:      id,
-			Name_:    name,
-			LogPath_: logPath,
-		},
-	}, nil
+	c := &Container{}
+	c.state.Store(impl{
+		ID_:          id,
+		Name_:        name,
+		LogPath_:     logPath,
+		Labels_:      copyMap(labels),
+		Annotations_: copyMap(annotations),
+	})
+	return c, nil
 }
 
 func (c *Container) ID() ID {
-	return c.ID_
+	return c.load().ID_
 }
 
 func (c *Container) Name() string {
-	return c.Name_
+	return c.load().Name_
 }
 
 func (c *Container) CreatedAt() string {
-	return c.CreatedAt_
+	return c.load().CreatedAt_
 }
 
 func (c *Container) CreatedAtNano() int64 {
@@ -65,78 +129,484 @@ func (c *Container) CreatedAtNano() int64 {
 }
 
 func (c *Container) SetCreatedAt(t time.Time) error {
-	if c.CreatedAt_ != "" {
-		return errors.New("CreatedAt has been already set")
-	}
-	c.CreatedAt_ = t.Format(timeFormat)
-	return nil
+	return c.update(func(s *impl) error {
+		if s.CreatedAt_ != "" {
+			return errors.New("CreatedAt has been already set")
+		}
+		s.CreatedAt_ = t.Format(timeFormat)
+		return nil
+	})
 }
 
 func (c *Container) StartedAt() string {
-	return c.StartedAt_
+	return c.load().StartedAt_
 }
 
 func (c *Container) StartedAtNano() int64 {
-	if c.StartedAt_ == "" {
+	startedAt := c.StartedAt()
+	if startedAt == "" {
 		return 0
 	}
-	return unixNanoTime(c.StartedAt())
+	return unixNanoTime(startedAt)
 }
 
 func (c *Container) SetStartedAt(t time.Time) error {
-	if c.StartedAt_ != "" {
-		return errors.New("StartedAt has been already set")
-	}
-	c.StartedAt_ = t.Format(timeFormat)
-	return nil
+	return c.update(func(s *impl) error {
+		if s.StartedAt_ != "" {
+			return errors.New("StartedAt has been already set")
+		}
+		s.StartedAt_ = t.Format(timeFormat)
+		return nil
+	})
 }
 
 func (c *Container) FinishedAt() string {
-	return c.FinishedAt_
+	return c.load().FinishedAt_
 }
 
 func (c *Container) FinishedAtNano() int64 {
-	if c.FinishedAt_ == "" {
+	finishedAt := c.FinishedAt()
+	if finishedAt == "" {
 		return 0
 	}
-	return unixNanoTime(c.FinishedAt())
+	return unixNanoTime(finishedAt)
 }
 
 func (c *Container) SetFinishedAt(t time.Time) error {
-	finishedAt := t.Format(timeFormat)
-	if c.FinishedAt_ == "" || c.FinishedAt_ == finishedAt {
-		c.FinishedAt_ = finishedAt
-		return nil
-	}
-	return errors.New("FinishedAt has been already set")
+	return c.update(func(s *impl) error {
+		finishedAt := t.Format(timeFormat)
+		if s.FinishedAt_ == "" || s.FinishedAt_ == finishedAt {
+			s.FinishedAt_ = finishedAt
+			return nil
+		}
+		return errors.New("FinishedAt has been already set")
+	})
 }
 
 func (c *Container) Status() Status {
-	return c.Status_
+	return c.load().Status_
 }
 
-func (c *Container) SetStatus(s Status) {
-	c.Status_ = s
+func (c *Container) SetStatus(st Status) {
+	c.update(func(s *impl) error {
+		s.Status_ = st
+		return nil
+	})
 }
 
 func (c *Container) ExitCode() int32 {
-	return c.ExitCode_
+	return c.load().ExitCode_
 }
 
 func (c *Container) SetExitCode(code int32) {
-	c.ExitCode_ = code
+	c.update(func(s *impl) error {
+		s.ExitCode_ = code
+		return nil
+	})
+}
+
+// Signal returns the number of the signal that terminated
+// the container, or 0 if the container exited on its own.
+func (c *Container) Signal() int32 {
+	return c.load().Signal_
+}
+
+func (c *Container) SetSignal(sig int32) {
+	c.update(func(s *impl) error {
+		s.Signal_ = sig
+		return nil
+	})
+}
+
+// Reason returns the brief termination reason, e.g. ReasonOOMKilled,
+// or an empty string if the container hasn't stopped yet.
+func (c *Container) Reason() string {
+	return c.load().Reason_
+}
+
+func (c *Container) SetReason(reason string) {
+	c.update(func(s *impl) error {
+		s.Reason_ = reason
+		return nil
+	})
+}
+
+// Message returns the termination message of the container, either
+// written by the container process or the tail of the container log.
+func (c *Container) Message() string {
+	return c.load().Message_
+}
+
+func (c *Container) SetMessage(message string) {
+	c.update(func(s *impl) error {
+		s.Message_ = message
+		return nil
+	})
+}
+
+// CgroupDir returns the memory cgroup directory of the container,
+// or an empty string if the container has no cgroup of its own.
+func (c *Container) CgroupDir() string {
+	return c.load().CgroupDir_
+}
+
+func (c *Container) SetCgroupDir(dir string) {
+	c.update(func(s *impl) error {
+		s.CgroupDir_ = dir
+		return nil
+	})
+}
+
+// AutoRemove tells if the container is removed once it has stopped.
+func (c *Container) AutoRemove() bool {
+	return c.load().AutoRemove_
+}
+
+func (c *Container) SetAutoRemove(autoRemove bool) {
+	c.update(func(s *impl) error {
+		s.AutoRemove_ = autoRemove
+		return nil
+	})
 }
 
 func (c *Container) LogPath() string {
-	return c.LogPath_
+	return c.load().LogPath_
+}
+
+// Rootfs returns the path to the rootfs (image)
+// the container has been created from.
+func (c *Container) Rootfs() string {
+	return c.load().Rootfs_
+}
+
+func (c *Container) SetRootfs(path string) {
+	c.update(func(s *impl) error {
+		s.Rootfs_ = path
+		return nil
+	})
+}
+
+// Image returns the image reference the container
+// has been created from, if any.
+func (c *Container) Image() string {
+	return c.load().Image_
+}
+
+func (c *Container) ImageID() string {
+	return c.load().ImageID_
+}
+
+func (c *Container) SetImage(ref, id string) {
+	c.update(func(s *impl) error {
+		s.Image_ = ref
+		s.ImageID_ = id
+		return nil
+	})
+}
+
+// Mounts returns the container mounts. The returned
+// slice is shared and must not be modified.
+func (c *Container) Mounts() []Mount {
+	return c.load().Mounts_
+}
+
+func (c *Container) SetMounts(mounts []Mount) error {
+	mounts = append([]Mount(nil), mounts...)
+	dests := make(map[string]bool)
+	for i, m := range mounts {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("invalid mount %s: %v", m.Destination, err)
+		}
+		mounts[i].Destination = path.Clean(m.Destination)
+		if dests[mounts[i].Destination] {
+			return fmt.Errorf("duplicate mount destination %s", mounts[i].Destination)
+		}
+		dests[mounts[i].Destination] = true
+	}
+
+	return c.update(func(s *impl) error {
+		s.Mounts_ = mounts
+		return nil
+	})
+}
+
+// SeccompProfile returns the seccomp profile name (unconfined,
+// runtime/default, or localhost/<path>).
+func (c *Container) SeccompProfile() string {
+	return c.load().SeccompProfile_
+}
+
+func (c *Container) SetSeccompProfile(profile string) {
+	c.update(func(s *impl) error {
+		s.SeccompProfile_ = profile
+		return nil
+	})
+}
+
+// Capabilities returns the container process capabilities. The
+// returned slice is shared and must not be modified.
+func (c *Container) Capabilities() []string {
+	return c.load().Capabilities_
+}
+
+func (c *Container) SetCapabilities(caps []string) {
+	caps = append([]string(nil), caps...)
+	c.update(func(s *impl) error {
+		s.Capabilities_ = caps
+		return nil
+	})
+}
+
+func (c *Container) Privileged() bool {
+	return c.load().Privileged_
+}
+
+func (c *Container) SetPrivileged(privileged bool) {
+	c.update(func(s *impl) error {
+		s.Privileged_ = privileged
+		return nil
+	})
+}
+
+func (c *Container) NoNewPrivileges() bool {
+	return c.load().NoNewPrivileges_
+}
+
+func (c *Container) SetNoNewPrivileges(noNewPrivileges bool) {
+	c.update(func(s *impl) error {
+		s.NoNewPrivileges_ = noNewPrivileges
+		return nil
+	})
+}
+
+// UIDMappings returns the user namespace UID mappings (with the
+// actual host IDs). The returned slice is shared and must not be
+// modified.
+func (c *Container) UIDMappings() []idmap.Mapping {
+	return c.load().UIDMappings_
+}
+
+// GIDMappings returns the user namespace GID mappings (with the
+// actual host IDs). The returned slice is shared and must not be
+// modified.
+func (c *Container) GIDMappings() []idmap.Mapping {
+	return c.load().GIDMappings_
+}
+
+func (c *Container) SetIDMappings(uidMap, gidMap []idmap.Mapping) {
+	uidMap = append([]idmap.Mapping(nil), uidMap...)
+	gidMap = append([]idmap.Mapping(nil), gidMap...)
+	c.update(func(s *impl) error {
+		s.UIDMappings_ = uidMap
+		s.GIDMappings_ = gidMap
+		return nil
+	})
+}
+
+func (c *Container) Namespaces() NamespaceOptions {
+	if ns := c.load().Namespaces_; ns != nil {
+		return *ns
+	}
+	return NamespaceOptions{}
+}
+
+func (c *Container) SetNamespaces(opts NamespaceOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid namespace options: %v", err)
+	}
+	return c.update(func(s *impl) error {
+		s.Namespaces_ = nil
+		if opts != (NamespaceOptions{}) {
+			s.Namespaces_ = &opts
+		}
+		return nil
+	})
+}
+
+// MaskedPaths returns the paths masked in the container. The
+// returned slice is shared and must not be modified.
+func (c *Container) MaskedPaths() []string {
+	return c.load().MaskedPaths_
+}
+
+// ReadonlyPaths returns the paths mounted read-only in the container.
+// The returned slice is shared and must not be modified.
+func (c *Container) ReadonlyPaths() []string {
+	return c.load().ReadonlyPaths_
+}
+
+func (c *Container) SetMaskedPaths(masked, readonly []string) error {
+	masked, err := cleanPaths(masked)
+	if err != nil {
+		return fmt.Errorf("invalid masked path: %v", err)
+	}
+	readonly, err = cleanPaths(readonly)
+	if err != nil {
+		return fmt.Errorf("invalid readonly path: %v", err)
+	}
+	return c.update(func(s *impl) error {
+		s.MaskedPaths_ = masked
+		s.ReadonlyPaths_ = readonly
+		return nil
+	})
+}
+
+func cleanPaths(paths []string) ([]string, error) {
+	var cleaned []string
+	for _, p := range paths {
+		if !path.IsAbs(p) {
+			return nil, fmt.Errorf("%q is not absolute", p)
+		}
+		cleaned = append(cleaned, path.Clean(p))
+	}
+	return cleaned, nil
+}
+
+// Sysctls returns the container sysctls. The returned
+// map is shared and must not be modified.
+func (c *Container) Sysctls() map[string]string {
+	return c.load().Sysctls_
+}
+
+func (c *Container) SetSysctls(sysctls map[string]string) {
+	sysctls = copyMap(sysctls)
+	c.update(func(s *impl) error {
+		s.Sysctls_ = sysctls
+		return nil
+	})
+}
+
+// Devices returns the host devices exposed to the container.
+// The returned slice is shared and must not be modified.
+func (c *Container) Devices() []Device {
+	return c.load().Devices_
+}
+
+// CDIDevices returns the CDI devices exposed to the container.
+// The returned slice is shared and must not be modified.
+func (c *Container) CDIDevices() []string {
+	return c.load().CDIDevices_
+}
+
+func (c *Container) SetDevices(devices []Device, cdiDevices []string) error {
+	devices = append([]Device(nil), devices...)
+	paths := make(map[string]bool)
+	for i, d := range devices {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("invalid device %s: %v", d.HostPath, err)
+		}
+		devices[i].ContainerPath = path.Clean(d.ContainerPath)
+		if paths[devices[i].ContainerPath] {
+			return fmt.Errorf("duplicate device path %s", devices[i].ContainerPath)
+		}
+		paths[devices[i].ContainerPath] = true
+	}
+	cdiDevices = append([]string(nil), cdiDevices...)
+
+	return c.update(func(s *impl) error {
+		s.Devices_ = devices
+		s.CDIDevices_ = cdiDevices
+		return nil
+	})
+}
+
+// Rlimits returns the container process resource limits. The
+// returned slice is shared and must not be modified.
+func (c *Container) Rlimits() []rlimit.Rlimit {
+	return c.load().Rlimits_
+}
+
+func (c *Container) SetRlimits(rlimits []rlimit.Rlimit) error {
+	for _, r := range rlimits {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid rlimit: %v", err)
+		}
+	}
+	rlimits = append([]rlimit.Rlimit(nil), rlimits...)
+	return c.update(func(s *impl) error {
+		s.Rlimits_ = rlimits
+		return nil
+	})
+}
+
+// OOMScoreAdj returns the container process oom_score_adj,
+// 0 stands for the one inherited from the OCI runtime.
+func (c *Container) OOMScoreAdj() int {
+	return c.load().OOMScoreAdj_
+}
+
+func (c *Container) SetOOMScoreAdj(adj int) error {
+	if adj < -1000 || adj > 1000 {
+		return fmt.Errorf("invalid oom_score_adj %d, expected [-1000, 1000]", adj)
+	}
+	return c.update(func(s *impl) error {
+		s.OOMScoreAdj_ = adj
+		return nil
+	})
+}
+
+// TerminationMessagePath returns the container path of the
+// termination message file.
+func (c *Container) TerminationMessagePath() string {
+	return c.load().TerminationMessagePath_
+}
+
+func (c *Container) SetTerminationMessagePath(p string) error {
+	if !path.IsAbs(p) {
+		return fmt.Errorf("termination message path %q is not absolute", p)
+	}
+	p = path.Clean(p)
+	if p == "/" {
+		return errors.New("termination message path can't be /")
+	}
+	return c.update(func(s *impl) error {
+		s.TerminationMessagePath_ = p
+		return nil
+	})
+}
+
+// Labels returns the container labels. The returned
+// map is shared and must not be modified.
+func (c *Container) Labels() map[string]string {
+	return c.load().Labels_
+}
+
+// Annotations returns the container annotations. The
+// returned map is shared and must not be modified.
+func (c *Container) Annotations() map[string]string {
+	return c.load().Annotations_
 }
 
 func (c *Container) MarshalJSON() ([]byte, error) {
-	return json.Marshal(c.impl)
+	return json.Marshal(c.load())
 }
 
 func (c *Container) UnmarshalJSON(bytes []byte) error {
-	return json.Unmarshal(bytes, &c.impl)
+	return c.update(func(s *impl) error {
+		return json.Unmarshal(bytes, s)
+	})
+}
+
+func (c *Container) load() impl {
+	if s, ok := c.state.Load().(impl); ok {
+		return s
+	}
+	return impl{}
+}
+
+// update applies fn to a copy of the current snapshot and publishes
+// the result only if fn succeeds. Slices and maps of the snapshot
+// are shared between copies and must never be modified in place.
+func (c *Container) update(fn func(*impl) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.load()
+	if err := fn(&s); err != nil {
+		return err
+	}
+	c.state.Store(s)
+	return nil
 }
 
 func isValidName(name string) bool {
@@ -148,10 +618,31 @@ func isValidName(name string) bool {
 	return len(name) > 0 && len(name) <= 32
 }
 
+func validateKeys(m map[string]string) error {
+	for k := range m {
+		if k == "" {
+			return errors.New("empty key")
+		}
+	}
+	return nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	cp := make(map[string]string, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
+
+// unixNanoTime returns 0 for the unset (or malformed) times.
 func unixNanoTime(s string) int64 {
 	t, err := time.Parse(timeFormat, s)
 	if err != nil {
-		panic(err)
+		return 0
 	}
 	return t.UnixNano()
 }
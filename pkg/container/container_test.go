@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/iximiuz/conman/pkg/container"
 )
@@ -39,3 +40,58 @@ func TestMarshalUnmarshalJSON(t *testing.T) {
 			bytes1, bytes2)
 	}
 }
+
+func TestNewCopiesLabelsAndAnnotations(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	annotations := map[string]string{"com.example.note": "hi"}
+
+	cont, err := container.New(container.RandID(), "cont1", "", labels, annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels["app"] = "db"
+	annotations["com.example.note"] = "bye"
+
+	if cont.Labels()["app"] != "web" {
+		t.Fatal("Unexpected labels", cont.Labels())
+	}
+	if cont.Annotations()["com.example.note"] != "hi" {
+		t.Fatal("Unexpected annotations", cont.Annotations())
+	}
+
+	blob, err := json.Marshal(cont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := &container.Container{}
+	if err := json.Unmarshal(blob, restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Labels()["app"] != "web" {
+		t.Fatal("Labels have not been restored", restored.Labels())
+	}
+}
+
+func TestNewInvalidLabels(t *testing.T) {
+	_, err := container.New(container.RandID(), "cont1", "", map[string]string{"": "x"}, nil)
+	if err == nil {
+		t.Fatal("Expected New() to fail")
+	}
+}
+
+func TestTimesUnsetOrMalformed(t *testing.T) {
+	cont := &container.Container{}
+	if err := json.Unmarshal([]byte(`{"id":"1","finishedAt":"yesterday"}`), cont); err != nil {
+		t.Fatal(err)
+	}
+	if cont.CreatedAtNano() != 0 || cont.StartedAtNano() != 0 || cont.FinishedAtNano() != 0 {
+		t.Fatal("Unset and malformed times are expected to be zero")
+	}
+
+	if err := cont.SetCreatedAt(time.Unix(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if cont.CreatedAtNano() != int64(time.Second) {
+		t.Fatalf("Unexpected createdAt %d", cont.CreatedAtNano())
+	}
+}
@@ -0,0 +1,40 @@
+package container
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// Device is a host device exposed to the container.
+type Device struct {
+	HostPath      string `json:"hostPath"`
+	ContainerPath string `json:"containerPath"`
+	// Any combination of r (read), w (write), and m (mknod).
+	Permissions string `json:"permissions"`
+}
+
+func (d Device) Validate() error {
+	if !path.IsAbs(d.HostPath) {
+		return errors.New("device host path must be absolute")
+	}
+	if !path.IsAbs(d.ContainerPath) {
+		return errors.New("device container path must be absolute")
+	}
+	if !isValidDevicePermissions(d.Permissions) {
+		return errors.New("device permissions must be a combination of r, w, and m")
+	}
+	return nil
+}
+
+func isValidDevicePermissions(perms string) bool {
+	if perms == "" || len(perms) > 3 {
+		return false
+	}
+	for _, c := range perms {
+		if strings.Count(perms, string(c)) > 1 || !strings.ContainsRune("rwm", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestSetDevices(t *testing.T) {
+	cases := []struct {
+		devices []container.Device
+		valid   bool
+	}{
+		{nil, true},
+		{[]container.Device{
+			{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rwm"},
+			{HostPath: "/dev/null", ContainerPath: "/dev/mynull", Permissions: "r"},
+		}, true},
+		{[]container.Device{{HostPath: "dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rwm"}}, false},
+		{[]container.Device{{HostPath: "/dev/fuse", ContainerPath: "dev/fuse", Permissions: "rwm"}}, false},
+		{[]container.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse"}}, false},
+		{[]container.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rx"}}, false},
+		{[]container.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rr"}}, false},
+		{[]container.Device{
+			{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rwm"},
+			{HostPath: "/dev/null", ContainerPath: "/dev/fuse/", Permissions: "rwm"},
+		}, false},
+	}
+
+	for _, c := range cases {
+		cont := testutil.NewContainer()
+		err := cont.SetDevices(c.devices, nil)
+		if c.valid && err != nil {
+			t.Errorf("unexpected error %v for %+v", err, c.devices)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected error for %+v", c.devices)
+		}
+		if !c.valid && len(cont.Devices()) != 0 {
+			t.Errorf("devices set despite the error for %+v", c.devices)
+		}
+	}
+}
@@ -2,11 +2,36 @@ package container
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/iximiuz/conman/pkg/rollback"
 )
 
+var ErrNotFound = errors.New("container not found")
+
+// AmbiguousRefError is returned when a container reference
+// matches more than one container.
+type AmbiguousRefError struct {
+	Ref        string
+	Candidates []*Container
+}
+
+func (e *AmbiguousRefError) Error() string {
+	var cs []string
+	for _, c := range e.Candidates {
+		cs = append(cs, fmt.Sprintf("%s (%s)", c.ID(), c.Name()))
+	}
+	sort.Strings(cs)
+	return fmt.Sprintf("container reference %q is ambiguous, candidates: %s",
+		e.Ref, strings.Join(cs, ", "))
+}
+
+// Map is an in-memory index of containers. It is safe for concurrent use.
 type Map struct {
+	mu     sync.RWMutex
 	byid   map[ID]*Container
 	byname map[string]*Container
 }
@@ -19,6 +44,9 @@ func NewMap() *Map {
 }
 
 func (m *Map) Add(c *Container, rb *rollback.Rollback) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.byid[c.ID()]; ok {
 		return errors.New("Duplicate container ID")
 	}
@@ -36,16 +64,60 @@ func (m *Map) Add(c *Container, rb *rollback.Rollback) error {
 }
 
 func (m *Map) Get(id ID) *Container {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	c, _ := m.byid[id]
 	return c
 }
 
 func (m *Map) GetByName(name string) *Container {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	c, _ := m.byname[name]
 	return c
 }
 
+// Resolve finds a container by a reference, which can be a full
+// container ID, a container name, or a unique prefix of a container
+// ID. The kinds of references are tried in this order, so a full ID
+// always wins over a name, and a name over an ID prefix.
+func (m *Map) Resolve(ref string) (*Container, error) {
+	if ref == "" {
+		return nil, ErrNotFound
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if c, ok := m.byid[ID(ref)]; ok {
+		return c, nil
+	}
+	if c, ok := m.byname[ref]; ok {
+		return c, nil
+	}
+
+	var candidates []*Container
+	for id, c := range m.byid {
+		if strings.HasPrefix(string(id), ref) {
+			candidates = append(candidates, c)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return candidates[0], nil
+	}
+	return nil, &AmbiguousRefError{Ref: ref, Candidates: candidates}
+}
+
+// All returns a point-in-time list of the containers.
 func (m *Map) All() (cs []*Container) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	for _, c := range m.byid {
 		cs = append(cs, c)
 	}
@@ -53,6 +125,9 @@ func (m *Map) All() (cs []*Container) {
 }
 
 func (m *Map) Del(id ID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	c, ok := m.byid[id]
 	if ok {
 		delete(m.byid, id)
@@ -1,6 +1,7 @@
 package container_test
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/iximiuz/conman/pkg/container"
@@ -56,3 +57,82 @@ func TestMapGetByName(t *testing.T) {
 		t.Fatal("GetByName() returned nil")
 	}
 }
+
+func TestMapConcurrentAccess(t *testing.T) {
+	m := container.NewMap()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := testutil.NewContainer()
+			if err := m.Add(c, nil); err != nil {
+				t.Error(err)
+				return
+			}
+			m.All()
+			m.Del(c.ID())
+		}()
+	}
+	wg.Wait()
+
+	if len(m.All()) != 0 {
+		t.Fatal("Unexpected containers left in the map")
+	}
+}
+
+func TestMapResolve(t *testing.T) {
+	m := container.NewMap()
+	c1 := newContainer(t, "0123456789abcdef0123456789abcdef", "cont1")
+	c2 := newContainer(t, "0123ffffffffffffffffffffffffffff", "cont2")
+	c3 := newContainer(t, "abcdef0123456789abcdef0123456789", "0123")
+	for _, c := range []*container.Container{c1, c2, c3} {
+		if err := m.Add(c, nil); err != nil {
+			t.Fatal("Unexpected", err)
+		}
+	}
+
+	cases := []struct {
+		ref      string
+		expected *container.Container
+	}{
+		{string(c1.ID()), c1}, // full ID
+		{"cont2", c2},         // name
+		{"01234", c1},         // unique ID prefix
+		{"0123f", c2},         // unique ID prefix
+		{"0123", c3},          // name wins over ID prefix
+		{"abc", c3},           // unique ID prefix
+	}
+	for _, tc := range cases {
+		c, err := m.Resolve(tc.ref)
+		if err != nil {
+			t.Fatalf("Resolve(%q) failed: %v", tc.ref, err)
+		}
+		if c != tc.expected {
+			t.Fatalf("Resolve(%q) returned %v, expected %v",
+				tc.ref, c.ID(), tc.expected.ID())
+		}
+	}
+
+	if _, err := m.Resolve("012"); err == nil {
+		t.Fatal("Expected ambiguous reference error")
+	} else if _, ok := err.(*container.AmbiguousRefError); !ok {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if _, err := m.Resolve("fff"); err != container.ErrNotFound {
+		t.Fatal("Expected not found error, got", err)
+	}
+	if _, err := m.Resolve(""); err != container.ErrNotFound {
+		t.Fatal("Expected not found error, got", err)
+	}
+}
+
+func newContainer(t *testing.T, id, name string) *container.Container {
+	c, err := container.New(container.ID(id), name, "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+package container
+
+import (
+	"errors"
+	"path"
+	"regexp"
+)
+
+type MountType string
+
+const (
+	// A host path mounted into the container.
+	MountBind MountType = "bind"
+	// A named volume managed by conman.
+	MountVolume MountType = "volume"
+	// A memory-backed filesystem private to the container.
+	MountTmpfs MountType = "tmpfs"
+)
+
+// Mount propagation modes, see mount_namespaces(7).
+const (
+	PropagationPrivate = "rprivate"
+	PropagationSlave   = "rslave"
+	PropagationShared  = "rshared"
+)
+
+type Mount struct {
+	Type MountType `json:"type"`
+	// A host path for bind mounts, a volume name for volume mounts.
+	// Not used by tmpfs mounts.
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination"`
+	Readonly    bool   `json:"readonly,omitempty"`
+	// Makes the submounts of a bind mount read-only too. Requires
+	// Readonly and private propagation.
+	RecursiveReadonly bool `json:"recursiveReadonly,omitempty"`
+	// Defaults to rprivate.
+	Propagation string `json:"propagation,omitempty"`
+	// Max tmpfs size in bytes. Unlimited if 0.
+	Size int64 `json:"size,omitempty"`
+}
+
+func (m Mount) Validate() error {
+	if !path.IsAbs(m.Destination) {
+		return errors.New("mount destination must be an absolute path")
+	}
+
+	switch m.Type {
+	case MountBind:
+		if !path.IsAbs(m.Source) {
+			return errors.New("bind mount source must be an absolute path")
+		}
+	case MountVolume:
+		if !IsValidVolumeName(m.Source) {
+			return errors.New("invalid volume name")
+		}
+	case MountTmpfs:
+		if m.Source != "" {
+			return errors.New("tmpfs mount doesn't have a source")
+		}
+	default:
+		return errors.New("unknown mount type")
+	}
+
+	if m.Size < 0 || (m.Size > 0 && m.Type != MountTmpfs) {
+		return errors.New("mount size is supported only by tmpfs")
+	}
+
+	switch m.Propagation {
+	case "", PropagationPrivate, PropagationSlave, PropagationShared:
+	default:
+		return errors.New("unknown mount propagation")
+	}
+
+	if m.RecursiveReadonly {
+		if m.Type == MountTmpfs {
+			return errors.New("recursive read-only is not supported by tmpfs")
+		}
+		if !m.Readonly {
+			return errors.New("recursive read-only requires read-only")
+		}
+		if m.Propagation != "" && m.Propagation != PropagationPrivate {
+			return errors.New("recursive read-only requires private propagation")
+		}
+	}
+	return nil
+}
+
+var volumeNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+// IsValidVolumeName tells if the name can be used for a named volume.
+// A valid name never starts with / or ., so it's never confused with
+// a host path.
+func IsValidVolumeName(name string) bool {
+	return volumeNameRegexp.MatchString(name)
+}
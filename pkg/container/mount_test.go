@@ -0,0 +1,80 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestSetMounts(t *testing.T) {
+	cases := []struct {
+		mounts []container.Mount
+		valid  bool
+	}{
+		{nil, true},
+		{[]container.Mount{
+			{Type: container.MountBind, Source: "/data", Destination: "/data", Readonly: true},
+			{Type: container.MountVolume, Source: "db-data.1", Destination: "/var/lib/db"},
+			{Type: container.MountTmpfs, Destination: "/tmp", Size: 1 << 20},
+		}, true},
+		{[]container.Mount{{Type: container.MountBind, Source: "data", Destination: "/data"}}, false},
+		{[]container.Mount{{Type: container.MountBind, Source: "/data", Destination: "data"}}, false},
+		{[]container.Mount{{Type: container.MountVolume, Source: "/data", Destination: "/data"}}, false},
+		{[]container.Mount{{Type: container.MountVolume, Source: ".data", Destination: "/data"}}, false},
+		{[]container.Mount{{Type: container.MountTmpfs, Source: "/tmp", Destination: "/tmp"}}, false},
+		{[]container.Mount{{Type: container.MountBind, Source: "/data", Destination: "/data", Size: 1}}, false},
+		{[]container.Mount{{Type: "nfs", Source: "/data", Destination: "/data"}}, false},
+		{[]container.Mount{{
+			Type:        container.MountBind,
+			Source:      "/data",
+			Destination: "/data",
+			Propagation: "bogus",
+		}}, false},
+		{[]container.Mount{
+			{Type: container.MountTmpfs, Destination: "/tmp"},
+			{Type: container.MountTmpfs, Destination: "/tmp/"},
+		}, false},
+		{[]container.Mount{{
+			Type:              container.MountBind,
+			Source:            "/data",
+			Destination:       "/data",
+			Readonly:          true,
+			RecursiveReadonly: true,
+		}}, true},
+		{[]container.Mount{{
+			Type:              container.MountBind,
+			Source:            "/data",
+			Destination:       "/data",
+			RecursiveReadonly: true,
+		}}, false},
+		{[]container.Mount{{
+			Type:              container.MountBind,
+			Source:            "/data",
+			Destination:       "/data",
+			Readonly:          true,
+			RecursiveReadonly: true,
+			Propagation:       container.PropagationSlave,
+		}}, false},
+		{[]container.Mount{{
+			Type:              container.MountTmpfs,
+			Destination:       "/tmp",
+			Readonly:          true,
+			RecursiveReadonly: true,
+		}}, false},
+	}
+
+	for _, c := range cases {
+		cont := testutil.NewContainer()
+		err := cont.SetMounts(c.mounts)
+		if c.valid && err != nil {
+			t.Errorf("unexpected error %v for %+v", err, c.mounts)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected error for %+v", c.mounts)
+		}
+		if !c.valid && len(cont.Mounts()) != 0 {
+			t.Errorf("mounts set despite the error for %+v", c.mounts)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package container
+
+import (
+	"errors"
+)
+
+type NamespaceMode string
+
+const (
+	// A new namespace private to the container (the default).
+	NamespacePrivate NamespaceMode = ""
+	// The host namespace.
+	NamespaceHost NamespaceMode = "host"
+	// The namespace of the target container.
+	NamespaceTarget NamespaceMode = "target"
+)
+
+// NamespaceOptions tells which namespaces the container shares
+// with the host or with another (target) container.
+type NamespaceOptions struct {
+	Network NamespaceMode `json:"network,omitempty"`
+	PID     NamespaceMode `json:"pid,omitempty"`
+	IPC     NamespaceMode `json:"ipc,omitempty"`
+	UTS     NamespaceMode `json:"uts,omitempty"`
+	// Set iff some of the namespaces are NamespaceTarget.
+	TargetID ID `json:"targetId,omitempty"`
+}
+
+func (o NamespaceOptions) Validate() error {
+	target := false
+	for _, m := range o.modes() {
+		switch m {
+		case NamespacePrivate, NamespaceHost:
+		case NamespaceTarget:
+			target = true
+		default:
+			return errors.New("unknown namespace mode")
+		}
+	}
+
+	if target && o.TargetID == "" {
+		return errors.New("namespace target container is missing")
+	}
+	if !target && o.TargetID != "" {
+		return errors.New("namespace target container is set, but no namespace is shared with it")
+	}
+	return nil
+}
+
+// Shares tells if some of the namespaces belong to the target container.
+func (o NamespaceOptions) Shares() bool {
+	return o.TargetID != ""
+}
+
+func (o NamespaceOptions) modes() []NamespaceMode {
+	return []NamespaceMode{o.Network, o.PID, o.IPC, o.UTS}
+}
@@ -0,0 +1,40 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestSetNamespaces(t *testing.T) {
+	cases := []struct {
+		opts  container.NamespaceOptions
+		valid bool
+	}{
+		{container.NamespaceOptions{}, true},
+		{container.NamespaceOptions{Network: container.NamespaceHost, UTS: container.NamespaceHost}, true},
+		{container.NamespaceOptions{
+			PID:      container.NamespaceTarget,
+			IPC:      container.NamespaceTarget,
+			TargetID: container.RandID(),
+		}, true},
+		{container.NamespaceOptions{PID: container.NamespaceTarget}, false},
+		{container.NamespaceOptions{PID: container.NamespaceHost, TargetID: container.RandID()}, false},
+		{container.NamespaceOptions{Network: "bridge"}, false},
+	}
+
+	for i, c := range cases {
+		cont := testutil.NewContainer()
+		err := cont.SetNamespaces(c.opts)
+		if c.valid && err != nil {
+			t.Errorf("case %d: unexpected error %v", i, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+		if c.valid && cont.Namespaces() != c.opts {
+			t.Errorf("case %d: unexpected namespaces %+v", i, cont.Namespaces())
+		}
+	}
+}
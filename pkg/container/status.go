@@ -16,6 +16,24 @@ const (
 	Unknown Status = math.MaxUint32
 )
 
+// The container termination reasons.
+const (
+	// Exited with the zero code.
+	ReasonCompleted = "Completed"
+	// Exited with a non-zero code.
+	ReasonError = "Error"
+	// Killed by the memory cgroup OOM killer.
+	ReasonOOMKilled = "OOMKilled"
+	// Killed by a signal (see Container.Signal()).
+	ReasonSignaled = "Signaled"
+	// The OCI runtime has failed to start the container process.
+	ReasonContainerCannotRun = "ContainerCannotRun"
+)
+
+// DefaultTerminationMessagePath is the container path of the file
+// the container process can write its termination message to.
+const DefaultTerminationMessagePath = "/dev/termination-log"
+
 func StatusFromString(s string) (Status, error) {
 	switch s {
 	case "created":
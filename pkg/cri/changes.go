@@ -0,0 +1,45 @@
+package cri
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/storage"
+)
+
+func rootfsSnapshotFile(h *storage.ContainerHandle) string {
+	return path.Join(h.ContainerDir(), "rootfs.snapshot.json")
+}
+
+// snapshotRootfs records the metadata of the freshly copied
+// container rootfs. The container changes are told by it, so the
+// source rootfs (e.g. a host dir) can change freely afterwards.
+func snapshotRootfs(h *storage.ContainerHandle) error {
+	snap, err := archive.TakeSnapshot(h.RootfsDir())
+	if err != nil {
+		return errors.Wrap(err, "snapshot rootfs")
+	}
+	return errors.Wrap(snap.Write(rootfsSnapshotFile(h)), "snapshot rootfs")
+}
+
+// rootfsChanges compares the container rootfs with its snapshot.
+// The snapshot is taken before the rootfs ownership is shifted (see
+// prepareMappedRootfs()), so the shifted IDs are mapped back.
+func (rs *runtimeService) rootfsChanges(cont *container.Container) ([]archive.Change, error) {
+	h, err := rs.cstore.GetContainer(cont.ID())
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, container.ErrNotFound
+	}
+
+	snap, err := archive.ReadSnapshot(rootfsSnapshotFile(h))
+	if err != nil {
+		return nil, errors.Wrap(err, "read rootfs snapshot")
+	}
+	return snap.Changes(h.RootfsDir(), rs.rootfsIDMappings(cont, h))
+}
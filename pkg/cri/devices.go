@@ -0,0 +1,153 @@
+package cri
+
+import (
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/cdi"
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/oci"
+)
+
+// deviceEdits are the container spec additions
+// exposing the requested devices.
+type deviceEdits struct {
+	devices []oci.Device
+	mounts  []rspec.Mount
+	env     []string
+	hooks   *rspec.Hooks
+}
+
+// resolveDevices looks up the host devices and applies the edits
+// of the CDI devices. The CDI specs are re-read on every call since
+// the device drivers (re)generate them at will.
+func (rs *runtimeService) resolveDevices(cont *container.Container) (edits deviceEdits, err error) {
+	for _, d := range cont.Devices() {
+		dev, err := oci.DeviceFromPath(d.HostPath, d.ContainerPath)
+		if err != nil {
+			return deviceEdits{}, errors.Wrapf(err, "device %s", d.HostPath)
+		}
+		edits.devices = append(edits.devices, oci.Device{LinuxDevice: dev, Permissions: d.Permissions})
+	}
+
+	if len(cont.CDIDevices()) == 0 {
+		return edits, nil
+	}
+
+	registry, err := cdi.Load(rs.config.CDISpecDirs)
+	if err != nil {
+		return deviceEdits{}, err
+	}
+	cdiEdits, err := registry.Edits(cont.CDIDevices())
+	if err != nil {
+		return deviceEdits{}, err
+	}
+
+	for _, n := range cdiEdits.DeviceNodes {
+		dev, err := cdiDevice(n)
+		if err != nil {
+			return deviceEdits{}, errors.Wrapf(err, "CDI device node %s", n.Path)
+		}
+		edits.devices = append(edits.devices, dev)
+	}
+	for _, m := range cdiEdits.Mounts {
+		edits.mounts = append(edits.mounts, cdiMount(m))
+	}
+	edits.env = cdiEdits.Env
+	if len(cdiEdits.Hooks) > 0 {
+		edits.hooks = &rspec.Hooks{}
+		for _, h := range cdiEdits.Hooks {
+			if err := addCDIHook(edits.hooks, h); err != nil {
+				return deviceEdits{}, err
+			}
+		}
+	}
+	return edits, nil
+}
+
+// cdiDevice fills in the device node attributes
+// omitted by the spec from the host device.
+func cdiDevice(n *cdi.DeviceNode) (oci.Device, error) {
+	dev := rspec.LinuxDevice{
+		Path:     n.Path,
+		Type:     n.Type,
+		Major:    n.Major,
+		Minor:    n.Minor,
+		FileMode: n.FileMode,
+		UID:      n.UID,
+		GID:      n.GID,
+	}
+	if dev.Type == "" || (dev.Type != "p" && dev.Major == 0 && dev.Minor == 0) {
+		hostPath := n.HostPath
+		if hostPath == "" {
+			hostPath = n.Path
+		}
+		host, err := oci.DeviceFromPath(hostPath, n.Path)
+		if err != nil {
+			return oci.Device{}, err
+		}
+		dev.Type, dev.Major, dev.Minor = host.Type, host.Major, host.Minor
+		if dev.FileMode == nil {
+			dev.FileMode = host.FileMode
+		}
+		if dev.UID == nil {
+			dev.UID = host.UID
+		}
+		if dev.GID == nil {
+			dev.GID = host.GID
+		}
+	}
+
+	perms := n.Permissions
+	if perms == "" {
+		perms = "rwm"
+	}
+	return oci.Device{LinuxDevice: dev, Permissions: perms}, nil
+}
+
+func cdiMount(m *cdi.Mount) rspec.Mount {
+	mount := rspec.Mount{
+		Type:        m.Type,
+		Source:      m.HostPath,
+		Destination: m.ContainerPath,
+		Options:     m.Options,
+	}
+	if mount.Type == "" {
+		mount.Type = "bind"
+	}
+	if mount.Type == "bind" {
+		for _, o := range mount.Options {
+			if o == "bind" || o == "rbind" {
+				return mount
+			}
+		}
+		mount.Options = append([]string{"rbind"}, mount.Options...)
+	}
+	return mount
+}
+
+func addCDIHook(hooks *rspec.Hooks, h *cdi.Hook) error {
+	hook := rspec.Hook{
+		Path:    h.Path,
+		Args:    h.Args,
+		Env:     h.Env,
+		Timeout: h.Timeout,
+	}
+	switch h.HookName {
+	case "prestart":
+		hooks.Prestart = append(hooks.Prestart, hook)
+	case "createRuntime":
+		hooks.CreateRuntime = append(hooks.CreateRuntime, hook)
+	case "createContainer":
+		hooks.CreateContainer = append(hooks.CreateContainer, hook)
+	case "startContainer":
+		hooks.StartContainer = append(hooks.StartContainer, hook)
+	case "poststart":
+		hooks.Poststart = append(hooks.Poststart, hook)
+	case "poststop":
+		hooks.Poststop = append(hooks.Poststop, hook)
+	default:
+		return errors.Errorf("unknown CDI hook %q", h.HookName)
+	}
+	return nil
+}
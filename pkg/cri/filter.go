@@ -0,0 +1,64 @@
+package cri
+
+import (
+	"strings"
+
+	"github.com/iximiuz/conman/pkg/container"
+)
+
+// ContainerFilter narrows down the ListContainers() result.
+// All the specified conditions are ANDed. Zero value matches
+// every container.
+type ContainerFilter struct {
+	// IDPrefix matches containers whose ID starts with it.
+	IDPrefix string
+
+	// Name matches a container with exactly this name.
+	Name string
+
+	// State, if not nil, matches containers in this state.
+	State *container.Status
+
+	// PodSandboxID matches containers of the given sandbox.
+	// Sandboxes aren't supported yet, so any non-empty
+	// value matches nothing.
+	PodSandboxID string
+
+	// LabelSelector matches containers having all of the
+	// specified labels set to the specified values.
+	LabelSelector map[string]string
+}
+
+// matchMeta checks the conditions that don't depend on the actual
+// container state, i.e. the ones that can be checked without making
+// a (costly) OCI runtime call.
+func (f *ContainerFilter) matchMeta(c *container.Container) bool {
+	if f == nil {
+		return true
+	}
+	if f.IDPrefix != "" && !strings.HasPrefix(string(c.ID()), f.IDPrefix) {
+		return false
+	}
+	if f.Name != "" && f.Name != c.Name() {
+		return false
+	}
+	if f.PodSandboxID != "" {
+		return false
+	}
+
+	labels := c.Labels()
+	for k, v := range f.LabelSelector {
+		if actual, ok := labels[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchState must be applied to a freshly synced container.
+func (f *ContainerFilter) matchState(c *container.Container) bool {
+	if f == nil || f.State == nil {
+		return true
+	}
+	return *f.State == c.Status()
+}
@@ -0,0 +1,79 @@
+package cri
+
+import (
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestContainerFilterMatchMeta(t *testing.T) {
+	c := testutil.NewContainer()
+
+	cases := []struct {
+		filter   *ContainerFilter
+		expected bool
+	}{
+		{nil, true},
+		{&ContainerFilter{}, true},
+		{&ContainerFilter{IDPrefix: string(c.ID()[:6])}, true},
+		{&ContainerFilter{IDPrefix: "xyz"}, false},
+		{&ContainerFilter{Name: c.Name()}, true},
+		{&ContainerFilter{Name: c.Name() + "_other"}, false},
+		{&ContainerFilter{PodSandboxID: "sandbox1"}, false},
+		{&ContainerFilter{LabelSelector: map[string]string{"foo": "bar"}}, false},
+	}
+	for _, tc := range cases {
+		if actual := tc.filter.matchMeta(c); actual != tc.expected {
+			t.Fatalf("matchMeta(%+v) = %v, expected %v",
+				tc.filter, actual, tc.expected)
+		}
+	}
+}
+
+func TestContainerFilterMatchLabels(t *testing.T) {
+	c, err := container.New(
+		container.RandID(),
+		"cont1",
+		"",
+		map[string]string{"app": "web", "tier": "front"},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		filter   *ContainerFilter
+		expected bool
+	}{
+		{&ContainerFilter{LabelSelector: map[string]string{"app": "web"}}, true},
+		{&ContainerFilter{LabelSelector: map[string]string{"app": "web", "tier": "front"}}, true},
+		{&ContainerFilter{LabelSelector: map[string]string{"app": "db"}}, false},
+		{&ContainerFilter{LabelSelector: map[string]string{"app": "web", "env": "prod"}}, false},
+	}
+	for _, tc := range cases {
+		if actual := tc.filter.matchMeta(c); actual != tc.expected {
+			t.Fatalf("matchMeta(%+v) = %v, expected %v",
+				tc.filter, actual, tc.expected)
+		}
+	}
+}
+
+func TestContainerFilterMatchState(t *testing.T) {
+	c := testutil.NewContainer()
+	c.SetStatus(container.Running)
+
+	running := container.Running
+	stopped := container.Stopped
+
+	if !(&ContainerFilter{}).matchState(c) {
+		t.Fatal("Empty filter must match any state")
+	}
+	if !(&ContainerFilter{State: &running}).matchState(c) {
+		t.Fatal("Filter by the actual state must match")
+	}
+	if (&ContainerFilter{State: &stopped}).matchState(c) {
+		t.Fatal("Filter by another state must not match")
+	}
+}
@@ -0,0 +1,94 @@
+package cri
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/storage"
+)
+
+// ImageService manages the locally stored images. Like RuntimeService,
+// it resembles the CRI image service, but doesn't follow it strictly.
+type ImageService interface {
+	ListImages(context.Context) ([]*storage.Image, error)
+
+	// ImportImage creates a single-layer image from a (possibly gzip-ed)
+	// tar archive of a root filesystem. The name (name:tag) is optional.
+	ImportImage(ctx context.Context, r io.Reader, name string) (*storage.Image, error)
+
+	// TODO: ImageStatus
+	// TODO: PullImage
+	// TODO: RemoveImage
+}
+
+func NewImageService(istore storage.ImageStore) ImageService {
+	return &imageService{
+		istore: istore,
+	}
+}
+
+type imageService struct {
+	istore storage.ImageStore
+}
+
+func (is *imageService) ListImages(ctx context.Context) ([]*storage.Image, error) {
+	return is.istore.ListImages()
+}
+
+func (is *imageService) ImportImage(
+	ctx context.Context,
+	r io.Reader,
+	name string,
+) (*storage.Image, error) {
+	var names []string
+	if name != "" {
+		normalized, err := storage.NormalizeImageName(name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, normalized)
+	}
+
+	r, err := archive.Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Layers are stored uncompressed, so the layer digest
+	// is the diff ID at the same time.
+	layer, err := is.istore.PutBlob(ispec.MediaTypeImageLayer, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't write layer")
+	}
+
+	now := time.Now()
+	config := ispec.Image{
+		Created:      &now,
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		RootFS: ispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{layer.Digest},
+		},
+		History: []ispec.History{{
+			Created:   &now,
+			CreatedBy: "conman import-rootfs",
+		}},
+	}
+
+	return is.istore.CreateImage(config, []ispec.Descriptor{layer}, names, func(rootfs string) error {
+		blob, err := is.istore.OpenBlob(layer.Digest)
+		if err != nil {
+			return err
+		}
+		defer blob.Close()
+		return archive.Untar(blob, rootfs, "/", "", nil)
+	})
+}
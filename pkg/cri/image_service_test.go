@@ -0,0 +1,59 @@
+package cri
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestImportImage(t *testing.T) {
+	rootfs := testutil.TempDir(t, "rootfs")
+	defer os.RemoveAll(rootfs)
+	if err := ioutil.WriteFile(path.Join(rootfs, "a.txt"), []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := archive.Tar(gz, rootfs, "/", ".", nil); err != nil {
+		t.Fatal(err)
+	}
+	gz.Close()
+
+	root := testutil.TempDir(t, "store")
+	defer os.RemoveAll(root)
+	istore := storage.NewImageStore(root)
+
+	img, err := NewImageService(istore).ImportImage(context.Background(), &buf, "imported")
+	if err != nil {
+		t.Fatal("ImportImage() failed", err)
+	}
+	if len(img.Names) != 1 || img.Names[0] != "imported:latest" {
+		t.Fatal("Unexpected image names", img.Names)
+	}
+
+	data, err := ioutil.ReadFile(path.Join(istore.ImageRootfsDir(img), "a.txt"))
+	if err != nil || string(data) != "foo" {
+		t.Fatalf("Unexpected image rootfs content %q: %v", data, err)
+	}
+
+	config, err := istore.ImageConfig(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := istore.ImageManifest(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Layers) != 1 || config.RootFS.DiffIDs[0] != manifest.Layers[0].Digest {
+		t.Fatal("Image must have a single uncompressed layer")
+	}
+}
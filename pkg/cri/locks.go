@@ -0,0 +1,61 @@
+package cri
+
+import (
+	"sync"
+
+	"github.com/iximiuz/conman/pkg/container"
+)
+
+// containerLocks hands out per-container operation locks. Lifecycle
+// operations on different containers don't contend with each other.
+// Every lock is a 1-slot semaphore channel, so it can be acquired in
+// a non-blocking manner too (see tryLock()).
+type containerLocks struct {
+	mu    sync.Mutex
+	locks map[container.ID]chan struct{}
+}
+
+func newContainerLocks() *containerLocks {
+	return &containerLocks{
+		locks: make(map[container.ID]chan struct{}),
+	}
+}
+
+// lock blocks until the container lock is acquired. The returned
+// function releases the lock.
+func (l *containerLocks) lock(id container.ID) func() {
+	ch := l.get(id)
+	ch <- struct{}{}
+	return func() { <-ch }
+}
+
+// tryLock acquires the container lock only if it's not held already.
+func (l *containerLocks) tryLock(id container.ID) (func(), bool) {
+	ch := l.get(id)
+	select {
+	case ch <- struct{}{}:
+		return func() { <-ch }, true
+	default:
+		return nil, false
+	}
+}
+
+// forget drops the container lock. It's supposed to be called on
+// container removal while the lock is still being held.
+func (l *containerLocks) forget(id container.ID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.locks, id)
+}
+
+func (l *containerLocks) get(id container.ID) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch, ok := l.locks[id]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		l.locks[id] = ch
+	}
+	return ch
+}
@@ -0,0 +1,182 @@
+package cri
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestContainerLocksTryLock(t *testing.T) {
+	locks := newContainerLocks()
+	id := container.RandID()
+
+	unlock, ok := locks.tryLock(id)
+	if !ok {
+		t.Fatal("free lock is expected to be acquired")
+	}
+	if _, ok := locks.tryLock(id); ok {
+		t.Fatal("held lock is not expected to be acquired")
+	}
+
+	unlock()
+	unlock, ok = locks.tryLock(id)
+	if !ok {
+		t.Fatal("released lock is expected to be acquired")
+	}
+	unlock()
+}
+
+func TestContainerLocksLockBlocks(t *testing.T) {
+	locks := newContainerLocks()
+	id := container.RandID()
+
+	unlock := locks.lock(id)
+
+	acquired := make(chan struct{})
+	go func() {
+		locks.lock(id)()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("held lock is not expected to be acquired")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("released lock is expected to be acquired")
+	}
+}
+
+func TestContainerLocksIndependent(t *testing.T) {
+	locks := newContainerLocks()
+	id1, id2 := container.RandID(), container.RandID()
+
+	unlock1 := locks.lock(id1)
+	defer unlock1()
+
+	acquired := make(chan func())
+	go func() { acquired <- locks.lock(id2) }()
+
+	select {
+	case unlock2 := <-acquired:
+		unlock2()
+	case <-time.After(time.Second):
+		t.Fatal("lock of another container is not expected to block")
+	}
+
+	unlock2, ok := locks.tryLock(id2)
+	if !ok {
+		t.Fatal("lock of another container is expected to be acquired")
+	}
+	unlock2()
+}
+
+func TestContainerLocksForget(t *testing.T) {
+	locks := newContainerLocks()
+	id := container.RandID()
+
+	unlock := locks.lock(id)
+	locks.forget(id)
+
+	// A (re-created) container with the same ID gets a fresh lock.
+	unlock2, ok := locks.tryLock(id)
+	if !ok {
+		t.Fatal("forgotten lock is not expected to be held")
+	}
+
+	// Releasing the forgotten lock must neither block
+	// nor affect the fresh one.
+	released := make(chan struct{})
+	go func() {
+		unlock()
+		close(released)
+	}()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("forgotten lock release is not expected to block")
+	}
+	if _, ok := locks.tryLock(id); ok {
+		t.Fatal("fresh lock is expected to stay held")
+	}
+	unlock2()
+
+	locks.forget(id)
+	if len(locks.locks) != 0 {
+		t.Fatal("no locks are expected to be kept")
+	}
+}
+
+// blockingRuntime holds the container creation until released.
+type blockingRuntime struct {
+	createdRuntime
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (r *blockingRuntime) CreateContainer(
+	ctx context.Context,
+	id container.ID,
+	bundleDir, logfile, exitfile, attachfile string,
+	stdin, stdinOnce bool,
+	timeout time.Duration,
+) (int, error) {
+	close(r.creating)
+	<-r.release
+	return 1, nil
+}
+
+func TestListContainersDuringCreate(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rootfs := path.Join(dir, "rootfs")
+	must(t, os.MkdirAll(path.Join(rootfs, "etc"), 0755))
+
+	rt := &blockingRuntime{creating: make(chan struct{}), release: make(chan struct{})}
+	svc, err := NewRuntimeService(
+		rt,
+		storage.NewContainerStore(path.Join(dir, "containers")),
+		storage.NewImageStore(path.Join(dir, "images")),
+		storage.NewVolumeStore(path.Join(dir, "volumes")),
+		dir, dir, dir,
+		RuntimeConfig{},
+	)
+	must(t, err)
+
+	created := make(chan error)
+	go func() {
+		_, err := svc.CreateContainer(context.Background(), ContainerOptions{
+			Name:       "cont1",
+			Command:    "/bin/sleep",
+			RootfsPath: rootfs,
+		})
+		created <- err
+	}()
+
+	<-rt.creating
+	cs, err := svc.ListContainers(context.Background(), nil)
+	close(rt.release)
+	must(t, err)
+	if len(cs) != 0 {
+		t.Fatalf("containers being created are not expected to be listed, got %d", len(cs))
+	}
+	must(t, <-created)
+
+	cs, err = svc.ListContainers(context.Background(), nil)
+	must(t, err)
+	if len(cs) != 1 {
+		t.Fatalf("unexpected containers %v", cs)
+	}
+}
@@ -0,0 +1,244 @@
+package cri
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/rollback"
+)
+
+// specMounts turns the container mounts into the OCI runtime spec ones.
+// The volumes are acquired on the way (see acquireVolume()) and released
+// on rollback. The rootfs is the source (image) rootfs of the container.
+func (rs *runtimeService) specMounts(
+	cont *container.Container,
+	rootfs string,
+	rb *rollback.Rollback,
+) ([]rspec.Mount, error) {
+	var mounts []rspec.Mount
+	for _, m := range cont.Mounts() {
+		opts := []string{"rw"}
+		if m.Readonly {
+			opts[0] = "ro"
+		}
+		if m.RecursiveReadonly {
+			opts[0] = "rro"
+		}
+		if m.Propagation != "" {
+			opts = append(opts, m.Propagation)
+		} else {
+			opts = append(opts, container.PropagationPrivate)
+		}
+
+		switch m.Type {
+		case container.MountBind:
+			source, err := rs.bindMountSource(m)
+			if err != nil {
+				return nil, err
+			}
+			mounts = append(mounts, rspec.Mount{
+				Type:        "bind",
+				Source:      source,
+				Destination: m.Destination,
+				Options:     append([]string{"rbind"}, opts...),
+			})
+
+		case container.MountVolume:
+			m := m
+			vol, err := rs.acquireVolume(cont.ID(), m.Source, rootfs, m.Destination)
+			if err != nil {
+				return nil, errors.Wrapf(err, "can't use volume %s", m.Source)
+			}
+			rb.Add(func() {
+				rs.releaseVolumes(cont.ID(), []container.Mount{m})
+			})
+			source := rs.vstore.VolumeDataDir(vol)
+			if err := ensureHostPropagation(source, m.Propagation); err != nil {
+				return nil, err
+			}
+			mounts = append(mounts, rspec.Mount{
+				Type:        "bind",
+				Source:      source,
+				Destination: m.Destination,
+				Options:     append([]string{"rbind"}, opts...),
+			})
+
+		case container.MountTmpfs:
+			opts = append(opts, "nosuid", "nodev", "mode=1777")
+			if m.Size > 0 {
+				opts = append(opts, "size="+strconv.FormatInt(m.Size, 10))
+			}
+			mounts = append(mounts, rspec.Mount{
+				Type:        "tmpfs",
+				Source:      "tmpfs",
+				Destination: m.Destination,
+				Options:     opts,
+			})
+		}
+	}
+	return mounts, nil
+}
+
+// bindMountSource resolves the bind mount source path and makes sure
+// it's neither denylisted nor misses the requested propagation. The
+// sources containing a denylisted path (e.g. /var/lib for the lib
+// root) are denied too, they'd expose it all the same.
+func (rs *runtimeService) bindMountSource(m container.Mount) (string, error) {
+	source, err := filepath.EvalSymlinks(m.Source)
+	if err != nil {
+		return "", errors.Wrap(err, "bad bind mount source")
+	}
+
+	for _, denied := range rs.config.MountDenylist {
+		if source == denied || (denied != "/" && isBeneath(source, denied)) || isBeneath(denied, source) {
+			if source != m.Source {
+				return "", errors.Errorf(
+					"bind mount source %s (%s) is not allowed, %s is denylisted",
+					m.Source, source, denied)
+			}
+			return "", errors.Errorf(
+				"bind mount source %s is not allowed, %s is denylisted",
+				source, denied)
+		}
+	}
+
+	if err := ensureHostPropagation(source, m.Propagation); err != nil {
+		return "", err
+	}
+	return source, nil
+}
+
+// ensureHostPropagation makes the host mount the path belongs to shared
+// if it's required for the mount propagation to work. An rshared mount
+// requires a shared host mount, while an rslave one is fine with either
+// a shared or a slave host mount.
+func ensureHostPropagation(path, propagation string) error {
+	if propagation != container.PropagationShared &&
+		propagation != container.PropagationSlave {
+		return nil
+	}
+
+	mi, err := fsutil.FindMount(path)
+	if err != nil {
+		return err
+	}
+	if mi.Shared() || (propagation == container.PropagationSlave && mi.Slave()) {
+		return nil
+	}
+
+	logrus.Infof("Making host mount %s shared for %s propagation of %s",
+		mi.MountPoint, propagation, path)
+	if err := fsutil.MakeShared(mi.MountPoint); err != nil {
+		return errors.Wrapf(err, "can't make host mount %s shared", mi.MountPoint)
+	}
+	return nil
+}
+
+// rootfsPropagation returns the rootfs propagation required by the
+// mounts. The propagation events can't reach a mount if its parent
+// mount (i.e. the container rootfs) doesn't propagate them.
+func rootfsPropagation(mounts []container.Mount) string {
+	rp := ""
+	for _, m := range mounts {
+		switch m.Propagation {
+		case container.PropagationShared:
+			return container.PropagationShared
+		case container.PropagationSlave:
+			rp = container.PropagationSlave
+		}
+	}
+	return rp
+}
+
+// isBeneath tells if the path lies strictly beneath the dir.
+func isBeneath(path, dir string) bool {
+	if dir == "/" {
+		return path != "/"
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
+
+// resolvePaths cleans up the paths resolving symlinks where possible.
+// The parent directory is resolved for the (yet) missing paths, e.g.
+// for the daemon socket.
+func resolvePaths(paths []string) []string {
+	var resolved []string
+	for _, p := range paths {
+		if r, err := filepath.EvalSymlinks(p); err == nil {
+			p = r
+		} else if r, err := filepath.EvalSymlinks(filepath.Dir(p)); err == nil {
+			p = filepath.Join(r, filepath.Base(p))
+		}
+		resolved = append(resolved, filepath.Clean(p))
+	}
+	return resolved
+}
+
+// containerPathRoot maps a container path to the host directory
+// it's stored in and the path relative to this directory. Paths
+// on bind and volume mounts are mapped to the mount sources, all
+// the other paths are mapped to the container rootfs. Only the
+// parent directory symlinks are resolved (within the rootfs), the
+// last path component is left intact. A trailing slash is kept.
+func (rs *runtimeService) containerPathRoot(
+	id container.ID,
+	cpath string,
+) (root string, rel string, err error) {
+	rootfs, err := rs.containerRootfs(id)
+	if err != nil {
+		return "", "", err
+	}
+	cont := rs.cmap.Get(id)
+	if cont == nil {
+		return "", "", container.ErrNotFound
+	}
+
+	slash := ""
+	if strings.HasSuffix(cpath, "/") {
+		slash = "/"
+	}
+	cpath = path.Clean("/" + cpath)
+	if cpath != "/" {
+		parent, err := fsutil.SecureJoin(rootfs, path.Dir(cpath))
+		if err != nil {
+			return "", "", err
+		}
+		parent = path.Join("/", strings.TrimPrefix(parent, rootfs))
+		cpath = path.Join(parent, path.Base(cpath))
+	}
+
+	// The most nested mount wins.
+	mounts := append([]container.Mount(nil), cont.Mounts()...)
+	sort.Slice(mounts, func(i, j int) bool {
+		return len(mounts[i].Destination) > len(mounts[j].Destination)
+	})
+	for _, m := range mounts {
+		if cpath != m.Destination && !strings.HasPrefix(cpath, m.Destination+"/") {
+			continue
+		}
+
+		rel = path.Join("/", strings.TrimPrefix(cpath, m.Destination)) + slash
+		switch m.Type {
+		case container.MountBind:
+			return m.Source, rel, nil
+		case container.MountVolume:
+			vol, err := rs.vstore.GetVolume(m.Source)
+			if err != nil {
+				return "", "", err
+			}
+			return rs.vstore.VolumeDataDir(vol), rel, nil
+		case container.MountTmpfs:
+			return "", "", errors.Errorf("%s: copying to or from tmpfs is not supported", cpath)
+		}
+	}
+	return rootfs, cpath + slash, nil
+}
@@ -0,0 +1,72 @@
+package cri
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestRootfsPropagation(t *testing.T) {
+	cases := []struct {
+		propagations []string
+		expected     string
+	}{
+		{nil, ""},
+		{[]string{"", container.PropagationPrivate}, ""},
+		{[]string{container.PropagationSlave, ""}, container.PropagationSlave},
+		{[]string{container.PropagationShared, container.PropagationSlave}, container.PropagationShared},
+		{[]string{container.PropagationSlave, container.PropagationShared}, container.PropagationShared},
+	}
+
+	for _, c := range cases {
+		var mounts []container.Mount
+		for _, p := range c.propagations {
+			mounts = append(mounts, container.Mount{Propagation: p})
+		}
+		if actual := rootfsPropagation(mounts); actual != c.expected {
+			t.Errorf("rootfsPropagation(%v) = %q, expected %q", c.propagations, actual, c.expected)
+		}
+	}
+}
+
+func TestBindMountSourceDenylist(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	lib := path.Join(dir, "var", "lib", "conman")
+	must(t, os.MkdirAll(lib, 0755))
+	must(t, os.MkdirAll(path.Join(dir, "var", "log"), 0755))
+	must(t, os.MkdirAll(path.Join(dir, "var", "run"), 0755))
+	must(t, os.Symlink(path.Join(dir, "var"), path.Join(dir, "link")))
+
+	rs := &runtimeService{config: RuntimeConfig{
+		MountDenylist: resolvePaths([]string{"/", "/proc", lib, path.Join(dir, "link", "run", "conmand.sock")}),
+	}}
+
+	cases := []struct {
+		source string
+		denied bool
+	}{
+		{"/", true},
+		{"/proc", true},
+		{"/proc/self", true},
+		{lib, true},
+		{path.Join(lib, "containers"), true},
+		{path.Join(dir, "var", "lib"), true},
+		{path.Join(dir, "link"), true},
+		{dir, true},
+		{path.Join(dir, "var", "run"), true},
+		{path.Join(dir, "var", "run", "other"), false},
+		{path.Join(dir, "var", "log"), false},
+	}
+	for _, c := range cases {
+		_ = os.MkdirAll(c.source, 0755)
+		_, err := rs.bindMountSource(container.Mount{Source: c.source})
+		if denied := err != nil; denied != c.denied {
+			t.Errorf("bindMountSource(%q) = %v, expected denied %v", c.source, err, c.denied)
+		}
+	}
+}
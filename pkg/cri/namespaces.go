@@ -0,0 +1,124 @@
+package cri
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/rollback"
+)
+
+// joinNamespaces makes the container a dependent of the namespace
+// target container and returns the namespaces to share. The target
+// has to have its init process around, i.e. be created or running.
+func (rs *runtimeService) joinNamespaces(
+	ctx context.Context,
+	id container.ID,
+	opts container.NamespaceOptions,
+	rb *rollback.Rollback,
+) (map[rspec.LinuxNamespaceType]string, error) {
+	shared := make(map[rspec.LinuxNamespaceType]string)
+
+	pid := 0
+	if opts.Shares() {
+		target, unlock, err := rs.lockContainer(opts.TargetID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "namespace target container %s", opts.TargetID)
+		}
+		defer unlock()
+
+		if err := assertStatus(target.Status(), container.Created, container.Running); err != nil {
+			return nil, errors.Wrapf(err, "namespace target container %s", opts.TargetID)
+		}
+		state, err := rs.runtime.ContainerState(ctx, target.ID())
+		if err != nil {
+			return nil, err
+		}
+		if state.Pid <= 0 {
+			return nil, errors.Errorf("namespace target container %s has no init process", target.ID())
+		}
+		pid = state.Pid
+
+		rs.namespacesMu.Lock()
+		rs.addNamespaceRefNoLock(target.ID(), id)
+		rs.namespacesMu.Unlock()
+		rb.Add(func() { rs.releaseNamespaces(id, opts) })
+	}
+
+	for typ, mode := range map[rspec.LinuxNamespaceType]container.NamespaceMode{
+		rspec.NetworkNamespace: opts.Network,
+		rspec.PIDNamespace:     opts.PID,
+		rspec.IPCNamespace:     opts.IPC,
+		rspec.UTSNamespace:     opts.UTS,
+	} {
+		switch mode {
+		case container.NamespaceHost:
+			shared[typ] = ""
+		case container.NamespaceTarget:
+			shared[typ] = fmt.Sprintf("/proc/%d/ns/%s", pid, nsFiles[typ])
+		}
+	}
+	return shared, nil
+}
+
+// The /proc/<pid>/ns/ entries.
+var nsFiles = map[rspec.LinuxNamespaceType]string{
+	rspec.NetworkNamespace: "net",
+	rspec.PIDNamespace:     "pid",
+	rspec.IPCNamespace:     "ipc",
+	rspec.UTSNamespace:     "uts",
+}
+
+func (rs *runtimeService) releaseNamespaces(id container.ID, opts container.NamespaceOptions) {
+	if !opts.Shares() {
+		return
+	}
+
+	rs.namespacesMu.Lock()
+	defer rs.namespacesMu.Unlock()
+
+	delete(rs.namespaceRefs[opts.TargetID], id)
+	if len(rs.namespaceRefs[opts.TargetID]) == 0 {
+		delete(rs.namespaceRefs, opts.TargetID)
+	}
+}
+
+func (rs *runtimeService) restoreNamespaceRefs(cont *container.Container) {
+	if opts := cont.Namespaces(); opts.Shares() {
+		rs.namespacesMu.Lock()
+		rs.addNamespaceRefNoLock(opts.TargetID, cont.ID())
+		rs.namespacesMu.Unlock()
+	}
+}
+
+func (rs *runtimeService) addNamespaceRefNoLock(target, id container.ID) {
+	if rs.namespaceRefs[target] == nil {
+		rs.namespaceRefs[target] = make(map[container.ID]bool)
+	}
+	rs.namespaceRefs[target][id] = true
+}
+
+// assertNoNamespaceDependents fails if some containers
+// (in any state) share the namespaces of the container.
+func (rs *runtimeService) assertNoNamespaceDependents(id container.ID) error {
+	rs.namespacesMu.Lock()
+	defer rs.namespacesMu.Unlock()
+
+	var deps []string
+	for dep := range rs.namespaceRefs[id] {
+		deps = append(deps, string(dep))
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	sort.Strings(deps)
+	return errors.Errorf(
+		"container %s namespaces are shared with container(s) %s",
+		id, strings.Join(deps, ", "),
+	)
+}
@@ -0,0 +1,113 @@
+package cri
+
+import (
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/cgroups"
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/shimutil"
+)
+
+// watchOOM remembers the memory cgroup of the container init process
+// and, on cgroup v1, subscribes to the cgroup OOM events. The v2
+// cgroups keep the OOM kill counter until the OCI runtime container
+// is deleted, so it's checked once the container exit is observed.
+func (rs *runtimeService) watchOOM(cont *container.Container, pid int) {
+	// Otherwise, the container shares the daemon cgroup.
+	if rs.config.Rootless && !rs.config.CgroupsDelegated {
+		return
+	}
+
+	dir, err := cgroups.MemoryDir(pid)
+	if err != nil {
+		logrus.WithError(err).Warnf("failed to find container %s memory cgroup", cont.ID())
+		return
+	}
+	cont.SetCgroupDir(dir)
+	rs.notifyOOM(cont.ID(), dir)
+}
+
+// restoreOOMWatch resubscribes to the OOM events
+// of the containers that haven't stopped yet.
+func (rs *runtimeService) restoreOOMWatch(cont *container.Container) {
+	if cont.CgroupDir() == "" {
+		return
+	}
+	if cont.Status() == container.Created || cont.Status() == container.Running {
+		rs.notifyOOM(cont.ID(), cont.CgroupDir())
+	}
+}
+
+func (rs *runtimeService) notifyOOM(id container.ID, dir string) {
+	if cgroups.IsV2() {
+		return
+	}
+
+	events, err := cgroups.NotifyOOM(dir)
+	if err != nil {
+		logrus.WithError(err).Warnf("failed to subscribe to container %s OOM events", id)
+		return
+	}
+	go func() {
+		for range events {
+			rs.oomMu.Lock()
+			rs.oomEvents[id] = true
+			rs.oomMu.Unlock()
+			emitOOMEvent(id)
+		}
+	}()
+}
+
+// checkOOMKilled tells if the container init process has been killed
+// by the OOM killer. It's called once the container is found stopped.
+// The cgroup OOM kills count any container process, so only the init
+// process killed by SIGKILL is considered an OOM victim. The seen OOM
+// events are consumed anyway.
+func (rs *runtimeService) checkOOMKilled(
+	cont *container.Container,
+	ts *shimutil.TerminationStatus,
+) bool {
+	rs.oomMu.Lock()
+	seen := rs.oomEvents[cont.ID()]
+	delete(rs.oomEvents, cont.ID())
+	rs.oomMu.Unlock()
+
+	if !ts.IsSignaled() || ts.Signal() != int32(syscall.SIGKILL) {
+		return false
+	}
+	if seen {
+		return true
+	}
+
+	if cont.CgroupDir() == "" {
+		return false
+	}
+	count, err := cgroups.OOMKillCount(cont.CgroupDir())
+	if err != nil {
+		logrus.WithError(err).Debugf("failed to read container %s OOM kill count", cont.ID())
+		return false
+	}
+	if count == 0 {
+		return false
+	}
+	emitOOMEvent(cont.ID())
+	return true
+}
+
+func (rs *runtimeService) forgetOOMEvents(id container.ID) {
+	rs.oomMu.Lock()
+	delete(rs.oomEvents, id)
+	rs.oomMu.Unlock()
+}
+
+// emitOOMEvent only logs the event. The clients learn about the OOM
+// kills from the OOMKilled reason of the container status (and of the
+// WaitContainer response).
+func emitOOMEvent(id container.ID) {
+	logrus.WithFields(logrus.Fields{
+		"event":     "oom",
+		"container": id,
+	}).Warn("Container memory cgroup OOM killer invoked")
+}
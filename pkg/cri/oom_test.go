@@ -0,0 +1,76 @@
+package cri
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/shimutil"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestCheckOOMKilled(t *testing.T) {
+	rs := &runtimeService{oomEvents: make(map[container.ID]bool)}
+	killed := parseExit(t, `{"reason":"signaled","signal":9}`)
+
+	cont := testutil.NewContainer()
+	if rs.checkOOMKilled(cont, killed) {
+		t.Error("container without a cgroup is not expected to be OOM killed")
+	}
+
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	cont.SetCgroupDir(dir)
+	events := filepath.Join(dir, "memory.events")
+
+	if err := ioutil.WriteFile(events, []byte("oom 0\noom_kill 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if rs.checkOOMKilled(cont, killed) {
+		t.Error("container is not expected to be OOM killed")
+	}
+
+	// The v1 notifications.
+	rs.oomEvents[cont.ID()] = true
+	if !rs.checkOOMKilled(cont, killed) {
+		t.Error("container is expected to be OOM killed")
+	}
+	if len(rs.oomEvents) != 0 {
+		t.Error("OOM events are expected to be consumed")
+	}
+
+	if err := ioutil.WriteFile(events, []byte("oom 1\noom_kill 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !rs.checkOOMKilled(cont, killed) {
+		t.Error("container is expected to be OOM killed")
+	}
+
+	// Some other container process has been OOM killed.
+	exited := parseExit(t, `{"reason":"exited","exitCode":0}`)
+	if rs.checkOOMKilled(cont, exited) {
+		t.Error("exited container is not expected to be OOM killed")
+	}
+	terminated := parseExit(t, `{"reason":"signaled","signal":15}`)
+	if rs.checkOOMKilled(cont, terminated) {
+		t.Error("terminated container is not expected to be OOM killed")
+	}
+
+	rs.oomEvents[cont.ID()] = true
+	if rs.checkOOMKilled(cont, exited) {
+		t.Error("exited container is not expected to be OOM killed")
+	}
+	if len(rs.oomEvents) != 0 {
+		t.Error("OOM events are expected to be consumed")
+	}
+}
+
+func parseExit(t *testing.T, exitFile string) *shimutil.TerminationStatus {
+	ts, err := shimutil.ParseExitFile([]byte(exitFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
@@ -0,0 +1,88 @@
+package cri
+
+import (
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/rollback"
+	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/usernet"
+)
+
+// rootlessIDMappings maps the daemon user to the container root and
+// the subordinate IDs of the daemon user to the rest of the IDs. The
+// OCI runtime needs newuidmap(1) and newgidmap(1) for the latter.
+// Every rootless container gets the same mappings.
+func rootlessIDMappings(id int, ranges []idmap.Range) []idmap.Mapping {
+	mappings := []idmap.Mapping{{ContainerID: 0, HostID: uint32(id), Size: 1}}
+	next := uint64(1)
+	for _, r := range ranges {
+		size := uint64(r.Size)
+		if next+size > 1<<32 {
+			size = 1<<32 - next
+		}
+		if size == 0 {
+			break
+		}
+		mappings = append(mappings, idmap.Mapping{
+			ContainerID: uint32(next),
+			HostID:      r.Start,
+			Size:        uint32(size),
+		})
+		next += size
+	}
+	return mappings
+}
+
+func (rs *runtimeService) allocateRootlessIDMappings(opts ContainerOptions) (uidMap, gidMap []idmap.Mapping, err error) {
+	if opts.UserNamespace || len(opts.UIDMappings) > 0 || len(opts.GIDMappings) > 0 {
+		return nil, nil, errors.New("custom user namespaces aren't supported by rootless conmand")
+	}
+	return rootlessIDMappings(os.Geteuid(), rs.config.SubUIDs),
+		rootlessIDMappings(os.Getegid(), rs.config.SubGIDs),
+		nil
+}
+
+func networkPidFile(h *storage.ContainerHandle) string {
+	return path.Join(h.BundleDir(), "usernet.pid")
+}
+
+// startNetwork connects the rootless container with its own network
+// namespace to the host network.
+// The root containers get no network (but the loopback) so far.
+func (rs *runtimeService) startNetwork(
+	cont *container.Container,
+	h *storage.ContainerHandle,
+	pid int,
+	rb *rollback.Rollback,
+) error {
+	if !rs.config.Rootless || rs.config.RootlessNetwork == usernet.DriverNone {
+		return nil
+	}
+	// The host or the target container network is used as is.
+	if cont.Namespaces().Network != container.NamespacePrivate {
+		return nil
+	}
+
+	rb.Add(func() { rs.stopNetwork(h.ContainerID()) })
+	return errors.Wrapf(
+		usernet.Start(rs.config.RootlessNetwork, pid, networkPidFile(h)),
+		"set up %s network", rs.config.RootlessNetwork,
+	)
+}
+
+// stopNetwork must precede the container directory removal.
+func (rs *runtimeService) stopNetwork(id container.ID) {
+	h, err := rs.cstore.GetContainer(id)
+	if err != nil || h == nil {
+		return
+	}
+	if err := usernet.Stop(networkPidFile(h)); err != nil {
+		logrus.WithError(err).Warnf("failed to stop container %s network helper", id)
+	}
+}
@@ -0,0 +1,43 @@
+package cri
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/idmap"
+)
+
+func TestRootlessIDMappings(t *testing.T) {
+	cases := []struct {
+		ranges   []idmap.Range
+		expected []idmap.Mapping
+	}{
+		{
+			expected: []idmap.Mapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+		},
+		{
+			ranges: []idmap.Range{{Start: 100000, Size: 65536}, {Start: 300000, Size: 1000}},
+			expected: []idmap.Mapping{
+				{ContainerID: 0, HostID: 1000, Size: 1},
+				{ContainerID: 1, HostID: 100000, Size: 65536},
+				{ContainerID: 65537, HostID: 300000, Size: 1000},
+			},
+		},
+		{
+			// Truncated at the end of the container ID space.
+			ranges: []idmap.Range{{Start: 5, Size: 1<<32 - 5}, {Start: 0, Size: 5}, {Start: 100, Size: 1}},
+			expected: []idmap.Mapping{
+				{ContainerID: 0, HostID: 1000, Size: 1},
+				{ContainerID: 1, HostID: 5, Size: 1<<32 - 5},
+				{ContainerID: 1<<32 - 4, HostID: 0, Size: 4},
+			},
+		},
+	}
+
+	for i, c := range cases {
+		mappings := rootlessIDMappings(1000, c.ranges)
+		if !reflect.DeepEqual(mappings, c.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, c.expected, mappings)
+		}
+	}
+}
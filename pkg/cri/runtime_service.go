@@ -1,52 +1,157 @@
 package cri
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
+	"runtime"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	ispec "github.com/opencontainers/image-spec/specs-go/v1"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/kubernetes/pkg/kubelet/cri/streaming"
 
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/caps"
+	"github.com/iximiuz/conman/pkg/cdi"
 	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/idmap"
 	"github.com/iximiuz/conman/pkg/oci"
+	"github.com/iximiuz/conman/pkg/procfs"
+	"github.com/iximiuz/conman/pkg/rlimit"
 	"github.com/iximiuz/conman/pkg/rollback"
+	"github.com/iximiuz/conman/pkg/seccomp"
 	"github.com/iximiuz/conman/pkg/shimutil"
 	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/sysctl"
+	"github.com/iximiuz/conman/pkg/timeutil"
+	"github.com/iximiuz/conman/pkg/usernet"
 )
 
 // RuntimeService is a service to manage container & sandbox runtimes.
 // While it resembles the CRI runtime interface, it does not follow it
 // strictly. The purpose of this service is to support the public-facing
 // CRI runtime service (see server.Server).
+// Cancellation of the ctx aborts the in-flight OCI runtime calls
+// and waits, rolling back the partially applied changes.
 type RuntimeService interface {
 	// CreateContainer prepares a new container bundle on disk
 	// and starts runc init, but does not start a specified process.
-	CreateContainer(ContainerOptions) (*container.Container, error)
+	CreateContainer(context.Context, ContainerOptions) (*container.Container, error)
 
 	// StartContainer actually starts a pre-defined process in
 	// a container created via CreateContainer() call.
-	StartContainer(container.ID) error
+	StartContainer(context.Context, container.ID) error
+
+	// RunContainer creates and starts a container as a single operation,
+	// i.e. if the start fails, the container gets removed. If startOnAttach
+	// is set, the start is postponed until the first attach session is
+	// established (see Attach()), so that no container output is lost.
+	// If nobody attaches in time, the container is removed.
+	RunContainer(
+		ctx context.Context,
+		opts ContainerOptions,
+		startOnAttach bool,
+	) (*container.Container, error)
 
 	// StopContainer signals the container to finish itself.
-	StopContainer(id container.ID, timeout time.Duration) error
+	StopContainer(ctx context.Context, id container.ID, timeout time.Duration) error
 
 	// Removes container from both conman and runc storages.
 	// If container has not been stopped yet, a force flag
 	// must be set. If container has already been removed, no
 	// error returned (i.e. idempotent behavior).
-	RemoveContainer(container.ID) error
+	RemoveContainer(context.Context, container.ID) error
 
-	ListContainers() ([]*container.Container, error)
+	// ListContainers returns the containers matching the filter.
+	// A nil filter matches every container.
+	ListContainers(context.Context, *ContainerFilter) ([]*container.Container, error)
 
 	// GetContainer returns the container doing a state request
 	// from the OCI runtime if applicable.
-	GetContainer(container.ID) (*container.Container, error)
+	GetContainer(context.Context, container.ID) (*container.Container, error)
+
+	// WaitContainer blocks until the container reaches the given status
+	// or any status following it in the container lifecycle (e.g. waiting
+	// for Running returns when the container is already Stopped).
+	WaitContainer(
+		ctx context.Context,
+		id container.ID,
+		status container.Status,
+	) (*container.Container, error)
+
+	// CopyFromContainer writes a tar archive of the path (a file or
+	// a directory) from the container rootfs to w.
+	CopyFromContainer(
+		ctx context.Context,
+		id container.ID,
+		path string,
+		w io.Writer,
+	) error
+
+	// CopyToContainer extracts a tar archive from r to the container
+	// rootfs. If path is an existing directory, the archive content is
+	// placed inside of it. Otherwise, the top-level archive entry is
+	// renamed to path.
+	CopyToContainer(
+		ctx context.Context,
+		id container.ID,
+		path string,
+		r io.Reader,
+	) error
+
+	// ContainerChanges lists the paths added, modified, or deleted
+	// in the container rootfs since it's been created from the source
+	// rootfs (image).
+	ContainerChanges(ctx context.Context, id container.ID) ([]archive.Change, error)
+
+	// ContainerTop lists the processes of a running container.
+	ContainerTop(ctx context.Context, id container.ID) ([]*ContainerProcess, error)
+
+	// ExportContainer writes a tar archive of the whole container rootfs to w.
+	ExportContainer(ctx context.Context, id container.ID, w io.Writer) error
+
+	// CommitContainer creates a new image from the container rootfs.
+	// If the container has been created from a stored image, the new
+	// image adds a layer with the rootfs changes on top of it. Otherwise,
+	// the new image consists of a single layer with the whole rootfs.
+	CommitContainer(
+		ctx context.Context,
+		id container.ID,
+		opts CommitOptions,
+	) (*storage.Image, error)
+
+	// CreateVolume creates a named volume. Volumes are also created
+	// on the fly when a container refers to a missing one.
+	CreateVolume(
+		ctx context.Context,
+		name string,
+		labels map[string]string,
+	) (*Volume, error)
+
+	GetVolume(ctx context.Context, name string) (*Volume, error)
+
+	ListVolumes(ctx context.Context) ([]*Volume, error)
+
+	// RemoveVolume removes a volume with its content. A volume
+	// referenced by a container (in any state) can't be removed.
+	RemoveVolume(ctx context.Context, name string) error
+
+	// ResolveContainerID turns a container reference, i.e. a full ID,
+	// a name, or a unique ID prefix, into the container ID.
+	ResolveContainerID(ref string) (container.ID, error)
 
 	// TODO: UpdateContainerResources
 	// TODO: ReopenContainerLog
@@ -62,10 +167,14 @@ type RuntimeService interface {
 
 // runtimeService implements RuntimeService interface.
 // Some design considerations:
-//   - runtimeService methods are thread-safe. There is a common locker
-//     on runtimeService instance protecting from concurrent container
-//     modifications. Given this lock, dependencies like container.Map,
-//     storage.ContainerStore can be simplified and omit their own locking.
+//   - runtimeService methods are thread-safe. Every lifecycle operation
+//     holds a per-container lock (see containerLocks), so operations on
+//     the same container are serialized, while operations on different
+//     containers run in parallel. The locks are held across slow OCI
+//     runtime calls, hence read-only methods (ListContainers,
+//     GetContainer) never wait for them. If a container is busy, its
+//     cached state is returned instead of a fresh one. The container.Map
+//     and container.Container are concurrency-safe on their own.
 //   - runtimeService tracks container states on its own. It uses ContainerStore
 //     to write a JSON-serialized container state inside of container base dir.
 //     Since atomic write of the state and runc execution is not possible,
@@ -74,6 +183,7 @@ type RuntimeService interface {
 //     back. However, during a cascading failure, the state saved in the container
 //     dir and the state of the container in accordance with runc might diverge.
 //     The state restoring logic should try to fix the introduced discrepancy.
+//     The state is written to disk only while holding the container lock.
 //   - ContainerStore is the only source of truth. Only containers tracked by
 //     the store are managed by conman. I.e. if someone uses the same runc config
 //     to create extra containers, the change will not be visible to conman.
@@ -81,31 +191,117 @@ type RuntimeService interface {
 //     manually (or by any other means) will introduce inconsistency in the conman
 //     tracked state and the actual state of the containers.
 type runtimeService struct {
-	sync.Mutex
-
 	runtime   oci.Runtime
 	cstore    storage.ContainerStore
+	istore    storage.ImageStore
+	vstore    storage.VolumeStore
 	logDir    string
 	exitDir   string
 	attachDir string
-
-	cmap *container.Map
+	config    RuntimeConfig
+
+	cmap  *container.Map
+	locks *containerLocks
+
+	// Containers created by RunContainer() waiting for the
+	// first attach session to be started.
+	pendingMu     sync.Mutex
+	pendingStarts map[container.ID]*time.Timer
+
+	// Final states of the auto-removed containers, kept for the
+	// late WaitContainer() callers (see watchAutoRemove()).
+	removedMu sync.Mutex
+	removed   map[container.ID]*container.Container
+
+	// Containers referencing every volume by the volume name.
+	// Volumes are created and removed while holding the mutex.
+	volumesMu  sync.Mutex
+	volumeRefs map[string]map[container.ID]bool
+
+	// Containers sharing the namespaces of every (target) container.
+	namespacesMu  sync.Mutex
+	namespaceRefs map[container.ID]map[container.ID]bool
+
+	// Containers the OOM killer has been seen in (cgroup v1).
+	oomMu     sync.Mutex
+	oomEvents map[container.ID]bool
+
+	// Host IDs of the user namespace containers.
+	uidAlloc     *idmap.Allocator
+	gidAlloc     *idmap.Allocator
+	idmappedOnce sync.Once
+	idmappedOK   bool
 }
 
+// Rollback actions must not be affected by the cancellation
+// of the original request, so they have their own deadline.
+const rollbackTimeout = 5 * time.Second
+
+// How often WaitContainer() checks the container state.
+const waitContainerInterval = 200 * time.Millisecond
+
+// How long a container created by RunContainer(startOnAttach=true)
+// waits for the first attach session before being discarded.
+const startOnAttachTimeout = 30 * time.Second
+
+// How long the final state of an auto-removed container
+// is still reported by WaitContainer().
+const autoRemovedStateTTL = time.Minute
+
 func NewRuntimeService(
 	runtime oci.Runtime,
 	cstore storage.ContainerStore,
+	istore storage.ImageStore,
+	vstore storage.VolumeStore,
 	logDir string,
 	exitDir string,
 	attachDir string,
+	config RuntimeConfig,
 ) (RuntimeService, error) {
+	config.MountDenylist = resolvePaths(config.MountDenylist)
+	defaultCaps, err := caps.NormalizeList(config.DefaultCapabilities)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad default capabilities")
+	}
+	config.DefaultCapabilities = defaultCaps
+	if _, err := rlimit.Merge(nil, config.DefaultRlimits); err != nil {
+		return nil, errors.Wrap(err, "bad default rlimits")
+	}
+	for _, p := range config.AllowedUnsafeSysctls {
+		if !sysctl.IsValidPattern(p) {
+			return nil, errors.Errorf("bad allowed unsafe sysctl %q", p)
+		}
+	}
+	if config.Rootless {
+		driver, err := usernet.Resolve(config.RootlessNetwork)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad rootless network")
+		}
+		if driver == usernet.DriverNone {
+			logrus.Warn("No network helper (pasta or slirp4netns), rootless containers get only the loopback")
+		}
+		config.RootlessNetwork = driver
+	}
+
 	rs := &runtimeService{
 		runtime:   runtime,
 		cstore:    cstore,
+		istore:    istore,
+		vstore:    vstore,
 		logDir:    logDir,
 		exitDir:   exitDir,
 		attachDir: attachDir,
+		config:    config,
 		cmap:      container.NewMap(),
+		locks:     newContainerLocks(),
+
+		pendingStarts: make(map[container.ID]*time.Timer),
+		removed:       make(map[container.ID]*container.Container),
+		volumeRefs:    make(map[string]map[container.ID]bool),
+		namespaceRefs: make(map[container.ID]map[container.ID]bool),
+		oomEvents:     make(map[container.ID]bool),
+		uidAlloc:      idmap.NewAllocator(config.SubUIDs),
+		gidAlloc:      idmap.NewAllocator(config.SubGIDs),
 	}
 	if err := rs.restore(); err != nil {
 		return nil, err
@@ -114,11 +310,9 @@ func NewRuntimeService(
 }
 
 func (rs *runtimeService) CreateContainer(
+	ctx context.Context,
 	opts ContainerOptions,
 ) (cont *container.Container, err error) {
-	rs.Lock()
-	defer rs.Unlock()
-
 	rb := rollback.New()
 	defer func() { _ = err == nil || rb.Execute() }()
 
@@ -127,41 +321,208 @@ func (rs *runtimeService) CreateContainer(
 		contID,
 		opts.Name,
 		rs.containerLogFile(contID),
+		opts.Labels,
+		opts.Annotations,
 	)
 	if err != nil {
 		return
 	}
 
+	rootfs, env, err := rs.containerRootfsSource(cont, opts)
+	if err != nil {
+		return
+	}
+
+	if err = cont.SetMounts(opts.Mounts); err != nil {
+		return
+	}
+
+	baseCaps := rs.config.DefaultCapabilities
+	if opts.Privileged {
+		baseCaps = caps.Host()
+	}
+	capabilities, err := caps.Merge(baseCaps, opts.CapAdd, opts.CapDrop)
+	if err != nil {
+		return
+	}
+	cont.SetCapabilities(capabilities)
+	cont.SetPrivileged(opts.Privileged)
+	cont.SetNoNewPrivileges(opts.NoNewPrivileges)
+	cont.SetAutoRemove(opts.AutoRemove)
+
+	if opts.SeccompProfile == "" {
+		opts.SeccompProfile = seccomp.ProfileRuntimeDefault
+		if opts.Privileged {
+			opts.SeccompProfile = seccomp.ProfileUnconfined
+		}
+	}
+	seccompProfile, err := seccomp.ResolveProfile(opts.SeccompProfile, capabilities)
+	if err != nil {
+		return
+	}
+	cont.SetSeccompProfile(opts.SeccompProfile)
+
+	uidMap, gidMap, err := rs.allocateIDMappings(opts, rb)
+	if err != nil {
+		return
+	}
+	cont.SetIDMappings(uidMap, gidMap)
+
+	namespaces := opts.Namespaces
+	if namespaces.Shares() {
+		namespaces.TargetID, err = rs.ResolveContainerID(string(namespaces.TargetID))
+		if err != nil {
+			err = errors.Wrapf(err, "namespace target container %s", opts.Namespaces.TargetID)
+			return
+		}
+	}
+	if err = cont.SetNamespaces(namespaces); err != nil {
+		return
+	}
+	if err = rs.validateSysctls(opts.Sysctls, namespaces); err != nil {
+		return
+	}
+	cont.SetSysctls(opts.Sysctls)
+
+	for _, name := range opts.CDIDevices {
+		if _, _, err = cdi.ParseQualifiedName(name); err != nil {
+			return
+		}
+	}
+	if err = cont.SetDevices(opts.Devices, opts.CDIDevices); err != nil {
+		return
+	}
+
+	rlimits, err := rlimit.Merge(rs.config.DefaultRlimits, opts.Rlimits)
+	if err != nil {
+		return
+	}
+	if err = cont.SetRlimits(rlimits); err != nil {
+		return
+	}
+	if err = cont.SetOOMScoreAdj(opts.OOMScoreAdj); err != nil {
+		return
+	}
+
+	if opts.TerminationMessagePath == "" {
+		opts.TerminationMessagePath = container.DefaultTerminationMessagePath
+	}
+	if err = cont.SetTerminationMessagePath(opts.TerminationMessagePath); err != nil {
+		return
+	}
+
+	maskedPaths, readonlyPaths, err := rs.resolveMaskedPaths(opts)
+	if err != nil {
+		return
+	}
+	if err = cont.SetMaskedPaths(maskedPaths, readonlyPaths); err != nil {
+		return
+	}
+
+	sharedNamespaces, err := rs.joinNamespaces(ctx, contID, namespaces, rb)
+	if err != nil {
+		return
+	}
+
+	// The lock has to be taken before the container becomes
+	// visible to the concurrent callers via the map.
+	unlock := rs.locks.lock(contID)
+	defer unlock()
+	rb.Add(func() { rs.locks.forget(contID) })
+
 	if err = rs.cmap.Add(cont, rb); err != nil {
 		return
 	}
 
+	// The container directory removal must not precede it.
+	rb.Add(func() { rs.unmountMappedRootfs(contID) })
+
 	hcont, err := rs.cstore.CreateContainer(cont.ID(), rb)
 	if err != nil {
 		return
 	}
 
+	mounts, err := rs.specMounts(cont, rootfs, rb)
+	if err != nil {
+		return
+	}
+
+	devices, err := rs.resolveDevices(cont)
+	if err != nil {
+		return
+	}
+
+	// The rootless containers' rootfs is owned by the
+	// daemon user, i.e. by the container root already.
+	remapRootfs := len(uidMap) > 0 && !rs.config.Rootless
+
+	rootPath := hcont.RootfsDir()
+	if remapRootfs && rs.idmappedMounts() {
+		rootPath = mappedRootfsDir(hcont)
+	}
+
 	spec, err := oci.NewSpec(oci.SpecOptions{
 		Command:      opts.Command,
 		Args:         opts.Args,
-		RootPath:     hcont.RootfsDir(),
+		Env:          env,
+		ExtraEnv:     devices.env,
+		RootPath:     rootPath,
 		RootReadonly: opts.RootfsReadonly,
+		Annotations:  opts.Annotations,
+		Mounts:       append(append(mounts, devices.mounts...), specTerminationLog(cont, hcont)),
+
+		RootfsPropagation: rootfsPropagation(cont.Mounts()),
+		Seccomp:           seccompProfile,
+		Capabilities:      capabilities,
+		NoNewPrivileges:   opts.NoNewPrivileges,
+		Rlimits:           specRlimits(cont.Rlimits()),
+		OOMScoreAdj:       specOOMScoreAdj(cont.OOMScoreAdj()),
+		Privileged:        opts.Privileged,
+		UIDMappings:       specIDMappings(uidMap),
+		GIDMappings:       specIDMappings(gidMap),
+		SharedNamespaces:  sharedNamespaces,
+		MaskedPaths:       cont.MaskedPaths(),
+		ReadonlyPaths:     cont.ReadonlyPaths(),
+		Sysctls:           opts.Sysctls,
+		Devices:           devices.devices,
+		Hooks:             devices.hooks,
+		Rootless:          rs.config.Rootless,
+		CgroupsDelegated:  rs.config.CgroupsDelegated,
 	})
 	if err != nil {
 		return
 	}
 
-	err = rs.cstore.CreateContainerBundle(cont.ID(), spec, opts.RootfsPath)
+	err = rs.cstore.CreateContainerBundle(cont.ID(), spec, rootfs)
 	if err != nil {
 		return
 	}
 
+	if err = createTerminationLog(hcont); err != nil {
+		return
+	}
+
+	if err = snapshotRootfs(hcont); err != nil {
+		return
+	}
+
+	if remapRootfs {
+		if err = rs.prepareMappedRootfs(cont, hcont, rootPath); err != nil {
+			return
+		}
+	}
+
 	err = rs.optimisticChangeContainerStatus(cont, container.Created)
 	if err != nil {
 		return
 	}
 
-	_, err = rs.runtime.CreateContainer(
+	// Shimmy might have managed to create the runc container
+	// even if the call below fails (e.g. on ctx cancellation).
+	rb.Add(func() { rs.deleteRuntimeContainer(contID) })
+
+	pid, err := rs.runtime.CreateContainer(
+		ctx,
 		cont.ID(),
 		hcont.BundleDir(),
 		cont.LogPath(),
@@ -175,20 +536,32 @@ func (rs *runtimeService) CreateContainer(
 		return
 	}
 
-	err = cont.SetCreatedAt(time.Now())
+	if err = rs.startNetwork(cont, hcont, pid, rb); err != nil {
+		return
+	}
+
+	rs.watchOOM(cont, pid)
+
+	if err = cont.SetCreatedAt(time.Now()); err != nil {
+		return
+	}
+
+	if cont.AutoRemove() {
+		rs.watchAutoRemove(contID)
+	}
 	return
 }
 
 func (rs *runtimeService) StartContainer(
+	ctx context.Context,
 	id container.ID,
-) error {
-	rs.Lock()
-	defer rs.Unlock()
-
-	cont := rs.cmap.Get(id)
-	if cont == nil {
-		return errors.New("container not found")
+) (err error) {
+	cont, unlock, err := rs.lockContainer(id)
+	if err != nil {
+		return err
 	}
+	defer unlock()
+
 	if err := assertStatus(cont.Status(), container.Created); err != nil {
 		return err
 	}
@@ -196,29 +569,60 @@ func (rs *runtimeService) StartContainer(
 	if err := rs.optimisticChangeContainerStatus(cont, container.Running); err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil && cont.Status() != container.Stopped {
+			rs.rollbackContainerStatusNoLock(cont, container.Created)
+		}
+	}()
 
-	if err := rs.runtime.StartContainer(cont.ID()); err != nil {
+	if err := rs.runtime.StartContainer(ctx, cont.ID()); err != nil {
+		rs.markCannotRunNoLock(cont, err)
 		return err
 	}
 
-	if err := rs.waitContainerStartedNoLock(id); err != nil {
+	if err := rs.waitContainerStartedNoLock(ctx, cont); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
 		return nil
 	}
 
 	return cont.SetStartedAt(time.Now())
 }
 
+func (rs *runtimeService) RunContainer(
+	ctx context.Context,
+	opts ContainerOptions,
+	startOnAttach bool,
+) (*container.Container, error) {
+	cont, err := rs.CreateContainer(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if startOnAttach {
+		rs.postponeStart(cont.ID())
+		return cont, nil
+	}
+
+	if err := rs.StartContainer(ctx, cont.ID()); err != nil {
+		rs.discardContainer(cont.ID())
+		return nil, err
+	}
+	return cont, nil
+}
+
 func (rs *runtimeService) StopContainer(
+	ctx context.Context,
 	id container.ID,
 	timeout time.Duration,
-) error {
-	rs.Lock()
-	defer rs.Unlock()
-
-	cont := rs.cmap.Get(id)
-	if cont == nil {
-		return errors.New("container not found")
+) (err error) {
+	cont, unlock, err := rs.lockContainer(id)
+	if err != nil {
+		return err
 	}
+	defer unlock()
+
 	if err := assertStatus(
 		cont.Status(), container.Created, container.Running); err != nil {
 		return err
@@ -231,11 +635,17 @@ func (rs *runtimeService) StopContainer(
 
 	// TODO: test for this logic!
 
+	prevStatus := cont.Status()
 	if err := rs.optimisticChangeContainerStatus(cont, container.Stopped); err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			rs.rollbackContainerStatusNoLock(cont, prevStatus)
+		}
+	}()
 
-	if err := rs.runtime.KillContainer(cont.ID(), syscall.SIGTERM); err != nil {
+	if err := rs.runtime.KillContainer(ctx, cont.ID(), syscall.SIGTERM); err != nil {
 		return err
 	}
 
@@ -244,9 +654,10 @@ func (rs *runtimeService) StopContainer(
 		250 * time.Millisecond,
 	}
 	for _, d := range delays {
-		time.Sleep(d)
-		cont, err := rs.getContainerNoLock(id)
-		if err != nil {
+		if err := timeutil.Sleep(ctx, d); err != nil {
+			return err
+		}
+		if err := rs.refreshContainerNoLock(ctx, cont); err != nil {
 			return err
 		}
 		if cont.Status() == container.Stopped {
@@ -254,13 +665,14 @@ func (rs *runtimeService) StopContainer(
 		}
 	}
 
-	if err := rs.runtime.KillContainer(cont.ID(), syscall.SIGKILL); err != nil {
+	if err := rs.runtime.KillContainer(ctx, cont.ID(), syscall.SIGKILL); err != nil {
 		return err
 	}
 	for _, d := range delays {
-		time.Sleep(d)
-		cont, err := rs.getContainerNoLock(id)
-		if err != nil {
+		if err := timeutil.Sleep(ctx, d); err != nil {
+			return err
+		}
+		if err := rs.refreshContainerNoLock(ctx, cont); err != nil {
 			return err
 		}
 		if cont.Status() == container.Stopped {
@@ -271,14 +683,22 @@ func (rs *runtimeService) StopContainer(
 	return errors.New("Cannot kill container. TODO: use os.kill() to force kill")
 }
 
-func (rs *runtimeService) RemoveContainer(id container.ID) error {
-	rs.Lock()
-	defer rs.Unlock()
-
-	cont := rs.cmap.Get(id)
-	if cont == nil {
+func (rs *runtimeService) RemoveContainer(
+	ctx context.Context,
+	id container.ID,
+) error {
+	cont, unlock, err := rs.lockContainer(id)
+	if err == container.ErrNotFound {
 		return nil
 	}
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := rs.assertNoNamespaceDependents(id); err != nil {
+		return err
+	}
 
 	// Atomically mark container removed
 	if err := rs.cstore.ContainerStateDeleteAtomic(id); err != nil {
@@ -286,26 +706,49 @@ func (rs *runtimeService) RemoveContainer(id container.ID) error {
 	}
 
 	// Initiate actual removal
-	if err := rs.runtime.DeleteContainer(cont.ID()); err != nil {
+	if err := rs.runtime.DeleteContainer(ctx, cont.ID()); err != nil {
 		return err
 	}
 
 	// Cleanup leftovers
+	rs.takePendingStart(id)
 	rs.cmap.Del(id)
+	rs.locks.forget(id)
+	rs.releaseVolumes(id, cont.Mounts())
+	rs.releaseNamespaces(id, cont.Namespaces())
+	rs.releaseIDMappings(cont)
+	rs.unmountMappedRootfs(id)
+	rs.stopNetwork(id)
+	rs.forgetOOMEvents(id)
 	return rs.cstore.DeleteContainer(id)
 }
 
-func (rs *runtimeService) ListContainers() ([]*container.Container, error) {
-	rs.Lock()
-	defer rs.Unlock()
-
+func (rs *runtimeService) ListContainers(
+	ctx context.Context,
+	filter *ContainerFilter,
+) ([]*container.Container, error) {
 	var cs []*container.Container
 	for _, c := range rs.cmap.All() {
-		c, err := rs.getContainerNoLock(c.ID())
+		// Don't bother the OCI runtime with the irrelevant containers.
+		if !filter.matchMeta(c) {
+			continue
+		}
+
+		err := rs.syncContainer(ctx, c)
+		if err == container.ErrNotFound {
+			continue // removed in the meantime
+		}
 		if err != nil {
 			return nil, err
 		}
-		cs = append(cs, c)
+		if c.CreatedAt() == "" {
+			// Still being created (hence not synced either).
+			continue
+		}
+
+		if filter.matchState(c) {
+			cs = append(cs, c)
+		}
 	}
 
 	sort.SliceStable(cs, func(i, j int) bool {
@@ -321,65 +764,402 @@ func (rs *runtimeService) ListContainers() ([]*container.Container, error) {
 }
 
 func (rs *runtimeService) GetContainer(
+	ctx context.Context,
 	id container.ID,
 ) (*container.Container, error) {
-	rs.Lock()
-	defer rs.Unlock()
-	return rs.getContainerNoLock(id)
+	cont := rs.cmap.Get(id)
+	if cont == nil {
+		return nil, container.ErrNotFound
+	}
+	if err := rs.syncContainer(ctx, cont); err != nil {
+		return nil, err
+	}
+	return cont, nil
 }
 
-func (rs *runtimeService) getContainerNoLock(
+// WaitContainer relies only on the container states observed from
+// the OCI runtime. The cached state of a busy container can be an
+// optimistic one (e.g. stopped while SIGTERM is yet to be sent).
+func (rs *runtimeService) WaitContainer(
+	ctx context.Context,
 	id container.ID,
+	status container.Status,
 ) (*container.Container, error) {
+	for {
+		cont := rs.cmap.Get(id)
+		if cont == nil {
+			// Auto-removed containers are stopped for good.
+			if cont := rs.autoRemovedContainer(id); cont != nil {
+				return cont, nil
+			}
+			return nil, container.ErrNotFound
+		}
+		synced, err := rs.trySyncContainer(ctx, cont)
+		if err != nil {
+			return nil, err
+		}
+		if synced && cont.Status() != container.Initial && cont.Status() >= status {
+			return cont, nil
+		}
+		if err := timeutil.Sleep(ctx, waitContainerInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Copying doesn't hold the container lock, so a slow client can't block
+// the container lifecycle operations. The container paths are resolved
+// within the rootfs or the bind (volume) mount sources they belong to
+// (see containerPathRoot()), so the symlinks can't lead elsewhere on
+// the host filesystem. The files are accessed relative to the opened
+// parent directories without following symlinks, hence the running
+// container can't redirect the copying by swapping a path component
+// for a symlink meanwhile either (see archive.Tar() and Untar()).
+func (rs *runtimeService) CopyFromContainer(
+	ctx context.Context,
+	id container.ID,
+	path string,
+	w io.Writer,
+) error {
+	root, rel, err := rs.containerPathRoot(id, path)
+	if err != nil {
+		return err
+	}
+
+	src, err := fsutil.SecureJoin(root, rel)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(src); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("%s: no such file or directory", path)
+		}
+		return err
+	}
+
+	// The symlink (if any) is followed by SecureJoin() above,
+	// it's resolved within root once again while archiving.
+	resolved, err := filepath.Rel(root, src)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(filepath.Clean("/" + path))
+	if name == "/" {
+		name = "."
+	}
+	return archive.Tar(w, root, resolved, name, rs.pathIDMappings(id, root))
+}
+
+func (rs *runtimeService) CopyToContainer(
+	ctx context.Context,
+	id container.ID,
+	path string,
+	r io.Reader,
+) error {
+	root, rel, err := rs.containerPathRoot(id, path)
+	if err != nil {
+		return err
+	}
+
+	dir, rename, err := archive.Target(root, rel)
+	if err != nil {
+		return err
+	}
+	return archive.Untar(r, root, dir, rename, rs.pathIDMappings(id, root))
+}
+
+func (rs *runtimeService) ContainerChanges(
+	ctx context.Context,
+	id container.ID,
+) ([]archive.Change, error) {
 	cont := rs.cmap.Get(id)
 	if cont == nil {
-		return nil, errors.New("container not found")
+		return nil, container.ErrNotFound
 	}
 
-	// Request container state
-	state, err := rs.runtime.ContainerState(cont.ID())
+	return rs.rootfsChanges(cont)
+}
+
+func (rs *runtimeService) ContainerTop(
+	ctx context.Context,
+	id container.ID,
+) ([]*ContainerProcess, error) {
+	cont, err := rs.GetContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := assertStatus(cont.Status(), container.Running); err != nil {
+		return nil, err
+	}
+
+	pids, err := rs.runtime.ContainerPids(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rootfs, err := rs.containerRootfs(id)
+	if err != nil {
+		return nil, err
+	}
+	users := passwdUsers(rootfs)
+
+	var procs []*ContainerProcess
+	for _, pid := range pids {
+		p, err := procfs.ReadProcess(pid)
+		if err != nil {
+			if procfs.IsNotExist(err) {
+				continue // exited meanwhile
+			}
+			return nil, err
+		}
+
+		uid := p.UID
+		if uidMap := cont.UIDMappings(); len(uidMap) > 0 {
+			if cuid, ok := idmap.ContainerID(uidMap, uid); ok {
+				uid = cuid
+			}
+		}
+		user, ok := users[uid]
+		if !ok {
+			user = strconv.FormatUint(uint64(uid), 10)
+		}
+		procs = append(procs, &ContainerProcess{Process: *p, User: user})
+	}
+	return procs, nil
+}
+
+func (rs *runtimeService) ExportContainer(
+	ctx context.Context,
+	id container.ID,
+	w io.Writer,
+) error {
+	rootfs, err := rs.containerRootfs(id)
+	if err != nil {
+		return err
+	}
+	return archive.Tar(w, rootfs, "/", ".", rs.pathIDMappings(id, rootfs))
+}
+
+func (rs *runtimeService) CommitContainer(
+	ctx context.Context,
+	id container.ID,
+	opts CommitOptions,
+) (*storage.Image, error) {
+	// Held to prevent the removal, the running container
+	// can still modify the rootfs in the meantime though.
+	cont, unlock, err := rs.lockContainer(id)
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
+
+	hcont, err := rs.cstore.GetContainer(id)
+	if err != nil {
+		return nil, err
+	}
+	if hcont == nil {
+		return nil, container.ErrNotFound
+	}
+
+	spec, err := rs.cstore.ContainerSpec(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rootfs := hcont.RootfsDir()
+	ids := rs.rootfsIDMappings(cont, hcont)
+	config := ispec.Image{
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		RootFS:       ispec.RootFS{Type: "layers"},
+	}
+	var layers []ispec.Descriptor
+	var layer ispec.Descriptor
+
+	if cont.ImageID() != "" {
+		img, err := rs.istore.GetImage(cont.ImageID())
+		if err != nil {
+			return nil, errors.Wrap(err, "can't find container image")
+		}
+		parent, err := rs.istore.ImageConfig(img)
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := rs.istore.ImageManifest(img)
+		if err != nil {
+			return nil, err
+		}
+		config, layers = *parent, manifest.Layers
+
+		changes, err := rs.rootfsChanges(cont)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't compute rootfs changes")
+		}
+		layer, err = rs.putLayer(func(w io.Writer) error {
+			return archive.ChangesTar(w, rootfs, changes, ids)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		layer, err = rs.putLayer(func(w io.Writer) error {
+			return archive.Tar(w, rootfs, "/", ".", ids)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	layers = append(layers, layer)
+	config.Created = &now
+	config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, layer.Digest)
+	config.History = append(config.History, ispec.History{
+		Created:   &now,
+		CreatedBy: strings.Join(spec.Process.Args, " "),
+		Author:    opts.Author,
+		Comment:   opts.Message,
+	})
+	if opts.Author != "" {
+		config.Author = opts.Author
+	}
+
+	config.Config.Entrypoint = nil
+	config.Config.Cmd = spec.Process.Args
+	config.Config.Env = spec.Process.Env
+	config.Config.WorkingDir = spec.Process.Cwd
+	config.Config.Labels = mergeLabels(config.Config.Labels, cont.Labels(), opts.Labels)
+
+	var names []string
+	if opts.Name != "" {
+		names = append(names, opts.Name)
+	}
+	return rs.istore.CreateImage(config, layers, names, func(dst string) error {
+		if ids == nil {
+			return fsutil.CopyDir(rootfs+"/.", dst)
+		}
+		// The shifted ownership is mapped back on the way.
+		pr, pw := io.Pipe()
+		go func() { pw.CloseWithError(archive.Tar(pw, rootfs, "/", ".", ids)) }()
+		defer pr.Close()
+		return archive.Untar(pr, dst, "/", "", nil)
+	})
+}
+
+func (rs *runtimeService) ResolveContainerID(ref string) (container.ID, error) {
+	cont, err := rs.cmap.Resolve(ref)
+	if err == container.ErrNotFound {
+		if cont := rs.autoRemovedContainer(container.ID(ref)); cont != nil {
+			return cont.ID(), nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return cont.ID(), nil
+}
+
+// lockContainer acquires the container lock and returns the container
+// if it still exists. The caller is responsible for calling unlock().
+func (rs *runtimeService) lockContainer(
+	id container.ID,
+) (*container.Container, func(), error) {
+	if rs.cmap.Get(id) == nil {
+		return nil, nil, container.ErrNotFound
+	}
+
+	unlock := rs.locks.lock(id)
+	cont := rs.cmap.Get(id)
+	if cont == nil {
+		unlock()
+		return nil, nil, container.ErrNotFound
+	}
+	return cont, unlock, nil
+}
+
+// syncContainer refreshes the container state from the OCI runtime
+// unless the container is busy with another lifecycle operation.
+// In the latter case, the cached state is used as is since the
+// ongoing operation is going to update it anyway.
+func (rs *runtimeService) syncContainer(
+	ctx context.Context,
+	cont *container.Container,
+) error {
+	_, err := rs.trySyncContainer(ctx, cont)
+	return err
+}
+
+// trySyncContainer is syncContainer telling if the container
+// state has actually been refreshed.
+func (rs *runtimeService) trySyncContainer(
+	ctx context.Context,
+	cont *container.Container,
+) (bool, error) {
+	unlock, ok := rs.locks.tryLock(cont.ID())
+	if !ok {
+		return false, nil
+	}
+	defer unlock()
+
+	if rs.cmap.Get(cont.ID()) != cont {
+		return false, container.ErrNotFound
+	}
+	return true, rs.refreshContainerNoLock(ctx, cont)
+}
+
+// refreshContainerNoLock requests the container state from the OCI
+// runtime and persists it. The container lock must be held by the caller.
+func (rs *runtimeService) refreshContainerNoLock(
+	ctx context.Context,
+	cont *container.Container,
+) error {
+	// Request container state
+	state, err := rs.runtime.ContainerState(ctx, cont.ID())
+	if err != nil {
+		return err
+	}
 
 	// Set container status from state
 	status, err := container.StatusFromString(state.Status)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	cont.SetStatus(status)
 
 	// Set container exit code if applicable
 	if cont.Status() == container.Stopped {
-		ts, err := rs.parseContainerExitFile(id)
+		ts, err := rs.parseContainerExitFile(cont.ID())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cont.SetFinishedAt(ts.At())
 
 		if ts.IsSignaled() {
 			cont.SetExitCode(127 + ts.Signal())
+			cont.SetSignal(ts.Signal())
 		} else {
 			cont.SetExitCode(ts.ExitCode())
 		}
+
+		// The cached status can be an optimistic one (see StopContainer()),
+		// so the termination is told by the reason not yet set.
+		if cont.Reason() == "" {
+			rs.setTermination(cont, ts)
+		}
 	}
 
 	blob, err := cont.MarshalJSON()
 	if err != nil {
-		return nil, err
-	}
-	if err := rs.cstore.ContainerStateWriteAtomic(id, blob); err != nil {
-		return nil, err
+		return err
 	}
-
-	return cont, nil
+	return rs.cstore.ContainerStateWriteAtomic(cont.ID(), blob)
 }
 
+// restore is called only once, before the service starts
+// serving requests, hence no container locking is needed.
 func (rs *runtimeService) restore() error {
-	rs.Lock()
-	defer rs.Unlock()
-
 	hconts, err := rs.cstore.FindContainers()
 	if err != nil {
 		return err
@@ -387,6 +1167,8 @@ func (rs *runtimeService) restore() error {
 
 	purgeBrokenContainer := func(id container.ID) {
 		rs.cmap.Del(id)
+		rs.unmountMappedRootfs(id)
+		rs.stopNetwork(id)
 		if err := rs.cstore.DeleteContainer(id); err != nil {
 			logrus.WithError(err).Warn("failed to purge broken container")
 		}
@@ -411,18 +1193,29 @@ func (rs *runtimeService) restore() error {
 			continue
 		}
 
-		cont, err = rs.getContainerNoLock(h.ContainerID())
-		if err != nil {
+		if err := rs.refreshContainerNoLock(context.Background(), cont); err != nil {
 			logrus.WithError(err).Warn("failed to update container state")
 			purgeBrokenContainer(h.ContainerID())
 			continue
 		}
+
+		rs.restoreVolumeRefs(cont)
+		rs.restoreNamespaceRefs(cont)
+		rs.restoreIDMappings(cont)
+		rs.restoreOOMWatch(cont)
+
+		if cont.AutoRemove() {
+			rs.watchAutoRemove(cont.ID())
+		}
 	}
 
 	return nil
 }
 
-func (rs *runtimeService) waitContainerStartedNoLock(id container.ID) error {
+func (rs *runtimeService) waitContainerStartedNoLock(
+	ctx context.Context,
+	cont *container.Container,
+) error {
 	delays := []time.Duration{
 		250 * time.Millisecond,
 		250 * time.Millisecond,
@@ -432,12 +1225,13 @@ func (rs *runtimeService) waitContainerStartedNoLock(id container.ID) error {
 	}
 	status := container.Unknown
 	for _, d := range delays {
-		time.Sleep(d)
-		cont, err := rs.getContainerNoLock(id)
-		status = cont.Status()
-		if err != nil {
+		if err := timeutil.Sleep(ctx, d); err != nil {
+			return err
+		}
+		if err := rs.refreshContainerNoLock(ctx, cont); err != nil {
 			return err
 		}
+		status = cont.Status()
 		if status == container.Running {
 			return nil
 		}
@@ -463,6 +1257,176 @@ func (rs *runtimeService) optimisticChangeContainerStatus(
 	return rs.cstore.ContainerStateWriteAtomic(c.ID(), blob)
 }
 
+func (rs *runtimeService) postponeStart(id container.ID) {
+	rs.pendingMu.Lock()
+	defer rs.pendingMu.Unlock()
+
+	rs.pendingStarts[id] = time.AfterFunc(startOnAttachTimeout, func() {
+		if rs.takePendingStart(id) {
+			logrus.WithField("id", id).
+				Warn("nobody attached to the container in time, discarding it")
+			rs.discardContainer(id)
+		}
+	})
+}
+
+// takePendingStart reports whether the container is waiting for
+// the start on attach. Only the first caller gets true.
+func (rs *runtimeService) takePendingStart(id container.ID) bool {
+	rs.pendingMu.Lock()
+	defer rs.pendingMu.Unlock()
+
+	t, ok := rs.pendingStarts[id]
+	if ok {
+		t.Stop()
+		delete(rs.pendingStarts, id)
+	}
+	return ok
+}
+
+// watchAutoRemove removes the container once it has stopped.
+// The final state is kept for a while, so the concurrent (and
+// the late) waiters still get the exit status.
+func (rs *runtimeService) watchAutoRemove(id container.ID) {
+	go func() {
+		ctx := context.Background()
+		cont, err := rs.WaitContainer(ctx, id, container.Stopped)
+		if err != nil {
+			if err != container.ErrNotFound {
+				logrus.WithError(err).Warnf("failed to wait for auto-remove container %s", id)
+			}
+			return
+		}
+
+		rs.removedMu.Lock()
+		rs.removed[id] = cont
+		rs.removedMu.Unlock()
+		time.AfterFunc(autoRemovedStateTTL, func() {
+			rs.removedMu.Lock()
+			delete(rs.removed, id)
+			rs.removedMu.Unlock()
+		})
+
+		if err := rs.RemoveContainer(ctx, id); err != nil {
+			logrus.WithError(err).Warnf("failed to auto-remove container %s", id)
+		}
+	}()
+}
+
+// autoRemovedContainer returns the final state of the recently
+// auto-removed container, if any.
+func (rs *runtimeService) autoRemovedContainer(id container.ID) *container.Container {
+	rs.removedMu.Lock()
+	defer rs.removedMu.Unlock()
+	return rs.removed[id]
+}
+
+// discardContainer is a rollback of a failed RunContainer().
+func (rs *runtimeService) discardContainer(id container.ID) {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+
+	if cont := rs.cmap.Get(id); cont != nil && cont.Status() == container.Running {
+		if err := rs.StopContainer(ctx, id, 0); err != nil {
+			logrus.WithError(err).Warn("failed to stop discarded container")
+		}
+	}
+	if err := rs.RemoveContainer(ctx, id); err != nil {
+		logrus.WithError(err).Warn("failed to remove discarded container")
+	}
+}
+
+// rollbackContainerStatusNoLock reverts an optimistic status change
+// after a failed (or aborted) operation. The actual status is requested
+// from the OCI runtime since the operation could have partially succeeded.
+// The original ctx might be done already, hence a fresh one is used.
+func (rs *runtimeService) rollbackContainerStatusNoLock(
+	c *container.Container,
+	prev container.Status,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+
+	if err := rs.refreshContainerNoLock(ctx, c); err == nil {
+		return
+	}
+	if err := rs.optimisticChangeContainerStatus(c, prev); err != nil {
+		logrus.WithError(err).Warn("failed to roll back container status")
+	}
+}
+
+func (rs *runtimeService) deleteRuntimeContainer(id container.ID) {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+
+	if err := rs.runtime.DeleteContainer(ctx, id); err != nil {
+		logrus.WithError(err).Debug("failed to delete runtime container")
+	}
+}
+
+// containerRootfsSource figures out the rootfs the new container
+// is created from and the default process environment.
+func (rs *runtimeService) containerRootfsSource(
+	cont *container.Container,
+	opts ContainerOptions,
+) (rootfs string, env []string, err error) {
+	if opts.Image == "" {
+		if opts.RootfsPath == "" {
+			return "", nil, errors.New("either image or rootfs path is required")
+		}
+		cont.SetRootfs(opts.RootfsPath)
+		return opts.RootfsPath, nil, nil
+	}
+	if opts.RootfsPath != "" {
+		return "", nil, errors.New("image and rootfs path are mutually exclusive")
+	}
+
+	img, err := rs.istore.GetImage(opts.Image)
+	if err != nil {
+		return "", nil, err
+	}
+	config, err := rs.istore.ImageConfig(img)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rootfs = rs.istore.ImageRootfsDir(img)
+	cont.SetRootfs(rootfs)
+	cont.SetImage(opts.Image, img.ID)
+	return rootfs, config.Config.Env, nil
+}
+
+// putLayer stores the layer written by the write func as an image blob.
+func (rs *runtimeService) putLayer(
+	write func(io.Writer) error,
+) (ispec.Descriptor, error) {
+	pr, pw := io.Pipe()
+	go func() { pw.CloseWithError(write(pw)) }()
+
+	desc, err := rs.istore.PutBlob(ispec.MediaTypeImageLayer, pr)
+	// Unblocks the writer if the blob writing has failed.
+	pr.CloseWithError(errors.New("layer writing aborted"))
+	if err != nil {
+		return ispec.Descriptor{}, errors.Wrap(err, "can't write layer")
+	}
+	return desc, nil
+}
+
+func (rs *runtimeService) containerRootfs(id container.ID) (string, error) {
+	if rs.cmap.Get(id) == nil {
+		return "", container.ErrNotFound
+	}
+
+	hcont, err := rs.cstore.GetContainer(id)
+	if err != nil {
+		return "", err
+	}
+	if hcont == nil {
+		return "", container.ErrNotFound
+	}
+	return hcont.RootfsDir(), nil
+}
+
 func (rs *runtimeService) containerLogFile(id container.ID) string {
 	return path.Join(rs.logDir, string(id)+".log")
 }
@@ -484,13 +1448,137 @@ func (rs *runtimeService) parseContainerExitFile(id container.ID) (*shimutil.Ter
 }
 
 type ContainerOptions struct {
-	Name           string
-	Command        string
-	Args           []string
+	Name    string
+	Command string
+	Args    []string
+	// Either an image reference or a rootfs path.
+	Image          string
 	RootfsPath     string
 	RootfsReadonly bool
 	Stdin          bool
 	StdinOnce      bool
+	Labels         map[string]string
+	Annotations    map[string]string
+	Mounts         []container.Mount
+	// Seccomp profile name: unconfined, runtime/default, or
+	// localhost/<absolute path>. If empty, runtime/default
+	// (unconfined for the privileged containers).
+	SeccompProfile string
+	// Added to (dropped from) the daemon default capabilities.
+	// ALL stands for every capability supported by the host.
+	CapAdd  []string
+	CapDrop []string
+	// Grants all the capabilities and exposes the host devices.
+	Privileged      bool
+	NoNewPrivileges bool
+	// Runs the container in its own user namespace. The host IDs of
+	// the mappings are relative to the block allocated for the
+	// container from the subordinate IDs. The UID mappings default
+	// to idmap.DefaultMappings, the GID ones to the UID ones.
+	UserNamespace bool
+	UIDMappings   []idmap.Mapping
+	GIDMappings   []idmap.Mapping
+	// Namespaces shared with the host or another container
+	// (the target ID can be a name or a unique ID prefix).
+	Namespaces container.NamespaceOptions
+	// ProcMountDefault or ProcMountUnmasked.
+	ProcMount string
+	// Replace the daemon defaults unless nil.
+	MaskedPaths   []string
+	ReadonlyPaths []string
+	// Namespaced sysctls, safe or allowed by the daemon.
+	Sysctls map[string]string
+	// Host devices and the qualified names of the
+	// CDI devices (vendor.com/class=name).
+	Devices    []container.Device
+	CDIDevices []string
+	// Override the daemon default rlimits of the same types.
+	Rlimits []rlimit.Rlimit
+	// [-1000, 1000], 0 leaves the OCI runtime one.
+	OOMScoreAdj int
+	// Defaults to container.DefaultTerminationMessagePath.
+	TerminationMessagePath string
+	// Remove the container once it has stopped.
+	AutoRemove bool
+}
+
+type ContainerProcess struct {
+	procfs.Process
+	// User name from the container /etc/passwd or the UID.
+	User string
+}
+
+// RuntimeConfig holds the daemon-wide policies applied to every container.
+type RuntimeConfig struct {
+	// Host paths that can't be bind mounted, along with everything
+	// beneath them. The root (/) denies only itself.
+	MountDenylist []string
+	// Capabilities granted to every non-privileged container,
+	// unless dropped.
+	DefaultCapabilities []string
+	// Paths masked and mounted read-only in every container,
+	// unless overridden or unmasked.
+	MaskedPaths   []string
+	ReadonlyPaths []string
+	// Unsafe namespaced sysctls (or prefixes ending with *)
+	// the containers can set.
+	AllowedUnsafeSysctls []string
+	// Resource limits of every container process, unless overridden.
+	DefaultRlimits []rlimit.Rlimit
+	// Subordinate IDs for the user namespace containers.
+	SubUIDs []idmap.Range
+	SubGIDs []idmap.Range
+	// The daemon runs as an unprivileged user. Every container gets
+	// a user namespace with the daemon user mapped to the root.
+	Rootless bool
+	// The daemon user can create the container cgroups. Otherwise,
+	// the rootless containers get no cgroup limits.
+	CgroupsDelegated bool
+	// Network helper of the rootless containers (see usernet).
+	RootlessNetwork string
+	// Directories with the CDI spec files,
+	// the latter ones take precedence.
+	CDISpecDirs []string
+}
+
+// specRlimits replaces the runtime-tools defaults
+// even if no limits are set.
+func specRlimits(rlimits []rlimit.Rlimit) []rspec.POSIXRlimit {
+	rv := []rspec.POSIXRlimit{}
+	for _, r := range rlimits {
+		rv = append(rv, rspec.POSIXRlimit{Type: r.Type, Soft: r.Soft, Hard: r.Hard})
+	}
+	return rv
+}
+
+func specOOMScoreAdj(adj int) *int {
+	if adj == 0 {
+		return nil
+	}
+	return &adj
+}
+
+type CommitOptions struct {
+	// Image name (name:tag), optional.
+	Name string
+	// Added to the container labels.
+	Labels  map[string]string
+	Author  string
+	Message string
+}
+
+// mergeLabels merges the label sets, the latter ones take precedence.
+func mergeLabels(sets ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, set := range sets {
+		for k, v := range set {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
 }
 
 func assertStatus(actual container.Status, expected ...container.Status) error {
@@ -1,8 +1,14 @@
 package cri_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -13,6 +19,7 @@ import (
 	"github.com/iximiuz/conman/pkg/oci"
 	"github.com/iximiuz/conman/pkg/storage"
 	"github.com/iximiuz/conman/pkg/testutil"
+	"github.com/iximiuz/conman/pkg/timeutil"
 )
 
 var cfg *config.Config
@@ -29,6 +36,12 @@ func Test_NonInteractive_FullCycle_Simple(t *testing.T) {
 	cstore, teardown2 := newContainerStore(t)
 	defer teardown2()
 
+	istore, teardown3 := newImageStore(t)
+	defer teardown3()
+
+	vstore, teardown4 := newVolumeStore(t)
+	defer teardown4()
+
 	logdir := testutil.TempDir(t)
 	defer os.RemoveAll(logdir)
 
@@ -38,7 +51,9 @@ func Test_NonInteractive_FullCycle_Simple(t *testing.T) {
 	attachdir := testutil.TempDir(t)
 	defer os.RemoveAll(attachdir)
 
-	sut, err := cri.NewRuntimeService(ociRt, cstore, logdir, exitdir, attachdir)
+	ctx := context.Background()
+
+	sut, err := cri.NewRuntimeService(ociRt, cstore, istore, vstore, logdir, exitdir, attachdir, cri.RuntimeConfig{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -51,17 +66,17 @@ func Test_NonInteractive_FullCycle_Simple(t *testing.T) {
 		RootfsPath:     testutil.DataDir("rootfs_alpine"),
 		RootfsReadonly: true,
 	}
-	cont, err := sut.CreateContainer(opts)
+	cont, err := sut.CreateContainer(ctx, opts)
 	if err != nil {
 		t.Fatalf("cri.CreateContainer() failed.\nerr=%v\nargs=%+v\n", err, opts)
 	}
 	contID := cont.ID()
-	defer sut.StopContainer(contID, 500*time.Millisecond)
+	defer sut.StopContainer(ctx, contID, 500*time.Millisecond)
 
 	assertContainerStatus(t, sut, contID, container.Created)
 
 	// (2) Start container.
-	err = sut.StartContainer(contID)
+	err = sut.StartContainer(ctx, contID)
 	if err != nil {
 		t.Fatalf("cri.StartContainer() failed.\nerr=%v\n", err)
 	}
@@ -69,20 +84,28 @@ func Test_NonInteractive_FullCycle_Simple(t *testing.T) {
 	assertContainerStatus(t, sut, contID, container.Running)
 
 	// (3) Stop container.
-	err = sut.StopContainer(contID, 500*time.Millisecond)
+	err = sut.StopContainer(ctx, contID, 500*time.Millisecond)
 	if err != nil {
 		t.Fatalf("cri.StopContainer() failed.\nerr=%v\n", err)
 	}
 
 	assertContainerStatus(t, sut, contID, container.Stopped, 136) // 127 + SIGKILL
 
+	waited, err := sut.WaitContainer(ctx, contID, container.Stopped)
+	if err != nil {
+		t.Fatalf("cri.WaitContainer() failed.\nerr=%v\n", err)
+	}
+	if waited.Signal() != 9 {
+		t.Fatalf("signal is %v, expected SIGKILL\n", waited.Signal())
+	}
+
 	// (4) RemoveContainer.
-	err = sut.RemoveContainer(contID)
+	err = sut.RemoveContainer(ctx, contID)
 	if err != nil {
 		t.Fatalf("cri.RemoveContainer() failed.\nerr=%v\n", err)
 	}
 
-	_, err = sut.GetContainer(contID)
+	_, err = sut.GetContainer(ctx, contID)
 	if err == nil || err.Error() != "container not found" {
 		t.Fatalf("RemoveContainer() did not remove container.\nerr=%v\n", err)
 	}
@@ -97,6 +120,7 @@ func newOciRuntime(
 		cfg.ShimmyPath,
 		cfg.RuntimePath,
 		fsutil.EnsureExists(path.Join(tmp, "runc")),
+		false,
 	), func() { os.RemoveAll(tmp) }
 }
 
@@ -107,6 +131,20 @@ func newContainerStore(
 	return storage.NewContainerStore(root), func() { os.RemoveAll(root) }
 }
 
+func newImageStore(
+	t *testing.T,
+) (storage.ImageStore, func()) {
+	root := testutil.TempDir(t)
+	return storage.NewImageStore(root), func() { os.RemoveAll(root) }
+}
+
+func newVolumeStore(
+	t *testing.T,
+) (storage.VolumeStore, func()) {
+	root := testutil.TempDir(t)
+	return storage.NewVolumeStore(root), func() { os.RemoveAll(root) }
+}
+
 func assertContainerStatus(
 	t *testing.T,
 	sut cri.RuntimeService,
@@ -114,7 +152,7 @@ func assertContainerStatus(
 	expected container.Status,
 	exitCode ...int32,
 ) {
-	cont, err := sut.GetContainer(id)
+	cont, err := sut.GetContainer(context.Background(), id)
 	if err != nil {
 		t.Fatalf("cri.ContainerStatus() failed.\nerr=%v\n", err)
 	}
@@ -129,3 +167,456 @@ func assertContainerStatus(
 		}
 	}
 }
+
+// ctxRuntime behaves like the OCI runtime aborted on the
+// context cancellation and keeps track of the containers.
+// The container processes start and terminate (on any signal)
+// after the delay, like slow ones do.
+type ctxRuntime struct {
+	mu          sync.Mutex
+	states      map[container.ID]string
+	terminating map[container.ID]bool
+
+	exitDir string
+	delay   time.Duration
+}
+
+func newCtxRuntime(exitDir string, delay time.Duration) *ctxRuntime {
+	return &ctxRuntime{
+		states:      make(map[container.ID]string),
+		terminating: make(map[container.ID]bool),
+		exitDir:     exitDir,
+		delay:       delay,
+	}
+}
+
+func (r *ctxRuntime) CreateContainer(
+	ctx context.Context,
+	id container.ID,
+	_, _, _, _ string,
+	_, _ bool,
+	_ time.Duration,
+) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.setState(id, "created")
+	return os.Getpid(), nil
+}
+
+func (r *ctxRuntime) StartContainer(ctx context.Context, id container.ID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := timeutil.Sleep(ctx, r.delay); err != nil {
+		return err
+	}
+	r.setState(id, "running")
+	return nil
+}
+
+func (r *ctxRuntime) KillContainer(ctx context.Context, id container.ID, sig os.Signal) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.terminating[id] {
+		return nil
+	}
+	r.terminating[id] = true
+
+	time.AfterFunc(r.delay, func() {
+		exit := fmt.Sprintf(
+			`{"at":%q,"reason":"signaled","signal":%d}`,
+			time.Now().Format(time.RFC3339Nano), sig.(syscall.Signal),
+		)
+		if err := ioutil.WriteFile(path.Join(r.exitDir, string(id)), []byte(exit), 0644); err != nil {
+			panic(err)
+		}
+		r.setState(id, "stopped")
+	})
+	return nil
+}
+
+func (r *ctxRuntime) DeleteContainer(_ context.Context, id container.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.states, id)
+	return nil
+}
+
+func (r *ctxRuntime) ContainerState(ctx context.Context, id container.ID) (oci.StateResp, error) {
+	if err := ctx.Err(); err != nil {
+		return oci.StateResp{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status, ok := r.states[id]
+	if !ok {
+		return oci.StateResp{}, errors.New("container does not exist")
+	}
+	return oci.StateResp{Id: string(id), Status: status}, nil
+}
+
+func (r *ctxRuntime) ContainerPids(ctx context.Context, _ container.ID) ([]int, error) {
+	return nil, ctx.Err()
+}
+
+func (r *ctxRuntime) setState(id container.ID, status string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[id] = status
+}
+
+func (r *ctxRuntime) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.states)
+}
+
+func Test_ContextCancellation_RollsBack(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rt := newCtxRuntime(dir, 0)
+
+	cstore, teardown1 := newContainerStore(t)
+	defer teardown1()
+
+	istore, teardown2 := newImageStore(t)
+	defer teardown2()
+
+	vstore, teardown3 := newVolumeStore(t)
+	defer teardown3()
+
+	sut, err := cri.NewRuntimeService(rt, cstore, istore, vstore, dir, dir, dir, cri.RuntimeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootfs := path.Join(dir, "rootfs")
+	if err := os.MkdirAll(path.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := cri.ContainerOptions{
+		Name:       "cont1",
+		Command:    "/bin/sleep",
+		Args:       []string{"999"},
+		RootfsPath: rootfs,
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// (1) Creation is rolled back.
+	if _, err := sut.CreateContainer(cancelled, opts); err != context.Canceled {
+		t.Fatalf("CreateContainer() is expected to fail with %v, got %v", context.Canceled, err)
+	}
+	conts, err := sut.ListContainers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conts) != 0 {
+		t.Fatalf("no containers are expected to be left, got %d", len(conts))
+	}
+	handles, err := cstore.FindContainers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handles) != 0 {
+		t.Fatalf("no container dirs are expected to be left, got %d", len(handles))
+	}
+
+	// The name isn't taken by the rolled back container.
+	cont, err := sut.CreateContainer(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (2) Start is rolled back.
+	if err := sut.StartContainer(cancelled, cont.ID()); err != context.Canceled {
+		t.Fatalf("StartContainer() is expected to fail with %v, got %v", context.Canceled, err)
+	}
+	assertContainerStatus(t, sut, cont.ID(), container.Created)
+
+	// The runtime container stays around for another attempt.
+	if rt.count() != 1 {
+		t.Fatalf("runtime container is expected to be kept, got %d", rt.count())
+	}
+	if err := sut.StartContainer(context.Background(), cont.ID()); err != nil {
+		t.Fatal(err)
+	}
+	assertContainerStatus(t, sut, cont.ID(), container.Running)
+}
+
+func Test_WaitContainer_ConcurrentLifecycle(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rt := newCtxRuntime(dir, 600*time.Millisecond)
+
+	cstore, teardown1 := newContainerStore(t)
+	defer teardown1()
+
+	istore, teardown2 := newImageStore(t)
+	defer teardown2()
+
+	vstore, teardown3 := newVolumeStore(t)
+	defer teardown3()
+
+	sut, err := cri.NewRuntimeService(rt, cstore, istore, vstore, dir, dir, dir, cri.RuntimeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootfs := path.Join(dir, "rootfs")
+	if err := os.MkdirAll(path.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cont, err := sut.CreateContainer(ctx, cri.ContainerOptions{
+		Name:       "cont1",
+		Command:    "/bin/sleep",
+		Args:       []string{"999"},
+		RootfsPath: rootfs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// waitDuring runs the wait while the lifecycle operation is in progress.
+	waitDuring := func(op func() error, status container.Status) *container.Container {
+		done := make(chan error, 1)
+		go func() { done <- op() }()
+		time.Sleep(100 * time.Millisecond)
+
+		waited, err := sut.WaitContainer(ctx, cont.ID(), status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state, err := rt.ContainerState(ctx, cont.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := status.String(); state.Status != expected {
+			t.Fatalf("wait returned while the runtime state is %s, expected %s", state.Status, expected)
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		return waited
+	}
+
+	// (1) Wait for running while starting.
+	waited := waitDuring(func() error { return sut.StartContainer(ctx, cont.ID()) }, container.Running)
+	if waited.Status() != container.Running {
+		t.Fatalf("status is %v, expected %v", waited.Status(), container.Running)
+	}
+
+	// (2) Wait for exit while stopping.
+	waited = waitDuring(func() error { return sut.StopContainer(ctx, cont.ID(), time.Second) }, container.Stopped)
+	if waited.Status() != container.Stopped {
+		t.Fatalf("status is %v, expected %v", waited.Status(), container.Stopped)
+	}
+	if waited.Signal() != int32(syscall.SIGTERM) || waited.ExitCode() != 127+int32(syscall.SIGTERM) {
+		t.Fatalf("unexpected signal %d and exit code %d", waited.Signal(), waited.ExitCode())
+	}
+	if waited.FinishedAtNano() == 0 {
+		t.Fatal("finished at is expected to be set")
+	}
+}
+
+func Test_StopContainer_ExitOnTerm(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	// The container process exits right on SIGTERM,
+	// i.e. before the first state poll.
+	rt := newCtxRuntime(dir, 0)
+
+	cstore, teardown1 := newContainerStore(t)
+	defer teardown1()
+
+	istore, teardown2 := newImageStore(t)
+	defer teardown2()
+
+	vstore, teardown3 := newVolumeStore(t)
+	defer teardown3()
+
+	sut, err := cri.NewRuntimeService(rt, cstore, istore, vstore, dir, dir, dir, cri.RuntimeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootfs := path.Join(dir, "rootfs")
+	if err := os.MkdirAll(path.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cont, err := sut.CreateContainer(ctx, cri.ContainerOptions{
+		Name:       "cont1",
+		Command:    "/bin/sleep",
+		Args:       []string{"999"},
+		RootfsPath: rootfs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sut.StartContainer(ctx, cont.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := sut.StopContainer(ctx, cont.ID(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	assertContainerStatus(t, sut, cont.ID(), container.Stopped, 127+int32(syscall.SIGTERM))
+	if cont.Reason() != container.ReasonSignaled {
+		t.Fatalf("reason is %q, expected %q", cont.Reason(), container.ReasonSignaled)
+	}
+}
+
+func Test_RunContainer_AutoRemove(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rt := newCtxRuntime(dir, 0)
+
+	cstore, teardown1 := newContainerStore(t)
+	defer teardown1()
+
+	istore, teardown2 := newImageStore(t)
+	defer teardown2()
+
+	vstore, teardown3 := newVolumeStore(t)
+	defer teardown3()
+
+	sut, err := cri.NewRuntimeService(rt, cstore, istore, vstore, dir, dir, dir, cri.RuntimeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootfs := path.Join(dir, "rootfs")
+	if err := os.MkdirAll(path.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cont, err := sut.RunContainer(ctx, cri.ContainerOptions{
+		Name:       "cont1",
+		Command:    "/bin/sleep",
+		Args:       []string{"999"},
+		RootfsPath: rootfs,
+		AutoRemove: true,
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContainerStatus(t, sut, cont.ID(), container.Running)
+
+	if err := sut.StopContainer(ctx, cont.ID(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 50; i++ {
+		if _, err = sut.GetContainer(ctx, cont.ID()); err == container.ErrNotFound {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != container.ErrNotFound {
+		t.Fatalf("container is expected to be removed, got %v", err)
+	}
+	if rt.count() != 0 {
+		t.Fatal("runtime container is expected to be deleted")
+	}
+
+	// The waiters still get the final state.
+	id, err := sut.ResolveContainerID(string(cont.ID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped, err := sut.WaitContainer(ctx, id, container.Stopped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stopped.Status() != container.Stopped || stopped.ExitCode() != 127+int32(syscall.SIGTERM) {
+		t.Fatalf("unexpected final state %v (exit code %d)", stopped.Status(), stopped.ExitCode())
+	}
+}
+
+func Test_ContainerChanges_SourceRootfsChanged(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rt := newCtxRuntime(dir, 0)
+
+	cstore, teardown1 := newContainerStore(t)
+	defer teardown1()
+
+	istore, teardown2 := newImageStore(t)
+	defer teardown2()
+
+	vstore, teardown3 := newVolumeStore(t)
+	defer teardown3()
+
+	sut, err := cri.NewRuntimeService(rt, cstore, istore, vstore, dir, dir, dir, cri.RuntimeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootfs := path.Join(dir, "rootfs")
+	if err := os.MkdirAll(path.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(rootfs, "etc", "hosts"), []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	cont, err := sut.CreateContainer(ctx, cri.ContainerOptions{
+		Name:       "cont1",
+		Command:    "/bin/sleep",
+		Args:       []string{"999"},
+		RootfsPath: rootfs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The source rootfs changes don't affect the container.
+	if err := ioutil.WriteFile(path.Join(rootfs, "etc", "hosts"), []byte("bar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(rootfs, "etc", "passwd"), []byte("baz"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := sut.ContainerChanges(ctx, cont.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+
+	h, err := cstore.GetContainer(cont.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path.Join(h.RootfsDir(), "etc", "hosts")); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = sut.ContainerChanges(ctx, cont.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].String() != "D /etc/hosts" {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+}
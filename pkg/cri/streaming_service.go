@@ -2,6 +2,7 @@ package cri
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net"
 
@@ -29,7 +30,8 @@ func (rs *runtimeService) Attach(
 		return errors.New("at least one of the std streams must be open")
 	}
 
-	cont, err := rs.GetContainer(container.ID(containerID))
+	// streaming.Runtime interface doesn't provide a request context.
+	cont, err := rs.GetContainer(context.Background(), container.ID(containerID))
 	if err != nil {
 		return err
 	}
@@ -47,6 +49,15 @@ func (rs *runtimeService) Attach(
 	}
 	defer conn.Close()
 
+	// The attach socket is connected, so the container
+	// output can't be missed anymore.
+	if rs.takePendingStart(cont.ID()) {
+		if err := rs.StartContainer(context.Background(), cont.ID()); err != nil {
+			rs.discardContainer(cont.ID())
+			return err
+		}
+	}
+
 	doneOut := make(chan error)
 	if stdout != nil || stderr != nil {
 		go func() {
@@ -75,7 +86,6 @@ func (rs *runtimeService) Attach(
 	case err := <-doneOut:
 		return err
 	}
-	return nil
 }
 
 func (rs *runtimeService) Exec(
@@ -128,7 +138,4 @@ func forwardOutStreams(conn io.Reader, stdout, stderr io.Writer) error {
 			return err
 		}
 	}
-
-	// Unreachable!
-	return nil
 }
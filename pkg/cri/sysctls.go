@@ -0,0 +1,62 @@
+package cri
+
+import (
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/sysctl"
+)
+
+const (
+	// The daemon masked and read-only paths apply.
+	ProcMountDefault = ""
+	// Nothing is masked or read-only in /proc and /sys.
+	ProcMountUnmasked = "unmasked"
+)
+
+// resolveMaskedPaths falls back to the daemon defaults unless
+// the container overrides them. The privileged containers and
+// the unmasked /proc ones get nothing masked.
+func (rs *runtimeService) resolveMaskedPaths(opts ContainerOptions) (masked, readonly []string, err error) {
+	switch opts.ProcMount {
+	case ProcMountDefault:
+	case ProcMountUnmasked:
+		return nil, nil, nil
+	default:
+		return nil, nil, errors.Errorf("unknown proc mount type %q", opts.ProcMount)
+	}
+	if opts.Privileged {
+		return nil, nil, nil
+	}
+
+	masked, readonly = opts.MaskedPaths, opts.ReadonlyPaths
+	if masked == nil {
+		masked = rs.config.MaskedPaths
+	}
+	if readonly == nil {
+		readonly = rs.config.ReadonlyPaths
+	}
+	return masked, readonly, nil
+}
+
+// validateSysctls checks that the sysctls are allowed and belong to
+// the namespaces of the container. A shared namespace can't be tuned
+// by the container.
+func (rs *runtimeService) validateSysctls(sysctls map[string]string, namespaces container.NamespaceOptions) error {
+	for name := range sysctls {
+		ns, err := sysctl.Validate(name, rs.config.AllowedUnsafeSysctls)
+		if err != nil {
+			return err
+		}
+
+		mode := namespaces.Network
+		if ns == rspec.IPCNamespace {
+			mode = namespaces.IPC
+		}
+		if mode != container.NamespacePrivate {
+			return errors.Errorf("sysctl %s can't be set, the container %s namespace is shared", name, ns)
+		}
+	}
+	return nil
+}
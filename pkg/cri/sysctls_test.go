@@ -0,0 +1,87 @@
+package cri
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+)
+
+func TestResolveMaskedPaths(t *testing.T) {
+	rs := &runtimeService{config: RuntimeConfig{
+		MaskedPaths:   []string{"/proc/kcore"},
+		ReadonlyPaths: []string{"/proc/sys"},
+	}}
+
+	cases := []struct {
+		opts     ContainerOptions
+		masked   []string
+		readonly []string
+	}{
+		{
+			masked:   []string{"/proc/kcore"},
+			readonly: []string{"/proc/sys"},
+		},
+		{
+			opts:     ContainerOptions{MaskedPaths: []string{"/proc/keys"}, ReadonlyPaths: []string{}},
+			masked:   []string{"/proc/keys"},
+			readonly: []string{},
+		},
+		{
+			opts: ContainerOptions{ProcMount: ProcMountUnmasked},
+		},
+		{
+			opts: ContainerOptions{Privileged: true, MaskedPaths: []string{"/proc/keys"}},
+		},
+	}
+
+	for i, c := range cases {
+		masked, readonly, err := rs.resolveMaskedPaths(c.opts)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error %v", i, err)
+		}
+		if !reflect.DeepEqual(masked, c.masked) || !reflect.DeepEqual(readonly, c.readonly) {
+			t.Errorf("case %d: unexpected paths %v, %v", i, masked, readonly)
+		}
+	}
+
+	if _, _, err := rs.resolveMaskedPaths(ContainerOptions{ProcMount: "raw"}); err == nil {
+		t.Error("unknown proc mount type is expected to fail")
+	}
+}
+
+func TestValidateSysctls(t *testing.T) {
+	rs := &runtimeService{config: RuntimeConfig{
+		AllowedUnsafeSysctls: []string{"net.core.*", "kernel.msgmax"},
+	}}
+
+	cases := []struct {
+		sysctls    map[string]string
+		namespaces container.NamespaceOptions
+		ok         bool
+	}{
+		{sysctls: map[string]string{"net.core.somaxconn": "1024", "kernel.msgmax": "8192"}, ok: true},
+		{sysctls: map[string]string{"net.ipv4.ip_forward": "1"}},
+		{sysctls: map[string]string{"vm.swappiness": "10"}},
+		{
+			sysctls:    map[string]string{"net.ipv4.tcp_syncookies": "1"},
+			namespaces: container.NamespaceOptions{Network: container.NamespaceHost},
+		},
+		{
+			sysctls:    map[string]string{"net.ipv4.tcp_syncookies": "1"},
+			namespaces: container.NamespaceOptions{IPC: container.NamespaceHost},
+			ok:         true,
+		},
+		{
+			sysctls:    map[string]string{"kernel.shm_rmid_forced": "1"},
+			namespaces: container.NamespaceOptions{IPC: container.NamespaceTarget, TargetID: "abc"},
+		},
+	}
+
+	for i, c := range cases {
+		err := rs.validateSysctls(c.sysctls, c.namespaces)
+		if (err == nil) != c.ok {
+			t.Errorf("case %d: unexpected result %v", i, err)
+		}
+	}
+}
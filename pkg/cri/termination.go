@@ -0,0 +1,196 @@
+package cri
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/shimutil"
+	"github.com/iximiuz/conman/pkg/storage"
+)
+
+// The Kubernetes limits.
+const (
+	maxTerminationMessageSize = 4096
+	maxLogTailSize            = 2048
+	maxLogTailLines           = 80
+)
+
+func terminationLogFile(h *storage.ContainerHandle) string {
+	return path.Join(h.BundleDir(), "termination-log")
+}
+
+// specTerminationLog bind mounts the termination log file
+// (see createTerminationLog()) at the container termination
+// message path.
+func specTerminationLog(
+	cont *container.Container,
+	h *storage.ContainerHandle,
+) rspec.Mount {
+	return rspec.Mount{
+		Type:        "bind",
+		Source:      terminationLogFile(h),
+		Destination: cont.TerminationMessagePath(),
+		Options:     []string{"rbind", "rw", container.PropagationPrivate},
+	}
+}
+
+// createTerminationLog creates the (empty) termination log file in
+// the bundle directory. The file is world-writable since the container
+// process can run as any (possibly unmapped) user.
+func createTerminationLog(h *storage.ContainerHandle) error {
+	file := terminationLogFile(h)
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return errors.Wrap(err, "create termination log")
+	}
+	f.Close()
+	// Bypasses the umask.
+	return errors.Wrap(os.Chmod(file, 0666), "create termination log")
+}
+
+// setTermination tells why the stopped container has terminated
+// and sets its termination message. The OOM kill takes precedence
+// over the signal (SIGKILL) it's carried out with.
+func (rs *runtimeService) setTermination(
+	cont *container.Container,
+	ts *shimutil.TerminationStatus,
+) {
+	reason := container.ReasonError
+	switch {
+	case rs.checkOOMKilled(cont, ts):
+		reason = container.ReasonOOMKilled
+	case ts.IsSignaled():
+		reason = container.ReasonSignaled
+	case ts.ExitCode() == 0:
+		reason = container.ReasonCompleted
+	}
+	cont.SetReason(reason)
+	cont.SetMessage(rs.terminationMessage(cont))
+}
+
+// terminationMessage reads the message left by the container
+// process. If there is none and the container has failed,
+// the tail of the container log is used instead.
+func (rs *runtimeService) terminationMessage(cont *container.Container) string {
+	h, err := rs.cstore.GetContainer(cont.ID())
+	if err != nil || h == nil {
+		return ""
+	}
+
+	msg, err := readHead(terminationLogFile(h), maxTerminationMessageSize)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.WithError(err).Warnf("failed to read container %s termination log", cont.ID())
+	}
+	if len(bytes.TrimSpace(msg)) > 0 || cont.Reason() == container.ReasonCompleted {
+		return string(msg)
+	}
+
+	tail, err := logTail(cont.LogPath(), maxLogTailLines, maxLogTailSize)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.WithError(err).Warnf("failed to read container %s log", cont.ID())
+	}
+	return tail
+}
+
+func readHead(file string, size int64) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(io.LimitReader(f, size))
+}
+
+// The CRI log line prefix: <RFC3339Nano time> <stream> <P|F tag>.
+var criLogPrefix = regexp.MustCompile(`^\S+ (stdout|stderr) ([PF]) `)
+
+// logTail returns up to the last lines (and bytes) of the container
+// log, with the CRI log format prefixes stripped if present.
+func logTail(file string, lines int, size int64) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	// Reads a bit more since the prefixes are going to be stripped.
+	offset := st.Size() - 4*size
+	if offset < 0 {
+		offset = 0
+	}
+	data := make([]byte, st.Size()-offset)
+	if _, err := f.ReadAt(data, offset); err != nil && err != io.EOF {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", nil
+	}
+
+	raw := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if offset > 0 {
+		// The first line is likely incomplete.
+		raw = raw[1:]
+	}
+
+	var b strings.Builder
+	for _, line := range raw {
+		if m := criLogPrefix.FindStringSubmatch(line); m != nil {
+			line = line[len(m[0]):]
+			if m[2] == "P" {
+				b.WriteString(line)
+				continue
+			}
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	out := b.String()
+	for n := strings.Count(out, "\n"); n > lines; n-- {
+		out = out[strings.Index(out, "\n")+1:]
+	}
+	if int64(len(out)) > size {
+		out = out[int64(len(out))-size:]
+	}
+	return out, nil
+}
+
+// markCannotRunNoLock records the OCI runtime start failure if the
+// container process is gone. Otherwise, the container status is
+// going to be rolled back to created.
+func (rs *runtimeService) markCannotRunNoLock(cont *container.Container, startErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+
+	if err := rs.refreshContainerNoLock(ctx, cont); err != nil {
+		return
+	}
+	if cont.Status() != container.Stopped {
+		return
+	}
+
+	msg := startErr.Error()
+	if len(msg) > maxTerminationMessageSize {
+		msg = msg[:maxTerminationMessageSize]
+	}
+	cont.SetReason(container.ReasonContainerCannotRun)
+	cont.SetMessage(msg)
+	if err := rs.optimisticChangeContainerStatus(cont, container.Stopped); err != nil {
+		logrus.WithError(err).Warn("failed to store container termination reason")
+	}
+}
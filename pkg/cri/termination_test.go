@@ -0,0 +1,157 @@
+package cri
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/rollback"
+	"github.com/iximiuz/conman/pkg/shimutil"
+	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestSetTermination(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rs := &runtimeService{
+		cstore:    storage.NewContainerStore(dir),
+		oomEvents: make(map[container.ID]bool),
+	}
+
+	cases := []struct {
+		exitFile string
+		termLog  string
+		log      string
+		oom      bool
+		reason   string
+		message  string
+	}{
+		{
+			exitFile: `{"reason":"exited","exitCode":0}`,
+			log:      "done\n",
+			reason:   container.ReasonCompleted,
+			message:  "",
+		},
+		{
+			exitFile: `{"reason":"exited","exitCode":0}`,
+			termLog:  "all good",
+			reason:   container.ReasonCompleted,
+			message:  "all good",
+		},
+		{
+			exitFile: `{"reason":"exited","exitCode":1}`,
+			log:      "boom\n",
+			reason:   container.ReasonError,
+			message:  "boom\n",
+		},
+		{
+			exitFile: `{"reason":"exited","exitCode":1}`,
+			termLog:  "bad config",
+			log:      "boom\n",
+			reason:   container.ReasonError,
+			message:  "bad config",
+		},
+		{
+			exitFile: `{"reason":"signaled","signal":15}`,
+			reason:   container.ReasonSignaled,
+			message:  "",
+		},
+		{
+			exitFile: `{"reason":"signaled","signal":9}`,
+			oom:      true,
+			reason:   container.ReasonOOMKilled,
+			message:  "",
+		},
+	}
+
+	for _, c := range cases {
+		id := container.RandID()
+		cont, err := container.New(id, "name_"+string(id[:8]), filepath.Join(dir, string(id)+".log"), nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := rs.cstore.CreateContainer(id, rollback.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(h.BundleDir(), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := createTerminationLog(h); err != nil {
+			t.Fatal(err)
+		}
+		if c.termLog != "" {
+			if err := ioutil.WriteFile(terminationLogFile(h), []byte(c.termLog), 0666); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if c.log != "" {
+			if err := ioutil.WriteFile(cont.LogPath(), []byte(c.log), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		rs.oomEvents[id] = c.oom
+
+		ts, err := shimutil.ParseExitFile([]byte(c.exitFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs.setTermination(cont, ts)
+
+		if cont.Reason() != c.reason {
+			t.Errorf("%s: unexpected reason %q, expected %q", c.exitFile, cont.Reason(), c.reason)
+		}
+		if cont.Message() != c.message {
+			t.Errorf("%s: unexpected message %q, expected %q", c.exitFile, cont.Message(), c.message)
+		}
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		log   string
+		lines int
+		size  int64
+		tail  string
+	}{
+		{"", 80, 2048, ""},
+		{"plain\nlines\n", 80, 2048, "plain\nlines\n"},
+		{"no newline", 80, 2048, "no newline\n"},
+		{
+			"2021-01-01T00:00:00.000000000Z stdout F hello\n" +
+				"2021-01-01T00:00:00.000000000Z stderr P split \n" +
+				"2021-01-01T00:00:00.000000000Z stderr F line\n",
+			80, 2048,
+			"hello\nsplit line\n",
+		},
+		{"1\n2\n3\n4\n", 2, 2048, "3\n4\n"},
+		{"0123456789\n", 80, 4, "789\n"},
+		{strings.Repeat("x\n", 100) + "last\n", 1, 16, "last\n"},
+	}
+
+	for _, c := range cases {
+		file := filepath.Join(dir, "container.log")
+		if err := ioutil.WriteFile(file, []byte(c.log), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tail, err := logTail(file, c.lines, c.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tail != c.tail {
+			t.Errorf("%q: unexpected tail %q, expected %q", c.log, tail, c.tail)
+		}
+	}
+
+	if _, err := logTail(filepath.Join(dir, "missing.log"), 80, 2048); !os.IsNotExist(err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package cri
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/rollback"
+	"github.com/iximiuz/conman/pkg/storage"
+)
+
+// allocateIDMappings picks the host IDs for the container user
+// namespace. The host IDs of the requested mappings are the
+// offsets in the block allocated from the subordinate IDs.
+func (rs *runtimeService) allocateIDMappings(
+	opts ContainerOptions,
+	rb *rollback.Rollback,
+) (uidMap, gidMap []idmap.Mapping, err error) {
+	if rs.config.Rootless {
+		return rs.allocateRootlessIDMappings(opts)
+	}
+	if !opts.UserNamespace {
+		if len(opts.UIDMappings) > 0 || len(opts.GIDMappings) > 0 {
+			return nil, nil, errors.New("ID mappings require a user namespace")
+		}
+		return nil, nil, nil
+	}
+
+	uidReq, gidReq := opts.UIDMappings, opts.GIDMappings
+	if len(uidReq) == 0 {
+		uidReq = idmap.DefaultMappings
+	}
+	if len(gidReq) == 0 {
+		gidReq = uidReq
+	}
+
+	uidMap, err = rs.uidAlloc.Allocate(uidReq)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "allocate UIDs")
+	}
+	rb.Add(func() { rs.uidAlloc.Release(uidMap) })
+
+	gidMap, err = rs.gidAlloc.Allocate(gidReq)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "allocate GIDs")
+	}
+	rb.Add(func() { rs.gidAlloc.Release(gidMap) })
+
+	return uidMap, gidMap, nil
+}
+
+func (rs *runtimeService) releaseIDMappings(cont *container.Container) {
+	if rs.config.Rootless {
+		return
+	}
+	rs.uidAlloc.Release(cont.UIDMappings())
+	rs.gidAlloc.Release(cont.GIDMappings())
+}
+
+func (rs *runtimeService) restoreIDMappings(cont *container.Container) {
+	if rs.config.Rootless {
+		return
+	}
+	if err := rs.uidAlloc.Reserve(cont.UIDMappings()); err != nil {
+		logrus.WithError(err).Warnf("container %s UIDs overlap", cont.ID())
+	}
+	if err := rs.gidAlloc.Reserve(cont.GIDMappings()); err != nil {
+		logrus.WithError(err).Warnf("container %s GIDs overlap", cont.ID())
+	}
+}
+
+// idmappedMounts tells if the container rootfs can be idmapped
+// (checked once, on the first user namespace container).
+func (rs *runtimeService) idmappedMounts() bool {
+	rs.idmappedOnce.Do(func() {
+		rs.idmappedOK = idmap.Supported(rs.cstore.RootDir())
+		logrus.Infof("idmapped container rootfs supported: %v", rs.idmappedOK)
+	})
+	return rs.idmappedOK
+}
+
+// The idmapped mount of the bundle rootfs. The bundle rootfs itself
+// keeps the image ownership then. Otherwise, the bundle rootfs
+// ownership is shifted to the host IDs, which diff, commit, export
+// and copying map back (see rootfsIDMappings()).
+func mappedRootfsDir(h *storage.ContainerHandle) string {
+	return path.Join(h.ContainerDir(), "rootfs-idmapped")
+}
+
+// prepareMappedRootfs makes the rootfs owned by the container root,
+// either via an idmapped mount (if rootPath is the mapped rootfs)
+// or by shifting the rootfs ownership.
+func (rs *runtimeService) prepareMappedRootfs(
+	cont *container.Container,
+	h *storage.ContainerHandle,
+	rootPath string,
+) error {
+	// The OCI runtime sets up the rootfs and the mounts already
+	// in the user namespace, as the container root.
+	dirs := []string{filepath.Dir(h.ContainerDir()), h.ContainerDir(), h.BundleDir()}
+	for _, m := range cont.Mounts() {
+		if m.Type == container.MountVolume {
+			dir := filepath.Dir(rs.vstore.VolumeDataDir(&storage.Volume{Name: m.Source}))
+			dirs = append(dirs, filepath.Dir(dir), dir)
+		}
+	}
+	if err := allowTraversal(dirs...); err != nil {
+		return err
+	}
+
+	if rootPath == h.RootfsDir() {
+		return errors.Wrap(
+			idmap.Shift(h.RootfsDir(), cont.UIDMappings(), cont.GIDMappings()),
+			"shift rootfs ownership",
+		)
+	}
+
+	if err := os.Mkdir(rootPath, 0755); err != nil {
+		return err
+	}
+	return errors.Wrap(
+		idmap.Mount(h.RootfsDir(), rootPath, cont.UIDMappings(), cont.GIDMappings()),
+		"idmap rootfs",
+	)
+}
+
+// rootfsIDMappings returns the ID mappings of the container rootfs
+// if its ownership has been shifted to the host IDs (i.e. if there
+// is no idmapped mount), nil otherwise.
+func (rs *runtimeService) rootfsIDMappings(
+	cont *container.Container,
+	h *storage.ContainerHandle,
+) *archive.IDMappings {
+	if len(cont.UIDMappings()) == 0 || rs.config.Rootless {
+		return nil
+	}
+	if _, err := os.Lstat(mappedRootfsDir(h)); err == nil {
+		return nil
+	}
+	return &archive.IDMappings{UIDs: cont.UIDMappings(), GIDs: cont.GIDMappings()}
+}
+
+// pathIDMappings returns the rootfs ID mappings if root (see
+// containerPathRoot()) is the container rootfs. The volumes
+// and the bind mount sources are never shifted.
+func (rs *runtimeService) pathIDMappings(id container.ID, root string) *archive.IDMappings {
+	cont := rs.cmap.Get(id)
+	if cont == nil {
+		return nil
+	}
+	h, err := rs.cstore.GetContainer(id)
+	if err != nil || h == nil || root != h.RootfsDir() {
+		return nil
+	}
+	return rs.rootfsIDMappings(cont, h)
+}
+
+// unmountMappedRootfs must precede the container directory removal.
+func (rs *runtimeService) unmountMappedRootfs(id container.ID) {
+	h, err := rs.cstore.GetContainer(id)
+	if err != nil {
+		return
+	}
+	if err := idmap.Unmount(mappedRootfsDir(h)); err != nil {
+		logrus.WithError(err).Warnf("failed to unmount container %s rootfs", id)
+	}
+}
+
+// allowTraversal lets everyone search the directories.
+func allowTraversal(dirs ...string) error {
+	for _, dir := range dirs {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if fi.Mode().Perm()&0001 == 0 {
+			if err := os.Chmod(dir, fi.Mode().Perm()|0001); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func specIDMappings(mappings []idmap.Mapping) []rspec.LinuxIDMapping {
+	var rv []rspec.LinuxIDMapping
+	for _, m := range mappings {
+		rv = append(rv, rspec.LinuxIDMapping{
+			ContainerID: m.ContainerID,
+			HostID:      m.HostID,
+			Size:        m.Size,
+		})
+	}
+	return rv
+}
@@ -0,0 +1,182 @@
+package cri
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/iximiuz/conman/pkg/archive"
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/oci"
+	"github.com/iximiuz/conman/pkg/storage"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+// createdRuntime only pretends to create the containers.
+type createdRuntime struct {
+	oci.Runtime
+}
+
+func (createdRuntime) CreateContainer(
+	ctx context.Context,
+	id container.ID,
+	bundleDir, logfile, exitfile, attachfile string,
+	stdin, stdinOnce bool,
+	timeout time.Duration,
+) (int, error) {
+	return 1, nil
+}
+
+func (createdRuntime) ContainerState(ctx context.Context, id container.ID) (oci.StateResp, error) {
+	return oci.StateResp{Id: string(id), Status: "created"}, nil
+}
+
+func (createdRuntime) DeleteContainer(ctx context.Context, id container.ID) error {
+	return nil
+}
+
+// The rootfs ownership is shifted to the host IDs if there are
+// no idmapped mounts, but the clients must see the container IDs.
+func TestShiftedRootfs(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	rootfs := path.Join(dir, "rootfs")
+	must(t, os.MkdirAll(path.Join(rootfs, "etc"), 0755))
+	must(t, ioutil.WriteFile(path.Join(rootfs, "etc", "hosts"), []byte("foo"), 0644))
+	must(t, os.Chown(path.Join(rootfs, "etc", "hosts"), 5, 5))
+
+	var buf bytes.Buffer
+	must(t, archive.Tar(&buf, rootfs, "/", ".", nil))
+	istore := storage.NewImageStore(path.Join(dir, "images"))
+	if _, err := NewImageService(istore).ImportImage(context.Background(), &buf, "base"); err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := NewRuntimeService(
+		createdRuntime{},
+		storage.NewContainerStore(path.Join(dir, "containers")),
+		istore,
+		storage.NewVolumeStore(path.Join(dir, "volumes")),
+		dir, dir, dir,
+		RuntimeConfig{
+			SubUIDs: []idmap.Range{{Start: 100000, Size: 65536}},
+			SubGIDs: []idmap.Range{{Start: 100000, Size: 65536}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := svc.(*runtimeService)
+	// Forces the chown fallback.
+	rs.idmappedOnce.Do(func() {})
+
+	ctx := context.Background()
+	cont, err := rs.CreateContainer(ctx, ContainerOptions{
+		Name:          "cont1",
+		Command:       "/bin/sleep",
+		Image:         "base",
+		UserNamespace: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := rs.cstore.GetContainer(cont.ID())
+	must(t, err)
+	assertOwner(t, path.Join(h.RootfsDir(), "etc", "hosts"), 100005)
+
+	changes, err := rs.ContainerChanges(ctx, cont.ID())
+	must(t, err)
+	if len(changes) != 0 {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+
+	// Copied files get the host IDs.
+	buf.Reset()
+	tw := tar.NewWriter(&buf)
+	must(t, tw.WriteHeader(&tar.Header{Name: "new", Mode: 0644, Uid: 7, Gid: 7, Size: 3}))
+	_, err = tw.Write([]byte("bar"))
+	must(t, err)
+	must(t, tw.Close())
+	must(t, rs.CopyToContainer(ctx, cont.ID(), "/etc/", &buf))
+	assertOwner(t, path.Join(h.RootfsDir(), "etc", "new"), 100007)
+
+	changes, err = rs.ContainerChanges(ctx, cont.ID())
+	must(t, err)
+	if len(changes) != 1 || changes[0].String() != "A /etc/new" {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+
+	img, err := rs.CommitContainer(ctx, cont.ID(), CommitOptions{})
+	must(t, err)
+	manifest, err := istore.ImageManifest(img)
+	must(t, err)
+	blob, err := istore.OpenBlob(manifest.Layers[len(manifest.Layers)-1].Digest)
+	must(t, err)
+	defer blob.Close()
+	assertTarOwners(t, blob, map[string]int{"etc/new": 7})
+
+	imgRootfs := istore.ImageRootfsDir(img)
+	assertOwner(t, path.Join(imgRootfs, "etc", "hosts"), 5)
+	assertOwner(t, path.Join(imgRootfs, "etc", "new"), 7)
+
+	buf.Reset()
+	must(t, rs.CopyFromContainer(ctx, cont.ID(), "/etc", &buf))
+	assertTarOwners(t, &buf, map[string]int{"etc/": 0, "etc/hosts": 5, "etc/new": 7})
+
+	buf.Reset()
+	must(t, rs.ExportContainer(ctx, cont.ID(), &buf))
+	assertTarOwners(t, &buf, map[string]int{"./": 0, "./etc/": 0, "./etc/hosts": 5, "./etc/new": 7})
+}
+
+func assertOwner(t *testing.T, file string, uid uint32) {
+	fi, err := os.Lstat(file)
+	must(t, err)
+	if st := fi.Sys().(*syscall.Stat_t); st.Uid != uid || st.Gid != uid {
+		t.Fatalf("%s is owned by %d:%d, expected %d:%d", file, st.Uid, st.Gid, uid, uid)
+	}
+}
+
+func assertTarOwners(t *testing.T, r io.Reader, expected map[string]int) {
+	actual := make(map[string]int)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		must(t, err)
+		if hdr.Uid != hdr.Gid {
+			t.Fatalf("%s is owned by %d:%d", hdr.Name, hdr.Uid, hdr.Gid)
+		}
+		actual[hdr.Name] = hdr.Uid
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("unexpected entries %v, expected %v", actual, expected)
+	}
+	for name, uid := range expected {
+		if actual[name] != uid {
+			t.Fatalf("unexpected entries %v, expected %v", actual, expected)
+		}
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
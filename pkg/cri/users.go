@@ -0,0 +1,55 @@
+package cri
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+)
+
+const maxPasswdSize = 1 << 20
+
+// passwdUsers reads the UID to user name mapping from the
+// rootfs /etc/passwd. The errors are ignored, since the file
+// is optional and fully controlled by the container.
+func passwdUsers(rootfs string) map[uint32]string {
+	users := make(map[uint32]string)
+
+	file, err := fsutil.SecureJoin(rootfs, "/etc/passwd")
+	if err != nil {
+		return users
+	}
+	// Neither a FIFO nor a huge file can block the daemon.
+	f, err := os.OpenFile(file, os.O_RDONLY|syscall.O_NOFOLLOW|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return users
+	}
+	defer f.Close()
+	if fi, err := f.Stat(); err != nil || !fi.Mode().IsRegular() {
+		return users
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(f, maxPasswdSize))
+	if err != nil {
+		return users
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		// name:password:UID:GID:GECOS:directory:shell
+		fields := strings.Split(line, ":")
+		if len(fields) < 3 {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := users[uint32(uid)]; !ok {
+			users[uint32(uid)] = fields[0]
+		}
+	}
+	return users
+}
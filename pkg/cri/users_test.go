@@ -0,0 +1,48 @@
+package cri
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestPasswdUsers(t *testing.T) {
+	rootfs := testutil.TempDir(t)
+	defer os.RemoveAll(rootfs)
+
+	if err := os.Mkdir(filepath.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	passwd := "root:x:0:0:root:/root:/bin/sh\n" +
+		"broken\n" +
+		"nobody:x:65534:65534:nobody:/:/sbin/nologin\n" +
+		"toor:x:0:0::/:/bin/sh\n"
+	if err := ioutil.WriteFile(filepath.Join(rootfs, "etc", "passwd"), []byte(passwd), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	users := passwdUsers(rootfs)
+	if len(users) != 2 || users[0] != "root" || users[65534] != "nobody" {
+		t.Fatalf("unexpected users %v", users)
+	}
+}
+
+func TestPasswdUsersFifo(t *testing.T) {
+	rootfs := testutil.TempDir(t)
+	defer os.RemoveAll(rootfs)
+
+	if err := os.Mkdir(filepath.Join(rootfs, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Mkfifo(filepath.Join(rootfs, "etc", "passwd"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if users := passwdUsers(rootfs); len(users) != 0 {
+		t.Fatalf("unexpected users %v", users)
+	}
+}
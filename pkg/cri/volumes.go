@@ -0,0 +1,159 @@
+package cri
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/storage"
+)
+
+type Volume struct {
+	storage.Volume
+	// Host path of the volume content.
+	Path string
+	// Containers referencing the volume (in any state).
+	Containers []container.ID
+}
+
+func (rs *runtimeService) CreateVolume(
+	ctx context.Context,
+	name string,
+	labels map[string]string,
+) (*Volume, error) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	vol, err := rs.vstore.CreateVolume(name, labels)
+	if err != nil {
+		return nil, err
+	}
+	return rs.volumeNoLock(vol), nil
+}
+
+func (rs *runtimeService) GetVolume(
+	ctx context.Context,
+	name string,
+) (*Volume, error) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	vol, err := rs.vstore.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	return rs.volumeNoLock(vol), nil
+}
+
+func (rs *runtimeService) ListVolumes(ctx context.Context) ([]*Volume, error) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	vols, err := rs.vstore.ListVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*Volume
+	for _, v := range vols {
+		res = append(res, rs.volumeNoLock(v))
+	}
+	return res, nil
+}
+
+func (rs *runtimeService) RemoveVolume(ctx context.Context, name string) error {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	if refs := rs.volumeRefs[name]; len(refs) > 0 {
+		return errors.Errorf(
+			"volume %s is in use by %d container(s)", name, len(refs))
+	}
+	return rs.vstore.RemoveVolume(name)
+}
+
+// acquireVolume makes the volume referenced by the container. A missing
+// volume is created on the fly. The volume content is seeded from the
+// source rootfs at the mount destination if it's the first use of it.
+func (rs *runtimeService) acquireVolume(
+	id container.ID,
+	name string,
+	rootfs string,
+	dest string,
+) (*storage.Volume, error) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	vol, err := rs.vstore.GetVolume(name)
+	if err == storage.ErrVolumeNotFound {
+		vol, err = rs.vstore.CreateVolume(name, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !vol.Seeded {
+		src, err := fsutil.SecureJoin(rootfs, dest)
+		if err != nil {
+			return nil, err
+		}
+		if err := rs.vstore.SeedVolume(vol, src); err != nil {
+			return nil, err
+		}
+	}
+
+	rs.addVolumeRefNoLock(name, id)
+	return vol, nil
+}
+
+// releaseVolumes drops the container references to its volumes.
+func (rs *runtimeService) releaseVolumes(id container.ID, mounts []container.Mount) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	for _, m := range mounts {
+		if m.Type != container.MountVolume {
+			continue
+		}
+		delete(rs.volumeRefs[m.Source], id)
+		if len(rs.volumeRefs[m.Source]) == 0 {
+			delete(rs.volumeRefs, m.Source)
+		}
+	}
+}
+
+// restoreVolumeRefs makes the volumes referenced by a restored container.
+func (rs *runtimeService) restoreVolumeRefs(cont *container.Container) {
+	rs.volumesMu.Lock()
+	defer rs.volumesMu.Unlock()
+
+	for _, m := range cont.Mounts() {
+		if m.Type == container.MountVolume {
+			rs.addVolumeRefNoLock(m.Source, cont.ID())
+		}
+	}
+}
+
+func (rs *runtimeService) addVolumeRefNoLock(name string, id container.ID) {
+	if rs.volumeRefs[name] == nil {
+		rs.volumeRefs[name] = make(map[container.ID]bool)
+	}
+	rs.volumeRefs[name][id] = true
+}
+
+func (rs *runtimeService) volumeNoLock(vol *storage.Volume) *Volume {
+	res := &Volume{
+		Volume: *vol,
+		Path:   rs.vstore.VolumeDataDir(vol),
+	}
+	for id := range rs.volumeRefs[vol.Name] {
+		res.Containers = append(res.Containers, id)
+	}
+	sort.Slice(res.Containers, func(i, j int) bool {
+		return res.Containers[i] < res.Containers[j]
+	})
+	return res
+}
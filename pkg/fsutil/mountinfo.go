@@ -0,0 +1,123 @@
+package fsutil
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// MountInfo is a /proc/<pid>/mountinfo entry (see proc(5)).
+type MountInfo struct {
+	MountPoint string
+	// Optional fields, e.g. shared:1 or master:2.
+	Optional []string
+}
+
+// Shared tells if the mount is a member of a peer group.
+func (m *MountInfo) Shared() bool {
+	return m.hasOptional("shared:")
+}
+
+// Slave tells if the mount receives propagation events from a peer group.
+func (m *MountInfo) Slave() bool {
+	return m.hasOptional("master:")
+}
+
+func (m *MountInfo) hasOptional(prefix string) bool {
+	for _, o := range m.Optional {
+		if strings.HasPrefix(o, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseMountInfo(r io.Reader) ([]*MountInfo, error) {
+	var mounts []*MountInfo
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			return nil, errors.Errorf("bad mountinfo line %q", scanner.Text())
+		}
+
+		m := &MountInfo{MountPoint: unescapeMountPath(fields[4])}
+		for _, f := range fields[6:] {
+			if f == "-" {
+				break
+			}
+			m.Optional = append(m.Optional, f)
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, scanner.Err()
+}
+
+// FindMount returns the mount the (symlink-free) path belongs to.
+// If a mount point is shadowed by a later mount, the latter wins.
+func FindMount(path string) (*MountInfo, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mounts, err := ParseMountInfo(f)
+	if err != nil {
+		return nil, err
+	}
+	return findMount(mounts, path)
+}
+
+func findMount(mounts []*MountInfo, path string) (*MountInfo, error) {
+	path = filepath.Clean(path)
+
+	var found *MountInfo
+	for _, m := range mounts {
+		if m.MountPoint != "/" && path != m.MountPoint &&
+			!strings.HasPrefix(path, m.MountPoint+"/") {
+			continue
+		}
+		if found == nil || len(m.MountPoint) >= len(found.MountPoint) {
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, errors.Errorf("can't find mount point of %s", path)
+	}
+	return found, nil
+}
+
+// MakeShared turns the mount into a shared one (mount --make-shared).
+func MakeShared(mountPoint string) error {
+	return syscall.Mount("", mountPoint, "", syscall.MS_SHARED, "")
+}
+
+// unescapeMountPath decodes the octal escapes (\040 etc.) the
+// kernel uses for spaces, tabs, newlines, and backslashes.
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
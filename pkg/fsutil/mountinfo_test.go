@@ -0,0 +1,46 @@
+package fsutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+36 22 98:0 /mnt1 /mnt\0402 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+37 22 0:5 / /proc rw,nosuid,nodev,noexec - proc proc rw
+`
+	mounts, err := fsutil.ParseMountInfo(strings.NewReader(mountinfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mounts) != 3 {
+		t.Fatalf("unexpected mounts %v", mounts)
+	}
+
+	if mounts[0].MountPoint != "/" || !mounts[0].Shared() || mounts[0].Slave() {
+		t.Errorf("unexpected mount %+v", mounts[0])
+	}
+	if mounts[1].MountPoint != "/mnt 2" || mounts[1].Shared() || !mounts[1].Slave() {
+		t.Errorf("unexpected mount %+v", mounts[1])
+	}
+	if mounts[2].MountPoint != "/proc" || mounts[2].Shared() || mounts[2].Slave() {
+		t.Errorf("unexpected mount %+v", mounts[2])
+	}
+
+	if _, err := fsutil.ParseMountInfo(strings.NewReader("bogus\n")); err == nil {
+		t.Error("bad mountinfo accepted")
+	}
+}
+
+func TestFindMount(t *testing.T) {
+	m, err := fsutil.FindMount("/proc/self/mountinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MountPoint != "/proc" {
+		t.Fatalf("unexpected mount point %s", m.MountPoint)
+	}
+}
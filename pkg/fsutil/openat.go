@@ -0,0 +1,296 @@
+package fsutil
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// OpenDirInRoot opens the directory at unsafePath resolving symlinks
+// as if root was the filesystem root (see SecureJoin()). Unlike
+// SecureJoin(), it's race-free: every component is opened relative to
+// its parent with O_NOFOLLOW and the symlinks are resolved by hand, so
+// a concurrent rename or symlink swap (e.g. by a running container)
+// can't make it escape root. If create is set, the missing components
+// are created (like with os.MkdirAll()).
+func OpenDirInRoot(root *os.File, unsafePath string, create bool) (*os.File, error) {
+	// The opened components of the resolved path,
+	// the first one is the root itself.
+	stack := []*os.File{root}
+	defer func() {
+		for _, d := range stack[1:] {
+			d.Close()
+		}
+	}()
+
+	links := 0
+	for unsafePath != "" {
+		var part string
+		if i := strings.IndexByte(unsafePath, '/'); i == -1 {
+			part, unsafePath = unsafePath, ""
+		} else {
+			part, unsafePath = unsafePath[:i], unsafePath[i+1:]
+		}
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(stack) > 1 {
+				stack[len(stack)-1].Close()
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+
+		cur := stack[len(stack)-1]
+		st, err := Lstatat(cur, part)
+		if os.IsNotExist(err) && create {
+			if err := Mkdirat(cur, part, 0755); err != nil && !os.IsExist(err) {
+				return nil, err
+			}
+			st, err = Lstatat(cur, part)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if st.Mode&syscall.S_IFMT == syscall.S_IFLNK {
+			links++
+			if links > maxSymlinks {
+				return nil, errors.Errorf("%s: too many levels of symbolic links", part)
+			}
+
+			dest, err := Readlinkat(cur, part)
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(dest, "/") {
+				for _, d := range stack[1:] {
+					d.Close()
+				}
+				stack = stack[:1]
+			}
+			unsafePath = dest + "/" + unsafePath
+			continue
+		}
+
+		// Fails if the component has been swapped meanwhile.
+		next, err := OpenAt(cur, part, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_NOFOLLOW, 0)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, next)
+	}
+
+	if len(stack) == 1 {
+		return OpenAt(root, ".", syscall.O_RDONLY|syscall.O_DIRECTORY, 0)
+	}
+	last := stack[len(stack)-1]
+	stack = stack[:len(stack)-1]
+	return last, nil
+}
+
+// OpenAt opens the file relative to the directory. Unlike with
+// openat(2), the file descriptor is close-on-exec.
+func OpenAt(dir *os.File, name string, flags int, perm uint32) (*os.File, error) {
+	fd, err := syscall.Openat(int(dir.Fd()), name, flags|syscall.O_CLOEXEC, perm)
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: name, Err: err}
+	}
+	return os.NewFile(uintptr(fd), name), nil
+}
+
+// Lstatat stats the file relative to the directory without
+// following the symlink if the file is one.
+func Lstatat(dir *os.File, name string) (*syscall.Stat_t, error) {
+	f, err := OpenPathAt(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &st); err != nil {
+		return nil, &os.PathError{Op: "fstat", Path: name, Err: err}
+	}
+	return &st, nil
+}
+
+// LstatatInfo is Lstatat() returning the os.FileInfo.
+func LstatatInfo(dir *os.File, name string) (os.FileInfo, error) {
+	f, err := OpenPathAt(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// OpenPathAt opens the file (or the symlink itself) relative to the
+// directory with O_PATH, i.e. only to refer to it. The file can be
+// operated on via /proc/self/fd/<fd> then.
+func OpenPathAt(dir *os.File, name string) (*os.File, error) {
+	return OpenAt(dir, name, oPath|syscall.O_NOFOLLOW, 0)
+}
+
+func Mkdirat(dir *os.File, name string, perm uint32) error {
+	if err := syscall.Mkdirat(int(dir.Fd()), name, perm); err != nil {
+		return &os.PathError{Op: "mkdirat", Path: name, Err: err}
+	}
+	return nil
+}
+
+func Mknodat(dir *os.File, name string, mode uint32, dev int) error {
+	if err := syscall.Mknodat(int(dir.Fd()), name, mode, dev); err != nil {
+		return &os.PathError{Op: "mknodat", Path: name, Err: err}
+	}
+	return nil
+}
+
+// Lchownat changes the ownership of the file (or the symlink itself).
+func Lchownat(dir *os.File, name string, uid, gid int) error {
+	err := syscall.Fchownat(int(dir.Fd()), name, uid, gid, atSymlinkNofollow)
+	if err != nil {
+		return &os.PathError{Op: "fchownat", Path: name, Err: err}
+	}
+	return nil
+}
+
+// RemoveAllAt removes the file or the directory tree relative to
+// the directory. The subdirectories are never followed if swapped
+// for symlinks, the symlinks themselves are removed instead.
+func RemoveAllAt(dir *os.File, name string) error {
+	st, err := Lstatat(dir, name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if st.Mode&syscall.S_IFMT != syscall.S_IFDIR {
+		return Unlinkat(dir, name, false)
+	}
+
+	sub, err := OpenAt(dir, name, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	names, err := sub.Readdirnames(-1)
+	if err == nil {
+		for _, n := range names {
+			if err = RemoveAllAt(sub, n); err != nil {
+				break
+			}
+		}
+	}
+	sub.Close()
+	if err != nil {
+		return err
+	}
+	return Unlinkat(dir, name, true)
+}
+
+// The syscall package has neither O_PATH nor the *at versions of these.
+
+const (
+	oPath             = 0x200000
+	atSymlinkNofollow = 0x100
+	atRemovedir       = 0x200
+)
+
+// Unlinkat removes the file or the (empty) directory.
+func Unlinkat(dir *os.File, name string, isDir bool) error {
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return err
+	}
+	flags := 0
+	if isDir {
+		flags = atRemovedir
+	}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_UNLINKAT,
+		dir.Fd(),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(flags),
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "unlinkat", Path: name, Err: errno}
+	}
+	return nil
+}
+
+func Readlinkat(dir *os.File, name string) (string, error) {
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return "", err
+	}
+	for size := 256; ; size *= 2 {
+		buf := make([]byte, size)
+		n, _, errno := syscall.Syscall6(
+			syscall.SYS_READLINKAT,
+			dir.Fd(),
+			uintptr(unsafe.Pointer(p)),
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(size),
+			0, 0,
+		)
+		if errno != 0 {
+			return "", &os.PathError{Op: "readlinkat", Path: name, Err: errno}
+		}
+		if int(n) < size {
+			return string(buf[:n]), nil
+		}
+	}
+}
+
+// Symlinkat creates the symlink name (relative to dir) pointing to target.
+func Symlinkat(target string, dir *os.File, name string) error {
+	t, err := syscall.BytePtrFromString(target)
+	if err != nil {
+		return err
+	}
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_SYMLINKAT,
+		uintptr(unsafe.Pointer(t)),
+		dir.Fd(),
+		uintptr(unsafe.Pointer(p)),
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "symlinkat", Path: name, Err: errno}
+	}
+	return nil
+}
+
+// Linkat creates the hard link, the old file is never followed
+// if it's a symlink.
+func Linkat(olddir *os.File, oldname string, newdir *os.File, newname string) error {
+	o, err := syscall.BytePtrFromString(oldname)
+	if err != nil {
+		return err
+	}
+	n, err := syscall.BytePtrFromString(newname)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_LINKAT,
+		olddir.Fd(),
+		uintptr(unsafe.Pointer(o)),
+		newdir.Fd(),
+		uintptr(unsafe.Pointer(n)),
+		0, 0,
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "linkat", Path: newname, Err: errno}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package fsutil_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestOpenDirInRoot(t *testing.T) {
+	root := testutil.TempDir(t)
+	defer os.RemoveAll(root)
+
+	must(t, os.MkdirAll(filepath.Join(root, "etc", "conf.d"), 0755))
+	must(t, os.Symlink("/etc", filepath.Join(root, "abs")))
+	must(t, os.Symlink("../../..", filepath.Join(root, "etc", "conf.d", "up")))
+	must(t, os.Symlink("conf.d", filepath.Join(root, "etc", "rel")))
+	must(t, os.Symlink("loop", filepath.Join(root, "loop")))
+
+	rootf, err := os.Open(root)
+	must(t, err)
+	defer rootf.Close()
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"etc/conf.d", "/etc/conf.d"},
+		{"../../etc", "/etc"},
+		{"abs", "/etc"},
+		{"etc/conf.d/up/etc", "/etc"},
+		{"etc/rel/up", "/"},
+		{"etc/rel/../rel", "/etc/conf.d"},
+	}
+	for _, c := range cases {
+		f, err := fsutil.OpenDirInRoot(rootf, c.path, false)
+		if err != nil {
+			t.Fatalf("OpenDirInRoot(%q) failed: %v", c.path, err)
+		}
+		actual, err := os.Readlink(fmt.Sprintf("/proc/self/fd/%d", f.Fd()))
+		f.Close()
+		must(t, err)
+		if expected := filepath.Join(root, c.expected); actual != expected {
+			t.Errorf("OpenDirInRoot(%q) = %q, expected %q", c.path, actual, expected)
+		}
+	}
+
+	if _, err := fsutil.OpenDirInRoot(rootf, "loop", false); err == nil {
+		t.Error("OpenDirInRoot() must fail on symlink loops")
+	}
+	if _, err := fsutil.OpenDirInRoot(rootf, "missing", false); !os.IsNotExist(err) {
+		t.Errorf("OpenDirInRoot() must fail on missing directories, got %v", err)
+	}
+
+	f, err := fsutil.OpenDirInRoot(rootf, "abs/new/sub", true)
+	must(t, err)
+	f.Close()
+	if fi, err := os.Lstat(filepath.Join(root, "etc", "new", "sub")); err != nil || !fi.IsDir() {
+		t.Fatalf("OpenDirInRoot() must have created the directories within root: %v", err)
+	}
+}
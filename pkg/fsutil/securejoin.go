@@ -0,0 +1,71 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const maxSymlinks = 255
+
+// SecureJoin joins unsafePath to root resolving symlinks as if root
+// was the filesystem root, i.e. neither .. components nor symlinks
+// (absolute or relative) can lead outside of root. Missing components
+// are joined lexically. The result is always within root.
+//
+// Note that the check is inherently racy: if someone can modify the
+// tree under root concurrently, the returned path has to be used with
+// care (e.g. opened with O_NOFOLLOW) or not used at all in favor of
+// OpenDirInRoot().
+func SecureJoin(root, unsafePath string) (string, error) {
+	root = filepath.Clean(root)
+
+	// Resolved part of the path, always absolute and clean
+	// (with the root being "/").
+	resolved := "/"
+	links := 0
+	for unsafePath != "" {
+		var part string
+		if i := strings.IndexByte(unsafePath, '/'); i == -1 {
+			part, unsafePath = unsafePath, ""
+		} else {
+			part, unsafePath = unsafePath[:i], unsafePath[i+1:]
+		}
+
+		next := filepath.Join(resolved, part)
+		if next == resolved {
+			continue
+		}
+
+		fi, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			if os.IsNotExist(err) {
+				resolved = next
+				continue
+			}
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", errors.Errorf("%s: too many levels of symbolic links", next)
+		}
+
+		dest, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(dest) {
+			resolved = "/"
+		}
+		unsafePath = dest + "/" + unsafePath
+	}
+
+	return filepath.Join(root, resolved), nil
+}
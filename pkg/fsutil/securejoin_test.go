@@ -0,0 +1,54 @@
+package fsutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestSecureJoin(t *testing.T) {
+	root := testutil.TempDir(t)
+	defer os.RemoveAll(root)
+
+	must(t, os.MkdirAll(filepath.Join(root, "etc", "conf.d"), 0755))
+	must(t, os.Symlink("/etc", filepath.Join(root, "abs")))
+	must(t, os.Symlink("../../..", filepath.Join(root, "etc", "conf.d", "up")))
+	must(t, os.Symlink("conf.d", filepath.Join(root, "etc", "rel")))
+	must(t, os.Symlink("loop", filepath.Join(root, "loop")))
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"etc/conf.d", "/etc/conf.d"},
+		{"../../etc", "/etc"},
+		{"abs/passwd", "/etc/passwd"},
+		{"etc/conf.d/up/etc", "/etc"},
+		{"etc/rel/up", "/"},
+		{"missing/../etc/rel", "/etc/conf.d"},
+	}
+	for _, c := range cases {
+		actual, err := fsutil.SecureJoin(root, c.path)
+		if err != nil {
+			t.Fatalf("SecureJoin(%q) failed: %v", c.path, err)
+		}
+		if expected := filepath.Join(root, c.expected); actual != expected {
+			t.Errorf("SecureJoin(%q) = %q, expected %q", c.path, actual, expected)
+		}
+	}
+
+	if _, err := fsutil.SecureJoin(root, "loop"); err == nil {
+		t.Error("SecureJoin() must fail on symlink loops")
+	}
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+}
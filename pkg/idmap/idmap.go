@@ -0,0 +1,236 @@
+// Package idmap allocates the user namespace ID mappings out of
+// the subordinate ID ranges (see subuid(5) and subgid(5)) and shifts
+// the container rootfs ownership accordingly.
+package idmap
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Mapping maps Size IDs starting from ContainerID in the user
+// namespace to the ones starting from HostID outside of it.
+type Mapping struct {
+	ContainerID uint32 `json:"containerId"`
+	HostID      uint32 `json:"hostId"`
+	Size        uint32 `json:"size"`
+}
+
+// DefaultMappings maps 65536 IDs starting from the container root.
+var DefaultMappings = []Mapping{{ContainerID: 0, HostID: 0, Size: 65536}}
+
+// Validate checks that the mappings are non-empty and don't
+// overlap on either side.
+func Validate(mappings []Mapping) error {
+	if len(mappings) == 0 {
+		return errors.New("no ID mappings")
+	}
+	for i, m := range mappings {
+		if m.Size == 0 {
+			return errors.Errorf("empty ID mapping %d:%d:%d", m.ContainerID, m.HostID, m.Size)
+		}
+		if uint64(m.ContainerID)+uint64(m.Size) > 1<<32 || uint64(m.HostID)+uint64(m.Size) > 1<<32 {
+			return errors.Errorf("ID mapping %d:%d:%d is out of range", m.ContainerID, m.HostID, m.Size)
+		}
+		for _, o := range mappings[:i] {
+			if overlaps(m.ContainerID, m.Size, o.ContainerID, o.Size) ||
+				overlaps(m.HostID, m.Size, o.HostID, o.Size) {
+				return errors.Errorf(
+					"ID mappings %d:%d:%d and %d:%d:%d overlap",
+					o.ContainerID, o.HostID, o.Size, m.ContainerID, m.HostID, m.Size,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// HostID maps the container ID to the host one.
+func HostID(mappings []Mapping, id uint32) (uint32, bool) {
+	for _, m := range mappings {
+		if id >= m.ContainerID && uint64(id) < uint64(m.ContainerID)+uint64(m.Size) {
+			return m.HostID + (id - m.ContainerID), true
+		}
+	}
+	return 0, false
+}
+
+// ContainerID maps the host ID back to the container one.
+func ContainerID(mappings []Mapping, id uint32) (uint32, bool) {
+	for _, m := range mappings {
+		if id >= m.HostID && uint64(id) < uint64(m.HostID)+uint64(m.Size) {
+			return m.ContainerID + (id - m.HostID), true
+		}
+	}
+	return 0, false
+}
+
+func overlaps(start1, size1, start2, size2 uint32) bool {
+	return uint64(start1) < uint64(start2)+uint64(size2) &&
+		uint64(start2) < uint64(start1)+uint64(size1)
+}
+
+// Range is a subordinate ID range.
+type Range struct {
+	Start uint32
+	Size  uint32
+}
+
+func (r Range) end() uint64 {
+	return uint64(r.Start) + uint64(r.Size)
+}
+
+// LoadSubIDs reads the ranges of the user (a name or
+// a numeric ID) from /etc/subuid or /etc/subgid.
+func LoadSubIDs(path, user string) ([]Range, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSubIDs(f, user)
+}
+
+// ParseSubIDs parses the <user>:<start>:<count> lines.
+func ParseSubIDs(r io.Reader, user string) ([]Range, error) {
+	var ranges []Range
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 {
+			return nil, errors.Errorf("bad subordinate ID line %q", line)
+		}
+		if parts[0] != user {
+			continue
+		}
+		start, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("bad subordinate ID line %q", line)
+		}
+		size, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil || start+size > 1<<32 {
+			return nil, errors.Errorf("bad subordinate ID line %q", line)
+		}
+		if size > 0 {
+			ranges = append(ranges, Range{Start: uint32(start), Size: uint32(size)})
+		}
+	}
+	return ranges, scanner.Err()
+}
+
+// Allocator hands out non-overlapping host IDs from the subordinate
+// ranges. It's safe for concurrent use.
+type Allocator struct {
+	mu     sync.Mutex
+	ranges []Range
+	used   []Range // sorted by Start
+}
+
+func NewAllocator(ranges []Range) *Allocator {
+	ranges = append([]Range(nil), ranges...)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+	return &Allocator{ranges: ranges}
+}
+
+// Allocate finds the lowest free block fitting the mappings, whose
+// host IDs are the offsets in the block. The returned mappings
+// have the actual host IDs.
+func (a *Allocator) Allocate(mappings []Mapping) ([]Mapping, error) {
+	if err := Validate(mappings); err != nil {
+		return nil, err
+	}
+	var span uint64
+	for _, m := range mappings {
+		if end := uint64(m.HostID) + uint64(m.Size); end > span {
+			span = end
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.ranges) == 0 {
+		return nil, errors.New("no subordinate IDs configured")
+	}
+
+	for _, r := range a.ranges {
+		base := uint64(r.Start)
+		for base+span <= r.end() {
+			next, free := a.firstUsedNoLock(base, span)
+			if free {
+				allocated := make([]Mapping, len(mappings))
+				for i, m := range mappings {
+					allocated[i] = m
+					allocated[i].HostID = uint32(base) + m.HostID
+				}
+				a.reserveNoLock(allocated)
+				return allocated, nil
+			}
+			base = next
+		}
+	}
+	return nil, errors.Errorf("no %d free subordinate IDs left", span)
+}
+
+// Reserve marks the host IDs of the (already allocated) mappings used.
+func (a *Allocator) Reserve(mappings []Mapping) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, m := range mappings {
+		if _, free := a.firstUsedNoLock(uint64(m.HostID), uint64(m.Size)); !free {
+			return errors.Errorf("host IDs %d-%d are already in use", m.HostID, uint64(m.HostID)+uint64(m.Size)-1)
+		}
+	}
+	a.reserveNoLock(mappings)
+	return nil
+}
+
+// Release makes the host IDs of the mappings available again.
+func (a *Allocator) Release(mappings []Mapping) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, m := range mappings {
+		for i, u := range a.used {
+			if u.Start == m.HostID && u.Size == m.Size {
+				a.used = append(a.used[:i], a.used[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// firstUsedNoLock checks if [start, start+size) is free. If not,
+// the end of the first used range overlapping it is returned.
+func (a *Allocator) firstUsedNoLock(start, size uint64) (uint64, bool) {
+	for _, u := range a.used {
+		if uint64(u.Start) < start+size && start < u.end() {
+			return u.end(), false
+		}
+	}
+	return 0, true
+}
+
+func (a *Allocator) reserveNoLock(mappings []Mapping) {
+	for _, m := range mappings {
+		a.used = append(a.used, Range{Start: m.HostID, Size: m.Size})
+	}
+	sort.Slice(a.used, func(i, j int) bool {
+		return a.used[i].Start < a.used[j].Start
+	})
+}
@@ -0,0 +1,183 @@
+package idmap_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/idmap"
+)
+
+func TestParseSubIDs(t *testing.T) {
+	ranges, err := idmap.ParseSubIDs(strings.NewReader(`
+# comment
+alice:100000:65536
+conman:200000:131072
+1000:300000:65536
+conman:500000:65536
+`), "conman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []idmap.Range{{Start: 200000, Size: 131072}, {Start: 500000, Size: 65536}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Fatalf("unexpected ranges %v", ranges)
+	}
+
+	if _, err := idmap.ParseSubIDs(strings.NewReader("conman:x:1\n"), "conman"); err == nil {
+		t.Fatal("expected error for bad line")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		mappings []idmap.Mapping
+		err      string
+	}{
+		{mappings: idmap.DefaultMappings},
+		{mappings: []idmap.Mapping{{0, 1000, 1}, {1, 0, 1000}}},
+		{err: "no ID mappings"},
+		{mappings: []idmap.Mapping{{0, 0, 0}}, err: "empty ID mapping"},
+		{mappings: []idmap.Mapping{{0, 0, 10}, {5, 100, 10}}, err: "overlap"},
+		{mappings: []idmap.Mapping{{0, 0, 10}, {100, 5, 10}}, err: "overlap"},
+		{mappings: []idmap.Mapping{{4294967295, 0, 2}}, err: "out of range"},
+	}
+	for i, c := range cases {
+		err := idmap.Validate(c.mappings)
+		if c.err == "" && err != nil {
+			t.Errorf("case %d: unexpected error %v", i, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("case %d: expected error %q, got %v", i, c.err, err)
+		}
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	a := idmap.NewAllocator([]idmap.Range{{Start: 300000, Size: 65536}, {Start: 100000, Size: 131072}})
+
+	m1, err := a.Allocate(idmap.DefaultMappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m1[0].HostID != 100000 {
+		t.Fatalf("unexpected mappings %v", m1)
+	}
+
+	m2, err := a.Allocate([]idmap.Mapping{{0, 1000, 1}, {1, 0, 1000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m2[0].HostID != 165536+1000 || m2[1].HostID != 165536 {
+		t.Fatalf("unexpected mappings %v", m2)
+	}
+
+	// Doesn't fit into the rest of the first range.
+	m3, err := a.Allocate(idmap.DefaultMappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m3[0].HostID != 300000 {
+		t.Fatalf("unexpected mappings %v", m3)
+	}
+
+	if _, err := a.Allocate(idmap.DefaultMappings); err == nil {
+		t.Fatal("expected error for exhausted ranges")
+	}
+
+	a.Release(m1)
+	if err := a.Reserve(m3); err == nil {
+		t.Fatal("expected error for reserving used IDs")
+	}
+	m4, err := a.Allocate(idmap.DefaultMappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m4, m1) {
+		t.Fatalf("unexpected mappings %v", m4)
+	}
+
+	if _, err := idmap.NewAllocator(nil).Allocate(idmap.DefaultMappings); err == nil {
+		t.Fatal("expected error for no ranges")
+	}
+}
+
+func TestShift(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+
+	root := t.TempDir()
+	must(t, ioutil.WriteFile(filepath.Join(root, "file"), nil, 0644))
+	must(t, os.Link(filepath.Join(root, "file"), filepath.Join(root, "link")))
+	must(t, ioutil.WriteFile(filepath.Join(root, "suid"), nil, 0755|os.ModeSetuid))
+	must(t, os.Chmod(filepath.Join(root, "suid"), 0755|os.ModeSetuid))
+	must(t, os.Symlink("file", filepath.Join(root, "symlink")))
+	must(t, os.Lchown(filepath.Join(root, "symlink"), 1, 1))
+
+	mappings := []idmap.Mapping{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	must(t, idmap.Shift(root, mappings, mappings))
+
+	for name, expected := range map[string]uint32{
+		".":       100000,
+		"file":    100000,
+		"link":    100000,
+		"suid":    100000,
+		"symlink": 100001,
+	} {
+		var st syscall.Stat_t
+		must(t, syscall.Lstat(filepath.Join(root, name), &st))
+		if st.Uid != expected || st.Gid != expected {
+			t.Errorf("unexpected %s owner %d:%d", name, st.Uid, st.Gid)
+		}
+	}
+
+	fi, err := os.Stat(filepath.Join(root, "suid"))
+	must(t, err)
+	if fi.Mode()&os.ModeSetuid == 0 {
+		t.Error("set-user-ID bit is lost")
+	}
+}
+
+func TestMount(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("requires root")
+	}
+	dir := t.TempDir()
+	if !idmap.Supported(dir) {
+		t.Skip("idmapped mounts are not supported")
+	}
+
+	src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	must(t, os.Mkdir(src, 0755))
+	must(t, os.Mkdir(dst, 0755))
+	must(t, ioutil.WriteFile(filepath.Join(src, "file"), nil, 0644))
+
+	mappings := []idmap.Mapping{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	must(t, idmap.Mount(src, dst, mappings, mappings))
+	defer idmap.Unmount(dst)
+
+	var st syscall.Stat_t
+	must(t, syscall.Stat(filepath.Join(dst, "file"), &st))
+	if st.Uid != 100000 || st.Gid != 100000 {
+		t.Errorf("unexpected owner %d:%d", st.Uid, st.Gid)
+	}
+	must(t, syscall.Stat(filepath.Join(src, "file"), &st))
+	if st.Uid != 0 {
+		t.Errorf("source owner changed to %d", st.Uid)
+	}
+
+	must(t, idmap.Unmount(dst))
+	must(t, idmap.Unmount(dst))
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+package idmap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// The new mount API syscalls have the same numbers on all the
+// architectures, but the syscall package doesn't define them.
+const (
+	sysOpenTree     = 428
+	sysMoveMount    = 429
+	sysMountSetattr = 442
+
+	openTreeClone       = 0x1
+	atRecursive         = 0x8000
+	atEmptyPath         = 0x1000
+	moveMountFEmptyPath = 0x4
+	mountAttrIDMap      = 0x100000
+)
+
+var atFDCWD = -100
+
+// struct mount_attr from <linux/mount.h>.
+type mountAttr struct {
+	attrSet     uint64
+	attrClr     uint64
+	propagation uint64
+	usernsFD    uint64
+}
+
+// Mount bind mounts src to dst, so that the files owned by the
+// container IDs in src appear owned by the mapped host IDs in dst.
+// Requires Linux 5.12+ and a file system supporting idmapped mounts.
+func Mount(src, dst string, uidMap, gidMap []Mapping) error {
+	userns, err := usernsFile(uidMap, gidMap)
+	if err != nil {
+		return errors.Wrap(err, "create user namespace")
+	}
+	defer userns.Close()
+
+	srcp, err := syscall.BytePtrFromString(src)
+	if err != nil {
+		return err
+	}
+	dstp, err := syscall.BytePtrFromString(dst)
+	if err != nil {
+		return err
+	}
+	empty, _ := syscall.BytePtrFromString("")
+
+	fd, _, errno := syscall.Syscall(
+		sysOpenTree,
+		uintptr(atFDCWD),
+		uintptr(unsafe.Pointer(srcp)),
+		openTreeClone|syscall.O_CLOEXEC|atRecursive,
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "open_tree", Path: src, Err: errno}
+	}
+	defer syscall.Close(int(fd))
+
+	attr := mountAttr{
+		attrSet:  mountAttrIDMap,
+		usernsFD: uint64(userns.Fd()),
+	}
+	_, _, errno = syscall.Syscall6(
+		sysMountSetattr,
+		fd,
+		uintptr(unsafe.Pointer(empty)),
+		atEmptyPath|atRecursive,
+		uintptr(unsafe.Pointer(&attr)),
+		unsafe.Sizeof(attr),
+		0,
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "mount_setattr", Path: src, Err: errno}
+	}
+
+	_, _, errno = syscall.Syscall6(
+		sysMoveMount,
+		fd,
+		uintptr(unsafe.Pointer(empty)),
+		uintptr(atFDCWD),
+		uintptr(unsafe.Pointer(dstp)),
+		moveMountFEmptyPath,
+		0,
+	)
+	if errno != 0 {
+		return &os.PathError{Op: "move_mount", Path: dst, Err: errno}
+	}
+	return nil
+}
+
+// Unmount lazily unmounts the idmapped mount. Not mounted
+// (or missing) dst isn't an error.
+func Unmount(dst string) error {
+	err := syscall.Unmount(dst, syscall.MNT_DETACH)
+	if err == syscall.EINVAL || err == syscall.ENOENT {
+		return nil
+	}
+	return err
+}
+
+// Supported tells if the file system of dir supports idmapped mounts.
+func Supported(dir string) bool {
+	tmp, err := ioutil.TempDir(dir, ".idmap-probe.")
+	if err != nil {
+		return false
+	}
+	defer os.RemoveAll(tmp)
+
+	src, dst := filepath.Join(tmp, "src"), filepath.Join(tmp, "dst")
+	if os.Mkdir(src, 0700) != nil || os.Mkdir(dst, 0700) != nil {
+		return false
+	}
+
+	// Any valid mapping will do, mapping the own UID
+	// doesn't require CAP_SETUID.
+	mappings := []Mapping{{ContainerID: 0, HostID: uint32(os.Geteuid()), Size: 1}}
+	if err := Mount(src, dst, mappings, mappings); err != nil {
+		return false
+	}
+	Unmount(dst)
+	return true
+}
+
+// usernsFile creates a user namespace with the mappings. The namespace
+// is kept alive by a child process stopped right after the exec (the
+// child is traced), so no Go code runs in it.
+func usernsFile(uidMap, gidMap []Mapping) (*os.File, error) {
+	cmd := exec.Command("/proc/self/exe")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWUSER,
+		UidMappings: sysMappings(uidMap),
+		GidMappings: sysMappings(gidMap),
+		Ptrace:      true,
+		Pdeathsig:   syscall.SIGKILL,
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	pid := cmd.Process.Pid
+	defer func() {
+		syscall.Kill(pid, syscall.SIGKILL)
+		// A traced child reports the stops too.
+		for {
+			var ws syscall.WaitStatus
+			_, err := syscall.Wait4(pid, &ws, 0, nil)
+			if err != syscall.EINTR && (err != nil || ws.Exited() || ws.Signaled()) {
+				break
+			}
+		}
+		cmd.Process.Release()
+	}()
+
+	return os.Open(fmt.Sprintf("/proc/%d/ns/user", pid))
+}
+
+func sysMappings(mappings []Mapping) []syscall.SysProcIDMap {
+	var rv []syscall.SysProcIDMap
+	for _, m := range mappings {
+		rv = append(rv, syscall.SysProcIDMap{
+			ContainerID: int(m.ContainerID),
+			HostID:      int(m.HostID),
+			Size:        int(m.Size),
+		})
+	}
+	return rv
+}
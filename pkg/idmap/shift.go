@@ -0,0 +1,58 @@
+package idmap
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Shift changes the ownership of the files under root from the
+// container IDs to the mapped host IDs. It's the fallback for the
+// file systems not supporting idmapped mounts. The IDs not covered
+// by the mappings are left as is.
+func Shift(root string, uidMap, gidMap []Mapping) error {
+	type inode struct {
+		dev uint64
+		ino uint64
+	}
+	// Hard links must not be shifted twice.
+	seen := make(map[inode]bool)
+
+	return filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		st, ok := fi.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		if st.Nlink > 1 && !fi.IsDir() {
+			key := inode{dev: uint64(st.Dev), ino: st.Ino}
+			if seen[key] {
+				return nil
+			}
+			seen[key] = true
+		}
+
+		uid, uidOK := HostID(uidMap, st.Uid)
+		gid, gidOK := HostID(gidMap, st.Gid)
+		if !uidOK {
+			uid = st.Uid
+		}
+		if !gidOK {
+			gid = st.Gid
+		}
+		if uid == st.Uid && gid == st.Gid {
+			return nil
+		}
+
+		if err := os.Lchown(path, int(uid), int(gid)); err != nil {
+			return err
+		}
+		// chown(2) clears the set-user-ID and set-group-ID bits.
+		if fi.Mode()&(os.ModeSetuid|os.ModeSetgid) != 0 && fi.Mode()&os.ModeSymlink == 0 {
+			return os.Chmod(path, fi.Mode())
+		}
+		return nil
+	})
+}
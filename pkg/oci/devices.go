@@ -0,0 +1,98 @@
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// Directories under /dev the runtime mounts on its own.
+var skipDevDirs = map[string]bool{
+	"/dev/pts":    true,
+	"/dev/shm":    true,
+	"/dev/fd":     true,
+	"/dev/mqueue": true,
+}
+
+// Devices the runtime creates on its own.
+var skipDevices = map[string]bool{
+	"/dev/console": true,
+	"/dev/ptmx":    true,
+}
+
+// hostDevices lists the block and char devices under /dev.
+func hostDevices() ([]rspec.LinuxDevice, error) {
+	var devices []rspec.LinuxDevice
+	err := filepath.Walk("/dev", func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			// Devices come and go, e.g. when a USB stick is unplugged.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() {
+			if skipDevDirs[path] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if skipDevices[path] || fi.Mode()&os.ModeDevice == 0 {
+			return nil
+		}
+
+		if d, ok := deviceFromInfo(fi, path); ok {
+			devices = append(devices, d)
+		}
+		return nil
+	})
+	return devices, err
+}
+
+// DeviceFromPath describes the host device to be created
+// in the container at the given path.
+func DeviceFromPath(hostPath, containerPath string) (rspec.LinuxDevice, error) {
+	fi, err := os.Stat(hostPath)
+	if err != nil {
+		return rspec.LinuxDevice{}, err
+	}
+	d, ok := deviceFromInfo(fi, containerPath)
+	if !ok {
+		return rspec.LinuxDevice{}, errors.Errorf("%s is not a device", hostPath)
+	}
+	return d, nil
+}
+
+func deviceFromInfo(fi os.FileInfo, path string) (rspec.LinuxDevice, bool) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || fi.Mode()&os.ModeDevice == 0 {
+		return rspec.LinuxDevice{}, false
+	}
+	typ := "b"
+	if fi.Mode()&os.ModeCharDevice != 0 {
+		typ = "c"
+	}
+	mode := fi.Mode().Perm()
+	uid, gid := st.Uid, st.Gid
+	return rspec.LinuxDevice{
+		Path:     path,
+		Type:     typ,
+		Major:    devMajor(uint64(st.Rdev)),
+		Minor:    devMinor(uint64(st.Rdev)),
+		FileMode: &mode,
+		UID:      &uid,
+		GID:      &gid,
+	}, true
+}
+
+// See MAJOR() and MINOR() in <sys/sysmacros.h>.
+func devMajor(dev uint64) int64 {
+	return int64(((dev >> 8) & 0xfff) | ((dev >> 32) &^ 0xfff))
+}
+
+func devMinor(dev uint64) int64 {
+	return int64((dev & 0xff) | ((dev >> 12) &^ 0xff))
+}
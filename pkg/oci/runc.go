@@ -1,6 +1,7 @@
 package oci
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,21 +29,27 @@ type runcRuntime struct {
 
 	// dir to store container state (on tmpfs), eg. /run/runc/
 	rootPath string
+
+	// runc --rootless, i.e. ignore the cgroup permission errors
+	rootless bool
 }
 
 func NewRuntime(
 	shimmyPath string,
 	runtimePath string,
 	rootPath string,
+	rootless bool,
 ) Runtime {
 	return &runcRuntime{
 		shimmyPath:  shimmyPath,
 		runtimePath: runtimePath,
 		rootPath:    rootPath,
+		rootless:    rootless,
 	}
 }
 
 func (r *runcRuntime) CreateContainer(
+	ctx context.Context,
 	id container.ID,
 	bundleDir string,
 	logfile string,
@@ -52,7 +59,8 @@ func (r *runcRuntime) CreateContainer(
 	stdinOnce bool,
 	timeout time.Duration,
 ) (pid int, err error) {
-	cmd := exec.Command(
+	cmd := exec.CommandContext(
+		ctx,
 		r.shimmyPath,
 		"--shimmy-pidfile", path.Join(bundleDir, "shimmy.pid"),
 		"--shimmy-log-level", strings.ToUpper(logrus.GetLevel().String()),
@@ -65,6 +73,9 @@ func (r *runcRuntime) CreateContainer(
 		"--container-exitfile", exitfile,
 		"--container-attachfile", attachfile,
 	)
+	if r.rootless {
+		cmd.Args = append(cmd.Args, "--runtime-arg='--rootless=true'")
+	}
 	if stdin {
 		cmd.Args = append(cmd.Args, "--stdin")
 	}
@@ -102,14 +113,17 @@ func (r *runcRuntime) CreateContainer(
 		Pid    int    `json:"pid"`
 	}
 
-	return pid, timeutil.WithTimeout(timeout, func() error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var report Report
+	err = timeutil.WithContext(ctx, func() error {
 		bytes, err := ioutil.ReadAll(syncpipeRead)
 		if err != nil {
 			return err
 		}
 		syncpipeRead.Close()
 
-		report := Report{}
 		if err := json.Unmarshal(bytes, &report); err != nil {
 			return errors.Wrap(
 				err,
@@ -117,59 +131,72 @@ func (r *runcRuntime) CreateContainer(
 					string(bytes), bytes),
 			)
 		}
-
-		if report.Kind == "container_pid" && report.Pid > 0 {
-			pid = report.Pid
-			return nil
-		}
-		return errors.Errorf("%+v", report)
+		return nil
 	})
+	if err != nil {
+		// The deferred syncpipeRead.Close() unblocks the reader on timeout.
+		return 0, errors.Wrap(err, "Failed to receive container PID")
+	}
+
+	if report.Kind == "container_pid" && report.Pid > 0 {
+		return report.Pid, nil
+	}
+	return 0, errors.Errorf("%+v", report)
 }
 
-func (r *runcRuntime) StartContainer(id container.ID) error {
-	cmd := exec.Command(
+func (r *runcRuntime) StartContainer(
+	ctx context.Context,
+	id container.ID,
+) error {
+	cmd := exec.CommandContext(
+		ctx,
 		r.runtimePath,
-		"--root", r.rootPath,
-		"start", string(id),
+		r.runtimeArgs("start", string(id))...,
 	)
 	_, err := runCommand(cmd)
 	return err
 }
 
-func (r *runcRuntime) KillContainer(id container.ID, sig os.Signal) error {
+func (r *runcRuntime) KillContainer(
+	ctx context.Context,
+	id container.ID,
+	sig os.Signal,
+) error {
 	sigstr, err := sigStr(sig)
 	if err != nil {
 		return err
 	}
 
-	cmd := exec.Command(
+	cmd := exec.CommandContext(
+		ctx,
 		r.runtimePath,
-		"--root", r.rootPath,
-		"kill",
-		string(id),
-		sigstr,
+		r.runtimeArgs("kill", string(id), sigstr)...,
 	)
 	_, err = runCommand(cmd)
 	return err
 }
 
-func (r *runcRuntime) DeleteContainer(id container.ID) error {
-	cmd := exec.Command(
+func (r *runcRuntime) DeleteContainer(
+	ctx context.Context,
+	id container.ID,
+) error {
+	cmd := exec.CommandContext(
+		ctx,
 		r.runtimePath,
-		"--root", r.rootPath,
-		"delete",
-		string(id),
+		r.runtimeArgs("delete", string(id))...,
 	)
 	_, err := runCommand(cmd)
 	return err
 }
 
-func (r *runcRuntime) ContainerState(id container.ID) (StateResp, error) {
-	cmd := exec.Command(
+func (r *runcRuntime) ContainerState(
+	ctx context.Context,
+	id container.ID,
+) (StateResp, error) {
+	cmd := exec.CommandContext(
+		ctx,
 		r.runtimePath,
-		"--root", r.rootPath,
-		"state",
-		string(id),
+		r.runtimeArgs("state", string(id))...,
 	)
 	output, err := runCommand(cmd)
 	if err != nil {
@@ -180,6 +207,33 @@ func (r *runcRuntime) ContainerState(id container.ID) (StateResp, error) {
 	return resp, json.Unmarshal(output, &resp)
 }
 
+func (r *runcRuntime) ContainerPids(
+	ctx context.Context,
+	id container.ID,
+) ([]int, error) {
+	cmd := exec.CommandContext(
+		ctx,
+		r.runtimePath,
+		r.runtimeArgs("ps", "--format", "json", string(id))...,
+	)
+	output, err := runCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	return pids, json.Unmarshal(output, &pids)
+}
+
+// runtimeArgs prepends the global options to the runc command args.
+func (r *runcRuntime) runtimeArgs(args ...string) []string {
+	global := []string{"--root", r.rootPath}
+	if r.rootless {
+		global = append(global, "--rootless", "true")
+	}
+	return append(global, args...)
+}
+
 func runCommand(cmd *exec.Cmd) ([]byte, error) {
 	output, err := cmd.Output()
 	debugLog(cmd, output, err)
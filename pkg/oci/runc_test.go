@@ -1,10 +1,12 @@
 package oci_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -91,7 +93,7 @@ func Test_NonInteractive_SimpleRun(t *testing.T) {
 	}
 
 	// Start container
-	if err := helper.rt.StartContainer(hcont.ContainerID()); err != nil {
+	if err := helper.rt.StartContainer(context.Background(), hcont.ContainerID()); err != nil {
 		t.Fatal(err)
 	}
 
@@ -143,6 +145,52 @@ func Test_NonInteractive_SignalContainer(t *testing.T) {
 func Test_CreateContainer_ContainerShellExitsWithError(t *testing.T) {
 }
 
+func Test_ContextCancellation_AbortsRuntime(t *testing.T) {
+	tmpDir := testutil.TempDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Stands in for both shimmy and runc.
+	hang := path.Join(tmpDir, "hang")
+	if err := ioutil.WriteFile(hang, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rt := oci.NewRuntime(hang, hang, tmpDir, false)
+	id := container.RandID()
+
+	calls := map[string]func(context.Context) error{
+		"CreateContainer": func(ctx context.Context) error {
+			_, err := rt.CreateContainer(ctx, id, tmpDir, "", "", "", false, false, time.Minute)
+			return err
+		},
+		"StartContainer": func(ctx context.Context) error {
+			return rt.StartContainer(ctx, id)
+		},
+		"KillContainer": func(ctx context.Context) error {
+			return rt.KillContainer(ctx, id, syscall.SIGTERM)
+		},
+	}
+	for name, call := range calls {
+		// Already cancelled.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := call(ctx); err == nil {
+			t.Errorf("%s() with cancelled context is expected to fail", name)
+		}
+
+		// Cancelled midway.
+		ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+		start := time.Now()
+		err := call(ctx)
+		cancel()
+		if err == nil {
+			t.Errorf("%s() is expected to fail on context cancellation", name)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("%s() is expected to be aborted, took %v", name, elapsed)
+		}
+	}
+}
+
 type TestHelper struct {
 	rt     oci.Runtime
 	cstore storage.ContainerStore
@@ -158,6 +206,7 @@ func NewTestHelper(t *testing.T, cfg *config.Config) *TestHelper {
 			cfg.ShimmyPath,
 			cfg.RuntimePath,
 			fsutil.EnsureExists(path.Join(tmpDir, "runc")),
+			false,
 		),
 		cstore: storage.NewContainerStore(path.Join(tmpDir, "cstore")),
 		tmpDir: tmpDir,
@@ -194,6 +243,7 @@ func (h *TestHelper) createContainer(
 	}
 
 	pid, err = h.rt.CreateContainer(
+		context.Background(),
 		contID,
 		hcont.BundleDir(),
 		h.containerLogPath(contID),
@@ -1,6 +1,7 @@
 package oci
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -8,8 +9,10 @@ import (
 )
 
 // Runtime represents an OCI container runtime interface.
+// Cancellation of the ctx aborts the underlying runtime invocation.
 type Runtime interface {
 	CreateContainer(
+		ctx context.Context,
 		id container.ID,
 		bundleDir string,
 		logfile string,
@@ -19,10 +22,12 @@ type Runtime interface {
 		stdinOnce bool,
 		timeout time.Duration,
 	) (pid int, err error)
-	StartContainer(id container.ID) error
-	KillContainer(id container.ID, sig os.Signal) error
-	DeleteContainer(id container.ID) error
-	ContainerState(container.ID) (StateResp, error)
+	StartContainer(ctx context.Context, id container.ID) error
+	KillContainer(ctx context.Context, id container.ID, sig os.Signal) error
+	DeleteContainer(ctx context.Context, id container.ID) error
+	ContainerState(ctx context.Context, id container.ID) (StateResp, error)
+	// ContainerPids lists the host PIDs of the container processes.
+	ContainerPids(ctx context.Context, id container.ID) ([]int, error)
 }
 
 type StateResp struct {
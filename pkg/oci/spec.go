@@ -1,19 +1,77 @@
 package oci
 
 import (
-	"bufio"
 	"bytes"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/pkg/errors"
 )
 
 type RuntimeSpec []byte
 
 type SpecOptions struct {
-	Command      string
-	Args         []string
+	Command string
+	Args    []string
+	// Replaces the default environment if set.
+	Env []string
+	// Added on top of Env (or the default environment),
+	// replacing the variables with the same names.
+	ExtraEnv     []string
 	RootPath     string
 	RootReadonly bool
+	Annotations  map[string]string
+	// Added on top of the default mounts, replacing
+	// the ones with the same destinations.
+	Mounts []rspec.Mount
+	// Rootfs mount propagation (rprivate, rslave, or rshared).
+	// Left to the OCI runtime if empty.
+	RootfsPropagation string
+	// Replaces the runtime-tools default profile.
+	// The container is unconfined if nil.
+	Seccomp *rspec.LinuxSeccomp
+	// Replaces the runtime-tools default capabilities (the bounding,
+	// effective, permitted, and inheritable sets) if not nil.
+	Capabilities    []string
+	NoNewPrivileges bool
+	// Replaces the runtime-tools default rlimits (RLIMIT_NOFILE
+	// 1024:1024) if not nil.
+	Rlimits []rspec.POSIXRlimit
+	// Inherited from the OCI runtime if nil.
+	OOMScoreAdj *int
+	// Exposes all the host devices, mounts /sys read-write,
+	// and leaves /proc and /sys unmasked.
+	Privileged bool
+	// The /proc and /sys paths masked (or mounted read-only)
+	// in the container.
+	MaskedPaths   []string
+	ReadonlyPaths []string
+	// Namespaced kernel parameters, e.g. net.core.somaxconn.
+	Sysctls map[string]string
+	// Device nodes created in the container, along
+	// with the cgroup rules allowing the access.
+	Devices []Device
+	Hooks   *rspec.Hooks
+	// The container gets a new user namespace if set.
+	UIDMappings []rspec.LinuxIDMapping
+	GIDMappings []rspec.LinuxIDMapping
+	// The namespaces shared with the host (an empty path) or joined
+	// by path (e.g. /proc/<pid>/ns/net) instead of the new ones.
+	SharedNamespaces map[rspec.LinuxNamespaceType]string
+	// Drops the mount options referring to the unmapped groups and,
+	// unless the cgroups are delegated, the cgroup limits.
+	Rootless         bool
+	CgroupsDelegated bool
+}
+
+type Device struct {
+	rspec.LinuxDevice
+	// Any combination of r (read), w (write), and m (mknod).
+	Permissions string
 }
 
 func NewSpec(opts SpecOptions) (RuntimeSpec, error) {
@@ -26,11 +84,219 @@ func NewSpec(opts SpecOptions) (RuntimeSpec, error) {
 	gen.SetRootPath(opts.RootPath)
 	gen.SetRootReadonly(opts.RootReadonly)
 	gen.SetProcessArgs(append([]string{opts.Command}, opts.Args...))
+	if len(opts.Env) > 0 {
+		gen.ClearProcessEnv()
+		for _, e := range opts.Env {
+			kv := strings.SplitN(e, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.Errorf("bad environment variable %q", e)
+			}
+			gen.AddProcessEnv(kv[0], kv[1])
+		}
+	}
+	for _, e := range opts.ExtraEnv {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("bad environment variable %q", e)
+		}
+		gen.AddProcessEnv(kv[0], kv[1])
+	}
+	if opts.Capabilities != nil {
+		setCapabilities(&gen, opts.Capabilities)
+	}
+	gen.SetProcessNoNewPrivileges(opts.NoNewPrivileges)
+	if opts.Rlimits != nil {
+		gen.Config.Process.Rlimits = opts.Rlimits
+	}
+	if opts.OOMScoreAdj != nil {
+		gen.SetProcessOOMScoreAdj(*opts.OOMScoreAdj)
+	}
+	if len(opts.UIDMappings) > 0 || len(opts.GIDMappings) > 0 {
+		if err := gen.AddOrReplaceLinuxNamespace("user", ""); err != nil {
+			return nil, err
+		}
+		for _, m := range opts.UIDMappings {
+			gen.AddLinuxUIDMapping(m.HostID, m.ContainerID, m.Size)
+		}
+		for _, m := range opts.GIDMappings {
+			gen.AddLinuxGIDMapping(m.HostID, m.ContainerID, m.Size)
+		}
+	}
+	if err := setSharedNamespaces(&gen, opts.SharedNamespaces); err != nil {
+		return nil, err
+	}
+	gen.Config.Linux.MaskedPaths = opts.MaskedPaths
+	gen.Config.Linux.ReadonlyPaths = opts.ReadonlyPaths
+	for k, v := range opts.Sysctls {
+		gen.AddLinuxSysctl(k, v)
+	}
+	if opts.Privileged {
+		if err := setPrivileged(&gen); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range opts.Devices {
+		d := d
+		gen.AddDevice(d.LinuxDevice)
+		gen.AddLinuxResourcesDevice(true, d.Type, &d.Major, &d.Minor, d.Permissions)
+	}
+	if err := addMounts(&gen, opts.Mounts); err != nil {
+		return nil, err
+	}
+	if opts.RootfsPropagation != "" {
+		if err := gen.SetLinuxRootPropagation(opts.RootfsPropagation); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Rootless {
+		setRootless(&gen, opts.GIDMappings, opts.CgroupsDelegated)
+	}
+	gen.Config.Linux.Seccomp = opts.Seccomp
+	gen.Config.Hooks = opts.Hooks
+	for k, v := range opts.Annotations {
+		gen.AddAnnotation(k, v)
+	}
 
 	var buf bytes.Buffer
 	exprOpts := generate.ExportOptions{}
-	if err := gen.Save(bufio.NewWriter(&buf), exprOpts); err != nil {
+	if err := gen.Save(&buf, exprOpts); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
+
+func setCapabilities(gen *generate.Generator, caps []string) {
+	gen.Config.Process.Capabilities = &rspec.LinuxCapabilities{
+		Bounding:    append([]string{}, caps...),
+		Effective:   append([]string{}, caps...),
+		Permitted:   append([]string{}, caps...),
+		Inheritable: append([]string{}, caps...),
+	}
+}
+
+func setSharedNamespaces(gen *generate.Generator, shared map[rspec.LinuxNamespaceType]string) error {
+	for typ, path := range shared {
+		if path == "" {
+			if err := gen.RemoveLinuxNamespace(string(typ)); err != nil {
+				return err
+			}
+		} else if err := gen.AddOrReplaceLinuxNamespace(string(typ), path); err != nil {
+			return err
+		}
+	}
+
+	// Sysfs can be mounted only by the owner of the network
+	// namespace, the host one is bind mounted instead.
+	if _, ok := shared[rspec.NetworkNamespace]; ok && hasNamespace(gen, rspec.UserNamespace) {
+		for i, m := range gen.Config.Mounts {
+			if m.Destination == "/sys" && m.Type == "sysfs" {
+				gen.Config.Mounts[i].Type = "bind"
+				gen.Config.Mounts[i].Source = "/sys"
+				gen.Config.Mounts[i].Options = append([]string{"rbind"}, m.Options...)
+			}
+		}
+	}
+	return nil
+}
+
+func hasNamespace(gen *generate.Generator, typ rspec.LinuxNamespaceType) bool {
+	for _, ns := range gen.Config.Linux.Namespaces {
+		if ns.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func setPrivileged(gen *generate.Generator) error {
+	devices, err := hostDevices()
+	if err != nil {
+		return errors.Wrap(err, "list host devices")
+	}
+	for _, d := range devices {
+		gen.AddDevice(d)
+	}
+	gen.Config.Linux.Resources.Devices = []rspec.LinuxDeviceCgroup{
+		{Allow: true, Access: "rwm"},
+	}
+
+	for i, m := range gen.Config.Mounts {
+		if m.Destination != "/sys" {
+			continue
+		}
+		var options []string
+		for _, o := range m.Options {
+			if o != "ro" {
+				options = append(options, o)
+			}
+		}
+		gen.Config.Mounts[i].Options = append(options, "rw")
+	}
+
+	gen.Config.Linux.MaskedPaths = nil
+	gen.Config.Linux.ReadonlyPaths = nil
+	return nil
+}
+
+func setRootless(gen *generate.Generator, gidMappings []rspec.LinuxIDMapping, cgroups bool) {
+	// E.g. gid=5 (tty) of /dev/pts.
+	for i, m := range gen.Config.Mounts {
+		var options []string
+		for _, o := range m.Options {
+			if strings.HasPrefix(o, "gid=") {
+				gid, err := strconv.ParseUint(strings.TrimPrefix(o, "gid="), 10, 32)
+				if err != nil || !gidMapped(gidMappings, uint32(gid)) {
+					continue
+				}
+			}
+			options = append(options, o)
+		}
+		gen.Config.Mounts[i].Options = options
+	}
+
+	// The runtime ignores the cgroup permission errors
+	// only if no cgroup controllers are needed.
+	if !cgroups {
+		gen.Config.Linux.CgroupsPath = ""
+		if r := gen.Config.Linux.Resources; r != nil {
+			gen.Config.Linux.Resources = &rspec.LinuxResources{Devices: r.Devices}
+		}
+	}
+}
+
+func gidMapped(mappings []rspec.LinuxIDMapping, gid uint32) bool {
+	for _, m := range mappings {
+		if gid >= m.ContainerID && uint64(gid) < uint64(m.ContainerID)+uint64(m.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+// addMounts adds the mounts ordered by the destination depth,
+// so that a mount never gets shadowed by its parent mount.
+func addMounts(gen *generate.Generator, mounts []rspec.Mount) error {
+	mounts = append([]rspec.Mount(nil), mounts...)
+	for i := range mounts {
+		if !path.IsAbs(mounts[i].Destination) {
+			return errors.Errorf("mount destination %q is not absolute", mounts[i].Destination)
+		}
+		mounts[i].Destination = path.Clean(mounts[i].Destination)
+	}
+	sort.SliceStable(mounts, func(i, j int) bool {
+		return mountDepth(mounts[i]) < mountDepth(mounts[j])
+	})
+
+	for _, m := range mounts {
+		gen.RemoveMount(m.Destination)
+		gen.AddMount(m)
+	}
+	return nil
+}
+
+func mountDepth(m rspec.Mount) int {
+	if m.Destination == "/" {
+		return 0
+	}
+	return strings.Count(m.Destination, "/")
+}
@@ -1,7 +1,11 @@
 package oci
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 func TestNewSpec(t *testing.T) {
@@ -11,3 +15,371 @@ func TestNewSpec(t *testing.T) {
 	}
 	t.Log(len(spec))
 }
+
+func TestNewSpecAnnotations(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		Annotations: map[string]string{"com.example.foo": "bar"},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	parsed := struct {
+		Annotations map[string]string `json:"annotations"`
+	}{}
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Annotations["com.example.foo"] != "bar" {
+		t.Fatalf("Unexpected annotations %v", parsed.Annotations)
+	}
+}
+
+func TestNewSpecMounts(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		Mounts: []rspec.Mount{
+			{Destination: "/data/sub", Type: "bind", Source: "/srv/sub", Options: []string{"rbind"}},
+			{Destination: "/data/", Type: "bind", Source: "/srv", Options: []string{"rbind", "ro"}},
+			{Destination: "/dev/shm", Type: "tmpfs", Source: "tmpfs", Options: []string{"size=1024"}},
+		},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	var dests []string
+	shm := 0
+	for _, m := range parsed.Mounts {
+		switch m.Destination {
+		case "/data", "/data/sub":
+			dests = append(dests, m.Destination)
+		case "/dev/shm":
+			shm++
+			if len(m.Options) != 1 || m.Options[0] != "size=1024" {
+				t.Fatalf("default /dev/shm mount is not replaced: %+v", m)
+			}
+		}
+	}
+	if len(dests) != 2 || dests[0] != "/data" || dests[1] != "/data/sub" {
+		t.Fatalf("unexpected mount order %v", dests)
+	}
+	if shm != 1 {
+		t.Fatalf("unexpected number of /dev/shm mounts %d", shm)
+	}
+
+	if _, err := NewSpec(SpecOptions{
+		Mounts: []rspec.Mount{{Destination: "data", Type: "tmpfs", Source: "tmpfs"}},
+	}); err == nil {
+		t.Fatal("relative mount destination accepted")
+	}
+}
+
+func TestNewSpecSeccomp(t *testing.T) {
+	for _, profile := range []*rspec.LinuxSeccomp{
+		nil,
+		{
+			DefaultAction: rspec.ActErrno,
+			Syscalls:      []rspec.LinuxSyscall{{Names: []string{"read"}, Action: rspec.ActAllow}},
+		},
+	} {
+		spec, err := NewSpec(SpecOptions{Seccomp: profile})
+		if err != nil {
+			t.Fatal("NewSpec() failed", err)
+		}
+
+		var parsed rspec.Spec
+		if err := json.Unmarshal(spec, &parsed); err != nil {
+			t.Fatal(err)
+		}
+		if profile == nil {
+			if parsed.Linux.Seccomp != nil {
+				t.Fatalf("unexpected seccomp profile %+v", parsed.Linux.Seccomp)
+			}
+			continue
+		}
+		if parsed.Linux.Seccomp == nil ||
+			parsed.Linux.Seccomp.DefaultAction != rspec.ActErrno ||
+			len(parsed.Linux.Seccomp.Syscalls) != 1 {
+			t.Fatalf("unexpected seccomp profile %+v", parsed.Linux.Seccomp)
+		}
+	}
+}
+
+func TestNewSpecCapabilities(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		Capabilities:    []string{"CAP_CHOWN", "CAP_NET_ADMIN"},
+		NoNewPrivileges: true,
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	c := parsed.Process.Capabilities
+	for _, set := range [][]string{c.Bounding, c.Effective, c.Permitted, c.Inheritable} {
+		if len(set) != 2 || set[0] != "CAP_CHOWN" || set[1] != "CAP_NET_ADMIN" {
+			t.Fatalf("unexpected capabilities %+v", c)
+		}
+	}
+	if len(c.Ambient) != 0 {
+		t.Fatalf("unexpected ambient capabilities %v", c.Ambient)
+	}
+	if !parsed.Process.NoNewPrivileges {
+		t.Fatal("noNewPrivileges is not set")
+	}
+}
+
+func TestNewSpecPrivileged(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{Privileged: true})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	devices := parsed.Linux.Resources.Devices
+	if len(devices) != 1 || !devices[0].Allow || devices[0].Access != "rwm" {
+		t.Fatalf("unexpected device cgroup rules %+v", devices)
+	}
+	null := false
+	for _, d := range parsed.Linux.Devices {
+		if d.Path == "/dev/null" {
+			null = d.Type == "c" && d.Major == 1 && d.Minor == 3
+		}
+	}
+	if !null {
+		t.Fatalf("/dev/null is missing in %+v", parsed.Linux.Devices)
+	}
+	for _, m := range parsed.Mounts {
+		if m.Destination != "/sys" {
+			continue
+		}
+		for _, o := range m.Options {
+			if o == "ro" {
+				t.Fatalf("/sys is read-only: %+v", m)
+			}
+		}
+	}
+}
+
+func TestNewSpecUserNamespace(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		UIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		GIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 200000, Size: 65536}},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	userns := false
+	for _, ns := range parsed.Linux.Namespaces {
+		userns = userns || ns.Type == rspec.UserNamespace
+	}
+	if !userns {
+		t.Fatalf("no user namespace in %+v", parsed.Linux.Namespaces)
+	}
+	if len(parsed.Linux.UIDMappings) != 1 || parsed.Linux.UIDMappings[0].HostID != 100000 ||
+		len(parsed.Linux.GIDMappings) != 1 || parsed.Linux.GIDMappings[0].HostID != 200000 {
+		t.Fatalf("unexpected mappings %+v %+v", parsed.Linux.UIDMappings, parsed.Linux.GIDMappings)
+	}
+}
+
+func TestNewSpecRootless(t *testing.T) {
+	cases := []struct {
+		gidMappings []rspec.LinuxIDMapping
+		delegated   bool
+		ptsGID      bool
+	}{
+		{
+			gidMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+		},
+		{
+			gidMappings: []rspec.LinuxIDMapping{
+				{ContainerID: 0, HostID: 1000, Size: 1},
+				{ContainerID: 1, HostID: 100000, Size: 65536},
+			},
+			delegated: true,
+			ptsGID:    true,
+		},
+	}
+
+	for i, c := range cases {
+		spec, err := NewSpec(SpecOptions{
+			UIDMappings:      []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+			GIDMappings:      c.gidMappings,
+			Rootless:         true,
+			CgroupsDelegated: c.delegated,
+		})
+		if err != nil {
+			t.Fatalf("case %d: NewSpec() failed %v", i, err)
+		}
+
+		var parsed rspec.Spec
+		if err := json.Unmarshal(spec, &parsed); err != nil {
+			t.Fatal(err)
+		}
+
+		ptsGID := false
+		for _, m := range parsed.Mounts {
+			for _, o := range m.Options {
+				if m.Destination == "/dev/pts" && o == "gid=5" {
+					ptsGID = true
+				}
+			}
+		}
+		if ptsGID != c.ptsGID {
+			t.Errorf("case %d: unexpected /dev/pts gid=5 option presence %v", i, ptsGID)
+		}
+
+		// The device cgroup rules are kept either way.
+		if parsed.Linux.Resources == nil || len(parsed.Linux.Resources.Devices) == 0 {
+			t.Errorf("case %d: no device cgroup rules", i)
+		}
+	}
+}
+
+func TestNewSpecSharedNamespaces(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		SharedNamespaces: map[rspec.LinuxNamespaceType]string{
+			rspec.NetworkNamespace: "",
+			rspec.PIDNamespace:     "/proc/42/ns/pid",
+		},
+		UIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		GIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	namespaces := make(map[rspec.LinuxNamespaceType]string)
+	for _, ns := range parsed.Linux.Namespaces {
+		namespaces[ns.Type] = ns.Path
+	}
+	if _, ok := namespaces[rspec.NetworkNamespace]; ok {
+		t.Errorf("unexpected network namespace in %+v", parsed.Linux.Namespaces)
+	}
+	if namespaces[rspec.PIDNamespace] != "/proc/42/ns/pid" {
+		t.Errorf("unexpected PID namespace in %+v", parsed.Linux.Namespaces)
+	}
+	if path, ok := namespaces[rspec.IPCNamespace]; !ok || path != "" {
+		t.Errorf("unexpected IPC namespace in %+v", parsed.Linux.Namespaces)
+	}
+
+	for _, m := range parsed.Mounts {
+		if m.Destination == "/sys" && (m.Type != "bind" || m.Source != "/sys") {
+			t.Errorf("unexpected /sys mount %+v", m)
+		}
+	}
+}
+
+func TestNewSpecMaskedPathsAndSysctls(t *testing.T) {
+	spec, err := NewSpec(SpecOptions{
+		MaskedPaths:   []string{"/proc/kcore"},
+		ReadonlyPaths: []string{"/proc/sys"},
+		Sysctls:       map[string]string{"net.core.somaxconn": "1024"},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed.Linux.MaskedPaths, []string{"/proc/kcore"}) {
+		t.Errorf("unexpected masked paths %v", parsed.Linux.MaskedPaths)
+	}
+	if !reflect.DeepEqual(parsed.Linux.ReadonlyPaths, []string{"/proc/sys"}) {
+		t.Errorf("unexpected readonly paths %v", parsed.Linux.ReadonlyPaths)
+	}
+	if parsed.Linux.Sysctl["net.core.somaxconn"] != "1024" {
+		t.Errorf("unexpected sysctls %v", parsed.Linux.Sysctl)
+	}
+}
+
+func TestNewSpecDevices(t *testing.T) {
+	null, err := DeviceFromPath("/dev/null", "/dev/mynull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DeviceFromPath("/etc/hostname", "/dev/hostname"); err == nil {
+		t.Error("DeviceFromPath() is expected to fail for a regular file")
+	}
+
+	spec, err := NewSpec(SpecOptions{
+		Env:      []string{"FOO=foo", "BAR=bar"},
+		ExtraEnv: []string{"BAR=baz"},
+		Devices:  []Device{{LinuxDevice: null, Permissions: "rw"}},
+		Hooks: &rspec.Hooks{
+			CreateRuntime: []rspec.Hook{{Path: "/usr/bin/true"}},
+		},
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed.Process.Env, []string{"FOO=foo", "BAR=baz"}) {
+		t.Errorf("unexpected env %v", parsed.Process.Env)
+	}
+	if len(parsed.Linux.Devices) != 1 || parsed.Linux.Devices[0].Path != "/dev/mynull" ||
+		parsed.Linux.Devices[0].Major != 1 || parsed.Linux.Devices[0].Minor != 3 {
+		t.Errorf("unexpected devices %+v", parsed.Linux.Devices)
+	}
+	rules := parsed.Linux.Resources.Devices
+	last := rules[len(rules)-1]
+	if !last.Allow || last.Type != "c" || *last.Major != 1 || *last.Minor != 3 || last.Access != "rw" {
+		t.Errorf("unexpected device rules %+v", rules)
+	}
+	if parsed.Hooks == nil || len(parsed.Hooks.CreateRuntime) != 1 {
+		t.Errorf("unexpected hooks %+v", parsed.Hooks)
+	}
+}
+
+func TestNewSpecRlimits(t *testing.T) {
+	adj := 500
+	spec, err := NewSpec(SpecOptions{
+		Rlimits:     []rspec.POSIXRlimit{{Type: "RLIMIT_NPROC", Soft: 100, Hard: 200}},
+		OOMScoreAdj: &adj,
+	})
+	if err != nil {
+		t.Fatal("NewSpec() failed", err)
+	}
+
+	var parsed rspec.Spec
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	expected := []rspec.POSIXRlimit{{Type: "RLIMIT_NPROC", Soft: 100, Hard: 200}}
+	if !reflect.DeepEqual(parsed.Process.Rlimits, expected) {
+		t.Errorf("unexpected rlimits %+v", parsed.Process.Rlimits)
+	}
+	if parsed.Process.OOMScoreAdj == nil || *parsed.Process.OOMScoreAdj != 500 {
+		t.Errorf("unexpected oom_score_adj %v", parsed.Process.OOMScoreAdj)
+	}
+}
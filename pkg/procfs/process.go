@@ -0,0 +1,150 @@
+// Package procfs reads the process information from /proc.
+package procfs
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// USER_HZ is 100 on all the architectures the kernel exposes to
+// the user space (see times(2)), so sysconf(_SC_CLK_TCK) isn't needed.
+const clockTicks = 100
+
+type Process struct {
+	// PID in the host PID namespace.
+	Pid int
+	// PID in the innermost PID namespace of the process.
+	NsPid int
+	// Real UID in the host user namespace.
+	UID     uint32
+	CPUTime time.Duration
+	// Resident set size in bytes.
+	RSS int64
+	// Comm is the executable name. Used when the Cmdline is not
+	// available (kernel threads and zombies).
+	Comm    string
+	Cmdline []string
+}
+
+// ReadProcess reads the process info from /proc/<pid>. If the process
+// is gone, the returned error satisfies IsNotExist().
+func ReadProcess(pid int) (*Process, error) {
+	dir := path.Join("/proc", strconv.Itoa(pid))
+	p := &Process{Pid: pid, NsPid: pid}
+
+	stat, err := ioutil.ReadFile(path.Join(dir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.parseStat(string(stat)); err != nil {
+		return nil, errors.Wrapf(err, "bad %s/stat", dir)
+	}
+
+	status, err := ioutil.ReadFile(path.Join(dir, "status"))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.parseStatus(status); err != nil {
+		return nil, errors.Wrapf(err, "bad %s/status", dir)
+	}
+
+	cmdline, err := ioutil.ReadFile(path.Join(dir, "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+	cmdline = bytes.TrimRight(cmdline, "\x00")
+	if len(cmdline) > 0 {
+		p.Cmdline = strings.Split(string(cmdline), "\x00")
+	}
+
+	return p, nil
+}
+
+// Command returns the command line or the [comm] if it's empty.
+func (p *Process) Command() string {
+	if len(p.Cmdline) == 0 {
+		return "[" + p.Comm + "]"
+	}
+	return strings.Join(p.Cmdline, " ")
+}
+
+// parseStat parses /proc/<pid>/stat (see proc(5)). The comm field
+// is in parentheses and may contain spaces and parentheses itself.
+func (p *Process) parseStat(stat string) error {
+	start := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if start == -1 || end < start {
+		return errors.New("no comm field")
+	}
+	p.Comm = stat[start+1 : end]
+
+	// Fields after comm, starting from state (3).
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 13 {
+		return errors.New("too few fields")
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return err
+	}
+	p.CPUTime = time.Duration(utime+stime) * time.Second / clockTicks
+	return nil
+}
+
+func (p *Process) parseStatus(status []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(status))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		fields := strings.Fields(parts[1])
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch parts[0] {
+		case "Uid":
+			uid, err := strconv.ParseUint(fields[0], 10, 32)
+			if err != nil {
+				return err
+			}
+			p.UID = uint32(uid)
+
+		case "NSpid":
+			// The last one is the innermost namespace.
+			nspid, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return err
+			}
+			p.NsPid = nspid
+
+		case "VmRSS":
+			rss, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			p.RSS = rss * 1024 // always in kB
+		}
+	}
+	return scanner.Err()
+}
+
+// IsNotExist tells if the process is gone.
+func IsNotExist(err error) bool {
+	err = errors.Cause(err)
+	return os.IsNotExist(err) || errors.Is(err, syscall.ESRCH)
+}
@@ -0,0 +1,40 @@
+package procfs_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/procfs"
+)
+
+func TestReadProcess(t *testing.T) {
+	p, err := procfs.ReadProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Pid != os.Getpid() {
+		t.Errorf("unexpected pid %d", p.Pid)
+	}
+	if p.UID != uint32(os.Getuid()) {
+		t.Errorf("unexpected uid %d", p.UID)
+	}
+	if p.RSS <= 0 {
+		t.Errorf("unexpected rss %d", p.RSS)
+	}
+	if len(p.Cmdline) == 0 || p.Cmdline[0] != os.Args[0] {
+		t.Errorf("unexpected cmdline %q", p.Cmdline)
+	}
+	if !strings.HasPrefix(p.Command(), os.Args[0]) {
+		t.Errorf("unexpected command %q", p.Command())
+	}
+}
+
+func TestReadProcessGone(t *testing.T) {
+	// Above the max pid_max (2^22).
+	_, err := procfs.ReadProcess(1 << 23)
+	if !procfs.IsNotExist(err) {
+		t.Fatalf("expected not exist error, got %v", err)
+	}
+}
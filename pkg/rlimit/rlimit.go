@@ -0,0 +1,160 @@
+// Package rlimit parses and merges the container process resource
+// limits, see setrlimit(2).
+package rlimit
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Infinity is RLIM_INFINITY.
+const Infinity = math.MaxUint64
+
+// The resources supported by Linux.
+var types = map[string]bool{
+	"RLIMIT_AS":         true,
+	"RLIMIT_CORE":       true,
+	"RLIMIT_CPU":        true,
+	"RLIMIT_DATA":       true,
+	"RLIMIT_FSIZE":      true,
+	"RLIMIT_LOCKS":      true,
+	"RLIMIT_MEMLOCK":    true,
+	"RLIMIT_MSGQUEUE":   true,
+	"RLIMIT_NICE":       true,
+	"RLIMIT_NOFILE":     true,
+	"RLIMIT_NPROC":      true,
+	"RLIMIT_RSS":        true,
+	"RLIMIT_RTPRIO":     true,
+	"RLIMIT_RTTIME":     true,
+	"RLIMIT_SIGPENDING": true,
+	"RLIMIT_STACK":      true,
+}
+
+type Rlimit struct {
+	// RLIMIT_NOFILE, RLIMIT_NPROC, etc.
+	Type string `json:"type"`
+	Soft uint64 `json:"soft"`
+	Hard uint64 `json:"hard"`
+}
+
+// NormalizeType turns nofile, NOFILE, and RLIMIT_NOFILE into RLIMIT_NOFILE.
+func NormalizeType(typ string) (string, error) {
+	norm := strings.ToUpper(typ)
+	if !strings.HasPrefix(norm, "RLIMIT_") {
+		norm = "RLIMIT_" + norm
+	}
+	if !types[norm] {
+		return "", errors.Errorf("unknown rlimit %q", typ)
+	}
+	return norm, nil
+}
+
+func (r Rlimit) Validate() error {
+	if !types[r.Type] {
+		return errors.Errorf("unknown rlimit %q", r.Type)
+	}
+	if r.Soft > r.Hard {
+		return errors.Errorf("%s soft limit exceeds the hard limit", r.Type)
+	}
+	return nil
+}
+
+// Parse parses <type>=<soft>[:<hard>], e.g. nofile=1024:65536. The
+// hard limit defaults to the soft one. The limits are numbers or
+// unlimited (-1).
+func Parse(s string) (Rlimit, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return Rlimit{}, errors.Errorf("bad rlimit %q, expected <type>=<soft>[:<hard>]", s)
+	}
+	typ, err := NormalizeType(parts[0])
+	if err != nil {
+		return Rlimit{}, err
+	}
+
+	values := strings.SplitN(parts[1], ":", 2)
+	soft, err := parseValue(values[0])
+	if err != nil {
+		return Rlimit{}, errors.Wrapf(err, "bad rlimit %q", s)
+	}
+	hard := soft
+	if len(values) == 2 {
+		if hard, err = parseValue(values[1]); err != nil {
+			return Rlimit{}, errors.Wrapf(err, "bad rlimit %q", s)
+		}
+	}
+
+	r := Rlimit{Type: typ, Soft: soft, Hard: hard}
+	return r, r.Validate()
+}
+
+func parseValue(s string) (uint64, error) {
+	if s == "unlimited" || s == "-1" {
+		return Infinity, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// ParseList parses the rlimits. The types must be unique.
+func ParseList(list []string) ([]Rlimit, error) {
+	var rlimits []Rlimit
+	for _, s := range list {
+		r, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		rlimits = append(rlimits, r)
+	}
+	return rlimits, validateUnique(rlimits)
+}
+
+// Merge overrides the default limits with the same types.
+// The result is sorted by type.
+func Merge(defaults, overrides []Rlimit) ([]Rlimit, error) {
+	if err := validateUnique(overrides); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]Rlimit)
+	for _, r := range append(append([]Rlimit(nil), defaults...), overrides...) {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		merged[r.Type] = r
+	}
+
+	var rv []Rlimit
+	for _, r := range merged {
+		rv = append(rv, r)
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Type < rv[j].Type })
+	return rv, nil
+}
+
+func validateUnique(rlimits []Rlimit) error {
+	seen := make(map[string]bool)
+	for _, r := range rlimits {
+		if seen[r.Type] {
+			return errors.Errorf("duplicate rlimit %s", r.Type)
+		}
+		seen[r.Type] = true
+	}
+	return nil
+}
+
+// String formats the limit the way Parse expects it.
+func (r Rlimit) String() string {
+	return strings.ToLower(strings.TrimPrefix(r.Type, "RLIMIT_")) +
+		"=" + formatValue(r.Soft) + ":" + formatValue(r.Hard)
+}
+
+func formatValue(v uint64) string {
+	if v == Infinity {
+		return "unlimited"
+	}
+	return strconv.FormatUint(v, 10)
+}
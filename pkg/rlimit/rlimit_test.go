@@ -0,0 +1,74 @@
+package rlimit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/rlimit"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected rlimit.Rlimit
+		valid    bool
+	}{
+		{"nofile=1024:65536", rlimit.Rlimit{Type: "RLIMIT_NOFILE", Soft: 1024, Hard: 65536}, true},
+		{"NPROC=100", rlimit.Rlimit{Type: "RLIMIT_NPROC", Soft: 100, Hard: 100}, true},
+		{"RLIMIT_CORE=0:unlimited", rlimit.Rlimit{Type: "RLIMIT_CORE", Soft: 0, Hard: rlimit.Infinity}, true},
+		{"memlock=-1", rlimit.Rlimit{Type: "RLIMIT_MEMLOCK", Soft: rlimit.Infinity, Hard: rlimit.Infinity}, true},
+		{"nofile=65536:1024", rlimit.Rlimit{}, false},
+		{"nofile", rlimit.Rlimit{}, false},
+		{"nofile=", rlimit.Rlimit{}, false},
+		{"nofile=1k", rlimit.Rlimit{}, false},
+		{"nofile=1:2:3", rlimit.Rlimit{}, false},
+		{"files=1024", rlimit.Rlimit{}, false},
+	}
+
+	for _, c := range cases {
+		r, err := rlimit.Parse(c.in)
+		if c.valid != (err == nil) {
+			t.Errorf("%s: unexpected error %v", c.in, err)
+			continue
+		}
+		if c.valid && r != c.expected {
+			t.Errorf("%s: expected %+v, got %+v", c.in, c.expected, r)
+		}
+		if c.valid {
+			if back, err := rlimit.Parse(r.String()); err != nil || back != r {
+				t.Errorf("%s: String() round trip failed: %s", c.in, r)
+			}
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	defaults, err := rlimit.ParseList([]string{"nofile=1024", "nproc=4096"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := rlimit.ParseList([]string{"nofile=65536", "core=0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := rlimit.Merge(defaults, overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []rlimit.Rlimit{
+		{Type: "RLIMIT_CORE", Soft: 0, Hard: 0},
+		{Type: "RLIMIT_NOFILE", Soft: 65536, Hard: 65536},
+		{Type: "RLIMIT_NPROC", Soft: 4096, Hard: 4096},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("unexpected rlimits %+v", merged)
+	}
+
+	if _, err := rlimit.ParseList([]string{"nofile=1024", "NOFILE=2048"}); err == nil {
+		t.Error("duplicate rlimits are expected to fail")
+	}
+	if _, err := rlimit.Merge(nil, []rlimit.Rlimit{{Type: "RLIMIT_BOGUS"}}); err == nil {
+		t.Error("unknown rlimit is expected to fail")
+	}
+}
@@ -0,0 +1,78 @@
+//go:build ignore
+// +build ignore
+
+// mksyscalls generates the table of syscall names known to an
+// architecture from the golang.org/x/sys/unix zsysnum file, e.g.:
+//
+//	go run mksyscalls.go amd64 $(go env GOMODCACHE)/golang.org/x/sys@<version>/unix/zsysnum_linux_amd64.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var sysnumRegexp = regexp.MustCompile(`^\s*SYS_(\w+)\s*=\s*\d+`)
+
+// x/sys names differing from the kernel (and libseccomp) ones.
+var aliases = map[string]string{
+	"fstatat": "newfstatat",
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: mksyscalls <goarch> <zsysnum file>")
+		os.Exit(1)
+	}
+	goarch, file := os.Args[1], os.Args[2]
+
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := sysnumRegexp.FindStringSubmatch(scanner.Text()); m != nil {
+			name := strings.ToLower(m[1])
+			if alias, ok := aliases[name]; ok {
+				name = alias
+			}
+			names = append(names, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mksyscalls.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package seccomp\n\n")
+	fmt.Fprintf(&buf, "var hostSyscalls = map[string]bool{\n")
+	for _, n := range names {
+		fmt.Fprintf(&buf, "\t%q: true,\n", n)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(fmt.Sprintf("syscalls_%s.go", goarch), src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
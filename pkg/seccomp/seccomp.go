@@ -0,0 +1,233 @@
+// Package seccomp provides the default seccomp profile and loads
+// the custom ones, checking them against the host architecture.
+package seccomp
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// Profile names, same as the CRI ones.
+const (
+	ProfileUnconfined     = "unconfined"
+	ProfileRuntimeDefault = "runtime/default"
+	// Followed by an absolute path to a JSON profile
+	// (the linux.seccomp section of the OCI runtime spec).
+	ProfileLocalhostPrefix = "localhost/"
+)
+
+// Derived from the Docker default profile: everything not allowed
+// explicitly fails with EPERM. The syscalls that need capabilities
+// (mount, ptrace, bpf, etc.) are allowed only if the container has
+// the capability (see capSyscalls).
+//
+//go:embed default.json
+var defaultProfile []byte
+
+// The default profile additions for the container capabilities.
+var capSyscalls = map[string][]string{
+	"CAP_DAC_READ_SEARCH": {"open_by_handle_at"},
+	"CAP_SYS_ADMIN": {
+		"bpf", "clone", "clone3", "fanotify_init", "fsconfig", "fsmount",
+		"fsopen", "fspick", "lookup_dcookie", "mount", "mount_setattr",
+		"move_mount", "open_tree", "perf_event_open", "quotactl", "quotactl_fd",
+		"setdomainname", "sethostname", "setns", "syslog", "umount", "umount2",
+		"unshare",
+	},
+	"CAP_SYS_BOOT":       {"reboot"},
+	"CAP_SYS_CHROOT":     {"chroot"},
+	"CAP_SYS_MODULE":     {"delete_module", "init_module", "finit_module"},
+	"CAP_SYS_PACCT":      {"acct"},
+	"CAP_SYS_PTRACE":     {"kcmp", "pidfd_getfd", "process_madvise", "process_vm_readv", "process_vm_writev", "ptrace"},
+	"CAP_SYS_RAWIO":      {"iopl", "ioperm"},
+	"CAP_SYS_TIME":       {"settimeofday", "stime", "clock_settime", "clock_settime64"},
+	"CAP_SYS_TTY_CONFIG": {"vhangup"},
+	"CAP_SYS_NICE":       {"get_mempolicy", "mbind", "set_mempolicy", "set_mempolicy_home_node"},
+	"CAP_SYSLOG":         {"syslog"},
+	"CAP_BPF":            {"bpf"},
+	"CAP_PERFMON":        {"perf_event_open"},
+}
+
+// DefaultProfile returns the runtime/default profile for a container
+// with the given capabilities. The syscalls unknown to the host
+// architecture are dropped from it.
+func DefaultProfile(caps []string) *rspec.LinuxSeccomp {
+	p, err := parseProfile(defaultProfile)
+	if err != nil {
+		panic(errors.Wrap(err, "bad default seccomp profile"))
+	}
+
+	allowed := make(map[string]bool)
+	for _, c := range caps {
+		for _, name := range capSyscalls[c] {
+			allowed[name] = true
+		}
+	}
+	if len(allowed) > 0 {
+		var names []string
+		for name := range allowed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		p.Syscalls = append(p.Syscalls, rspec.LinuxSyscall{
+			Names:  names,
+			Action: rspec.ActAllow,
+		})
+	}
+
+	syscalls := p.Syscalls[:0]
+	for _, s := range p.Syscalls {
+		names := s.Names[:0]
+		for _, name := range s.Names {
+			// The unconditional rule takes over (e.g. clone
+			// and clone3 for the CAP_SYS_ADMIN containers).
+			if s.Action != rspec.ActAllow || len(s.Args) > 0 {
+				if allowed[name] {
+					continue
+				}
+			}
+			if isHostSyscall(name) {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			s.Names = names
+			syscalls = append(syscalls, s)
+		}
+	}
+	p.Syscalls = syscalls
+	return p
+}
+
+// IsValidProfileName checks the profile name syntax. An empty
+// name stands for the runtime/default profile.
+func IsValidProfileName(name string) bool {
+	switch name {
+	case "", ProfileUnconfined, ProfileRuntimeDefault:
+		return true
+	}
+	return strings.HasPrefix(name, ProfileLocalhostPrefix) &&
+		filepath.IsAbs(strings.TrimPrefix(name, ProfileLocalhostPrefix))
+}
+
+// ResolveProfile returns the profile by its name. A nil profile
+// (and no error) is returned for unconfined. The capabilities
+// extend the runtime/default profile.
+func ResolveProfile(name string, caps []string) (*rspec.LinuxSeccomp, error) {
+	if !IsValidProfileName(name) {
+		return nil, errors.Errorf(
+			"bad seccomp profile %q, expected %s, %s, or %s<absolute path>",
+			name, ProfileUnconfined, ProfileRuntimeDefault, ProfileLocalhostPrefix,
+		)
+	}
+
+	switch name {
+	case ProfileUnconfined:
+		return nil, nil
+	case "", ProfileRuntimeDefault:
+		return DefaultProfile(caps), nil
+	}
+	return LoadProfile(strings.TrimPrefix(name, ProfileLocalhostPrefix))
+}
+
+// LoadProfile reads and validates a JSON profile.
+func LoadProfile(path string) (*rspec.LinuxSeccomp, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read seccomp profile")
+	}
+
+	p, err := parseProfile(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse seccomp profile %s", path)
+	}
+	if err := Validate(p); err != nil {
+		return nil, errors.Wrapf(err, "invalid seccomp profile %s", path)
+	}
+	return p, nil
+}
+
+func parseProfile(data []byte) (*rspec.LinuxSeccomp, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var p rspec.LinuxSeccomp
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+var (
+	actions = map[rspec.LinuxSeccompAction]bool{
+		rspec.ActKill:        true,
+		rspec.ActKillProcess: true,
+		rspec.ActKillThread:  true,
+		rspec.ActTrap:        true,
+		rspec.ActErrno:       true,
+		rspec.ActTrace:       true,
+		rspec.ActAllow:       true,
+		rspec.ActLog:         true,
+		rspec.ActNotify:      true,
+	}
+
+	operators = map[rspec.LinuxSeccompOperator]bool{
+		rspec.OpNotEqual:     true,
+		rspec.OpLessThan:     true,
+		rspec.OpLessEqual:    true,
+		rspec.OpEqualTo:      true,
+		rspec.OpGreaterEqual: true,
+		rspec.OpGreaterThan:  true,
+		rspec.OpMaskedEqual:  true,
+	}
+)
+
+// Syscalls have at most 6 arguments.
+const maxArgs = 6
+
+// Validate checks the profile actions and operators, and that
+// every syscall is known to the host architecture.
+func Validate(p *rspec.LinuxSeccomp) error {
+	if !actions[p.DefaultAction] {
+		return errors.Errorf("unknown default action %q", p.DefaultAction)
+	}
+
+	for _, s := range p.Syscalls {
+		if len(s.Names) == 0 {
+			return errors.New("syscall rule without names")
+		}
+		if !actions[s.Action] {
+			return errors.Errorf("unknown action %q for %s", s.Action, s.Names[0])
+		}
+		if s.Action == rspec.ActNotify && p.ListenerPath == "" {
+			return errors.Errorf("action %s for %s requires listenerPath", s.Action, s.Names[0])
+		}
+		for _, a := range s.Args {
+			if a.Index >= maxArgs {
+				return errors.Errorf("argument index %d of %s is out of range", a.Index, s.Names[0])
+			}
+			if !operators[a.Op] {
+				return errors.Errorf("unknown operator %q for %s", a.Op, s.Names[0])
+			}
+		}
+		for _, name := range s.Names {
+			if !isHostSyscall(name) {
+				return errors.Errorf("syscall %q is unknown to the host architecture (%s)", name, runtime.GOARCH)
+			}
+		}
+	}
+	return nil
+}
+
+func isHostSyscall(name string) bool {
+	return hostSyscalls == nil || hostSyscalls[name]
+}
@@ -0,0 +1,167 @@
+package seccomp_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/iximiuz/conman/pkg/seccomp"
+)
+
+func TestDefaultProfile(t *testing.T) {
+	p := seccomp.DefaultProfile(nil)
+	if p.DefaultAction != rspec.ActErrno {
+		t.Errorf("unexpected default action %q", p.DefaultAction)
+	}
+	if err := seccomp.Validate(p); err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := allowedSyscalls(p)
+	for _, name := range []string{"read", "write", "execve", "exit_group"} {
+		if !allowed[name] {
+			t.Errorf("syscall %s is not allowed", name)
+		}
+	}
+	for _, name := range []string{"mount", "ptrace", "kexec_load", "reboot", "bpf"} {
+		if allowed[name] {
+			t.Errorf("syscall %s is allowed", name)
+		}
+	}
+}
+
+func TestDefaultProfileCapabilities(t *testing.T) {
+	p := seccomp.DefaultProfile([]string{"CAP_SYS_ADMIN", "CAP_SYS_PTRACE"})
+	if err := seccomp.Validate(p); err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := allowedSyscalls(p)
+	for _, name := range []string{"read", "mount", "unshare", "clone", "clone3", "ptrace"} {
+		if !allowed[name] {
+			t.Errorf("syscall %s is not allowed", name)
+		}
+	}
+	if allowed["reboot"] {
+		t.Error("syscall reboot is allowed")
+	}
+
+	// No conflicting rules for the same syscall.
+	for _, s := range p.Syscalls {
+		for _, name := range s.Names {
+			if (name == "clone" || name == "clone3") && (s.Action != rspec.ActAllow || len(s.Args) > 0) {
+				t.Errorf("unexpected rule %+v", s)
+			}
+		}
+	}
+}
+
+// allowedSyscalls returns the unconditionally allowed syscalls.
+func allowedSyscalls(p *rspec.LinuxSeccomp) map[string]bool {
+	allowed := make(map[string]bool)
+	for _, s := range p.Syscalls {
+		if s.Action == rspec.ActAllow && len(s.Args) == 0 {
+			for _, name := range s.Names {
+				allowed[name] = true
+			}
+		}
+	}
+	return allowed
+}
+
+func TestResolveProfile(t *testing.T) {
+	p, err := seccomp.ResolveProfile(seccomp.ProfileUnconfined, nil)
+	if err != nil || p != nil {
+		t.Errorf("unexpected unconfined profile %v, error %v", p, err)
+	}
+
+	for _, name := range []string{"", seccomp.ProfileRuntimeDefault} {
+		p, err := seccomp.ResolveProfile(name, nil)
+		if err != nil || p == nil {
+			t.Errorf("unexpected %q profile %v, error %v", name, p, err)
+		}
+	}
+
+	for _, name := range []string{"docker/default", "localhost/", "localhost/profile.json"} {
+		if _, err := seccomp.ResolveProfile(name, nil); err == nil {
+			t.Errorf("expected error for profile %q", name)
+		}
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	cases := []struct {
+		name    string
+		profile string
+		err     string
+	}{
+		{
+			name: "valid",
+			profile: `{
+				"defaultAction": "SCMP_ACT_ALLOW",
+				"syscalls": [{
+					"names": ["mkdir", "mkdirat"],
+					"action": "SCMP_ACT_ERRNO",
+					"errnoRet": 13
+				}, {
+					"names": ["personality"],
+					"action": "SCMP_ACT_ALLOW",
+					"args": [{"index": 0, "value": 8, "op": "SCMP_CMP_EQ"}]
+				}]
+			}`,
+		},
+		{
+			name:    "unknown syscall",
+			profile: `{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["mkdir", "nosuchcall"], "action": "SCMP_ACT_ERRNO"}]}`,
+			err:     `syscall "nosuchcall" is unknown to the host architecture`,
+		},
+		{
+			name:    "unknown action",
+			profile: `{"defaultAction": "SCMP_ACT_DENY"}`,
+			err:     `unknown default action "SCMP_ACT_DENY"`,
+		},
+		{
+			name:    "unknown operator",
+			profile: `{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["mkdir"], "action": "SCMP_ACT_ERRNO", "args": [{"index": 0, "value": 0, "op": "SCMP_CMP_LIKE"}]}]}`,
+			err:     `unknown operator "SCMP_CMP_LIKE" for mkdir`,
+		},
+		{
+			name:    "bad argument index",
+			profile: `{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["mkdir"], "action": "SCMP_ACT_ERRNO", "args": [{"index": 6, "value": 0, "op": "SCMP_CMP_EQ"}]}]}`,
+			err:     "argument index 6 of mkdir is out of range",
+		},
+		{
+			name:    "unknown field",
+			profile: `{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"name": "mkdir", "action": "SCMP_ACT_ERRNO"}]}`,
+			err:     `unknown field "name"`,
+		},
+	}
+
+	dir := t.TempDir()
+	for i, c := range cases {
+		path := filepath.Join(dir, strings.ReplaceAll(c.name, " ", "_")+".json")
+		if err := ioutil.WriteFile(path, []byte(c.profile), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := seccomp.ResolveProfile(seccomp.ProfileLocalhostPrefix+path, nil)
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("case %d (%s): unexpected error %v", i, c.name, err)
+			} else if len(p.Syscalls) != 2 || *p.Syscalls[0].ErrnoRet != 13 {
+				t.Errorf("case %d (%s): unexpected profile %+v", i, c.name, p)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("case %d (%s): expected error %q, got %v", i, c.name, c.err, err)
+		}
+	}
+
+	if _, err := seccomp.LoadProfile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing profile")
+	}
+}
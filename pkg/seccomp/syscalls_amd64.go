@@ -0,0 +1,360 @@
+// Code generated by mksyscalls.go; DO NOT EDIT.
+
+package seccomp
+
+var hostSyscalls = map[string]bool{
+	"_sysctl":                true,
+	"accept":                 true,
+	"accept4":                true,
+	"access":                 true,
+	"acct":                   true,
+	"add_key":                true,
+	"adjtimex":               true,
+	"afs_syscall":            true,
+	"alarm":                  true,
+	"arch_prctl":             true,
+	"bind":                   true,
+	"bpf":                    true,
+	"brk":                    true,
+	"capget":                 true,
+	"capset":                 true,
+	"chdir":                  true,
+	"chmod":                  true,
+	"chown":                  true,
+	"chroot":                 true,
+	"clock_adjtime":          true,
+	"clock_getres":           true,
+	"clock_gettime":          true,
+	"clock_nanosleep":        true,
+	"clock_settime":          true,
+	"clone":                  true,
+	"clone3":                 true,
+	"close":                  true,
+	"close_range":            true,
+	"connect":                true,
+	"copy_file_range":        true,
+	"creat":                  true,
+	"create_module":          true,
+	"delete_module":          true,
+	"dup":                    true,
+	"dup2":                   true,
+	"dup3":                   true,
+	"epoll_create":           true,
+	"epoll_create1":          true,
+	"epoll_ctl":              true,
+	"epoll_ctl_old":          true,
+	"epoll_pwait":            true,
+	"epoll_pwait2":           true,
+	"epoll_wait":             true,
+	"epoll_wait_old":         true,
+	"eventfd":                true,
+	"eventfd2":               true,
+	"execve":                 true,
+	"execveat":               true,
+	"exit":                   true,
+	"exit_group":             true,
+	"faccessat":              true,
+	"faccessat2":             true,
+	"fadvise64":              true,
+	"fallocate":              true,
+	"fanotify_init":          true,
+	"fanotify_mark":          true,
+	"fchdir":                 true,
+	"fchmod":                 true,
+	"fchmodat":               true,
+	"fchown":                 true,
+	"fchownat":               true,
+	"fcntl":                  true,
+	"fdatasync":              true,
+	"fgetxattr":              true,
+	"finit_module":           true,
+	"flistxattr":             true,
+	"flock":                  true,
+	"fork":                   true,
+	"fremovexattr":           true,
+	"fsconfig":               true,
+	"fsetxattr":              true,
+	"fsmount":                true,
+	"fsopen":                 true,
+	"fspick":                 true,
+	"fstat":                  true,
+	"fstatfs":                true,
+	"fsync":                  true,
+	"ftruncate":              true,
+	"futex":                  true,
+	"futimesat":              true,
+	"get_kernel_syms":        true,
+	"get_mempolicy":          true,
+	"get_robust_list":        true,
+	"get_thread_area":        true,
+	"getcpu":                 true,
+	"getcwd":                 true,
+	"getdents":               true,
+	"getdents64":             true,
+	"getegid":                true,
+	"geteuid":                true,
+	"getgid":                 true,
+	"getgroups":              true,
+	"getitimer":              true,
+	"getpeername":            true,
+	"getpgid":                true,
+	"getpgrp":                true,
+	"getpid":                 true,
+	"getpmsg":                true,
+	"getppid":                true,
+	"getpriority":            true,
+	"getrandom":              true,
+	"getresgid":              true,
+	"getresuid":              true,
+	"getrlimit":              true,
+	"getrusage":              true,
+	"getsid":                 true,
+	"getsockname":            true,
+	"getsockopt":             true,
+	"gettid":                 true,
+	"gettimeofday":           true,
+	"getuid":                 true,
+	"getxattr":               true,
+	"init_module":            true,
+	"inotify_add_watch":      true,
+	"inotify_init":           true,
+	"inotify_init1":          true,
+	"inotify_rm_watch":       true,
+	"io_cancel":              true,
+	"io_destroy":             true,
+	"io_getevents":           true,
+	"io_pgetevents":          true,
+	"io_setup":               true,
+	"io_submit":              true,
+	"io_uring_enter":         true,
+	"io_uring_register":      true,
+	"io_uring_setup":         true,
+	"ioctl":                  true,
+	"ioperm":                 true,
+	"iopl":                   true,
+	"ioprio_get":             true,
+	"ioprio_set":             true,
+	"kcmp":                   true,
+	"kexec_file_load":        true,
+	"kexec_load":             true,
+	"keyctl":                 true,
+	"kill":                   true,
+	"lchown":                 true,
+	"lgetxattr":              true,
+	"link":                   true,
+	"linkat":                 true,
+	"listen":                 true,
+	"listxattr":              true,
+	"llistxattr":             true,
+	"lookup_dcookie":         true,
+	"lremovexattr":           true,
+	"lseek":                  true,
+	"lsetxattr":              true,
+	"lstat":                  true,
+	"madvise":                true,
+	"mbind":                  true,
+	"membarrier":             true,
+	"memfd_create":           true,
+	"migrate_pages":          true,
+	"mincore":                true,
+	"mkdir":                  true,
+	"mkdirat":                true,
+	"mknod":                  true,
+	"mknodat":                true,
+	"mlock":                  true,
+	"mlock2":                 true,
+	"mlockall":               true,
+	"mmap":                   true,
+	"modify_ldt":             true,
+	"mount":                  true,
+	"mount_setattr":          true,
+	"move_mount":             true,
+	"move_pages":             true,
+	"mprotect":               true,
+	"mq_getsetattr":          true,
+	"mq_notify":              true,
+	"mq_open":                true,
+	"mq_timedreceive":        true,
+	"mq_timedsend":           true,
+	"mq_unlink":              true,
+	"mremap":                 true,
+	"msgctl":                 true,
+	"msgget":                 true,
+	"msgrcv":                 true,
+	"msgsnd":                 true,
+	"msync":                  true,
+	"munlock":                true,
+	"munlockall":             true,
+	"munmap":                 true,
+	"name_to_handle_at":      true,
+	"nanosleep":              true,
+	"newfstatat":             true,
+	"nfsservctl":             true,
+	"open":                   true,
+	"open_by_handle_at":      true,
+	"open_tree":              true,
+	"openat":                 true,
+	"openat2":                true,
+	"pause":                  true,
+	"perf_event_open":        true,
+	"personality":            true,
+	"pidfd_getfd":            true,
+	"pidfd_open":             true,
+	"pidfd_send_signal":      true,
+	"pipe":                   true,
+	"pipe2":                  true,
+	"pivot_root":             true,
+	"pkey_alloc":             true,
+	"pkey_free":              true,
+	"pkey_mprotect":          true,
+	"poll":                   true,
+	"ppoll":                  true,
+	"prctl":                  true,
+	"pread64":                true,
+	"preadv":                 true,
+	"preadv2":                true,
+	"prlimit64":              true,
+	"process_madvise":        true,
+	"process_vm_readv":       true,
+	"process_vm_writev":      true,
+	"pselect6":               true,
+	"ptrace":                 true,
+	"putpmsg":                true,
+	"pwrite64":               true,
+	"pwritev":                true,
+	"pwritev2":               true,
+	"query_module":           true,
+	"quotactl":               true,
+	"read":                   true,
+	"readahead":              true,
+	"readlink":               true,
+	"readlinkat":             true,
+	"readv":                  true,
+	"reboot":                 true,
+	"recvfrom":               true,
+	"recvmmsg":               true,
+	"recvmsg":                true,
+	"remap_file_pages":       true,
+	"removexattr":            true,
+	"rename":                 true,
+	"renameat":               true,
+	"renameat2":              true,
+	"request_key":            true,
+	"restart_syscall":        true,
+	"rmdir":                  true,
+	"rseq":                   true,
+	"rt_sigaction":           true,
+	"rt_sigpending":          true,
+	"rt_sigprocmask":         true,
+	"rt_sigqueueinfo":        true,
+	"rt_sigreturn":           true,
+	"rt_sigsuspend":          true,
+	"rt_sigtimedwait":        true,
+	"rt_tgsigqueueinfo":      true,
+	"sched_get_priority_max": true,
+	"sched_get_priority_min": true,
+	"sched_getaffinity":      true,
+	"sched_getattr":          true,
+	"sched_getparam":         true,
+	"sched_getscheduler":     true,
+	"sched_rr_get_interval":  true,
+	"sched_setaffinity":      true,
+	"sched_setattr":          true,
+	"sched_setparam":         true,
+	"sched_setscheduler":     true,
+	"sched_yield":            true,
+	"seccomp":                true,
+	"security":               true,
+	"select":                 true,
+	"semctl":                 true,
+	"semget":                 true,
+	"semop":                  true,
+	"semtimedop":             true,
+	"sendfile":               true,
+	"sendmmsg":               true,
+	"sendmsg":                true,
+	"sendto":                 true,
+	"set_mempolicy":          true,
+	"set_robust_list":        true,
+	"set_thread_area":        true,
+	"set_tid_address":        true,
+	"setdomainname":          true,
+	"setfsgid":               true,
+	"setfsuid":               true,
+	"setgid":                 true,
+	"setgroups":              true,
+	"sethostname":            true,
+	"setitimer":              true,
+	"setns":                  true,
+	"setpgid":                true,
+	"setpriority":            true,
+	"setregid":               true,
+	"setresgid":              true,
+	"setresuid":              true,
+	"setreuid":               true,
+	"setrlimit":              true,
+	"setsid":                 true,
+	"setsockopt":             true,
+	"settimeofday":           true,
+	"setuid":                 true,
+	"setxattr":               true,
+	"shmat":                  true,
+	"shmctl":                 true,
+	"shmdt":                  true,
+	"shmget":                 true,
+	"shutdown":               true,
+	"sigaltstack":            true,
+	"signalfd":               true,
+	"signalfd4":              true,
+	"socket":                 true,
+	"socketpair":             true,
+	"splice":                 true,
+	"stat":                   true,
+	"statfs":                 true,
+	"statx":                  true,
+	"swapoff":                true,
+	"swapon":                 true,
+	"symlink":                true,
+	"symlinkat":              true,
+	"sync":                   true,
+	"sync_file_range":        true,
+	"syncfs":                 true,
+	"sysfs":                  true,
+	"sysinfo":                true,
+	"syslog":                 true,
+	"tee":                    true,
+	"tgkill":                 true,
+	"time":                   true,
+	"timer_create":           true,
+	"timer_delete":           true,
+	"timer_getoverrun":       true,
+	"timer_gettime":          true,
+	"timer_settime":          true,
+	"timerfd_create":         true,
+	"timerfd_gettime":        true,
+	"timerfd_settime":        true,
+	"times":                  true,
+	"tkill":                  true,
+	"truncate":               true,
+	"tuxcall":                true,
+	"umask":                  true,
+	"umount2":                true,
+	"uname":                  true,
+	"unlink":                 true,
+	"unlinkat":               true,
+	"unshare":                true,
+	"uselib":                 true,
+	"userfaultfd":            true,
+	"ustat":                  true,
+	"utime":                  true,
+	"utimensat":              true,
+	"utimes":                 true,
+	"vfork":                  true,
+	"vhangup":                true,
+	"vmsplice":               true,
+	"vserver":                true,
+	"wait4":                  true,
+	"waitid":                 true,
+	"write":                  true,
+	"writev":                 true,
+}
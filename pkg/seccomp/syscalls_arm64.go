@@ -0,0 +1,305 @@
+// Code generated by mksyscalls.go; DO NOT EDIT.
+
+package seccomp
+
+var hostSyscalls = map[string]bool{
+	"accept":                 true,
+	"accept4":                true,
+	"acct":                   true,
+	"add_key":                true,
+	"adjtimex":               true,
+	"arch_specific_syscall":  true,
+	"bind":                   true,
+	"bpf":                    true,
+	"brk":                    true,
+	"capget":                 true,
+	"capset":                 true,
+	"chdir":                  true,
+	"chroot":                 true,
+	"clock_adjtime":          true,
+	"clock_getres":           true,
+	"clock_gettime":          true,
+	"clock_nanosleep":        true,
+	"clock_settime":          true,
+	"clone":                  true,
+	"clone3":                 true,
+	"close":                  true,
+	"close_range":            true,
+	"connect":                true,
+	"copy_file_range":        true,
+	"delete_module":          true,
+	"dup":                    true,
+	"dup3":                   true,
+	"epoll_create1":          true,
+	"epoll_ctl":              true,
+	"epoll_pwait":            true,
+	"epoll_pwait2":           true,
+	"eventfd2":               true,
+	"execve":                 true,
+	"execveat":               true,
+	"exit":                   true,
+	"exit_group":             true,
+	"faccessat":              true,
+	"faccessat2":             true,
+	"fadvise64":              true,
+	"fallocate":              true,
+	"fanotify_init":          true,
+	"fanotify_mark":          true,
+	"fchdir":                 true,
+	"fchmod":                 true,
+	"fchmodat":               true,
+	"fchown":                 true,
+	"fchownat":               true,
+	"fcntl":                  true,
+	"fdatasync":              true,
+	"fgetxattr":              true,
+	"finit_module":           true,
+	"flistxattr":             true,
+	"flock":                  true,
+	"fremovexattr":           true,
+	"fsconfig":               true,
+	"fsetxattr":              true,
+	"fsmount":                true,
+	"fsopen":                 true,
+	"fspick":                 true,
+	"fstat":                  true,
+	"fstatfs":                true,
+	"fsync":                  true,
+	"ftruncate":              true,
+	"futex":                  true,
+	"get_mempolicy":          true,
+	"get_robust_list":        true,
+	"getcpu":                 true,
+	"getcwd":                 true,
+	"getdents64":             true,
+	"getegid":                true,
+	"geteuid":                true,
+	"getgid":                 true,
+	"getgroups":              true,
+	"getitimer":              true,
+	"getpeername":            true,
+	"getpgid":                true,
+	"getpid":                 true,
+	"getppid":                true,
+	"getpriority":            true,
+	"getrandom":              true,
+	"getresgid":              true,
+	"getresuid":              true,
+	"getrlimit":              true,
+	"getrusage":              true,
+	"getsid":                 true,
+	"getsockname":            true,
+	"getsockopt":             true,
+	"gettid":                 true,
+	"gettimeofday":           true,
+	"getuid":                 true,
+	"getxattr":               true,
+	"init_module":            true,
+	"inotify_add_watch":      true,
+	"inotify_init1":          true,
+	"inotify_rm_watch":       true,
+	"io_cancel":              true,
+	"io_destroy":             true,
+	"io_getevents":           true,
+	"io_pgetevents":          true,
+	"io_setup":               true,
+	"io_submit":              true,
+	"io_uring_enter":         true,
+	"io_uring_register":      true,
+	"io_uring_setup":         true,
+	"ioctl":                  true,
+	"ioprio_get":             true,
+	"ioprio_set":             true,
+	"kcmp":                   true,
+	"kexec_file_load":        true,
+	"kexec_load":             true,
+	"keyctl":                 true,
+	"kill":                   true,
+	"lgetxattr":              true,
+	"linkat":                 true,
+	"listen":                 true,
+	"listxattr":              true,
+	"llistxattr":             true,
+	"lookup_dcookie":         true,
+	"lremovexattr":           true,
+	"lseek":                  true,
+	"lsetxattr":              true,
+	"madvise":                true,
+	"mbind":                  true,
+	"membarrier":             true,
+	"memfd_create":           true,
+	"migrate_pages":          true,
+	"mincore":                true,
+	"mkdirat":                true,
+	"mknodat":                true,
+	"mlock":                  true,
+	"mlock2":                 true,
+	"mlockall":               true,
+	"mmap":                   true,
+	"mount":                  true,
+	"mount_setattr":          true,
+	"move_mount":             true,
+	"move_pages":             true,
+	"mprotect":               true,
+	"mq_getsetattr":          true,
+	"mq_notify":              true,
+	"mq_open":                true,
+	"mq_timedreceive":        true,
+	"mq_timedsend":           true,
+	"mq_unlink":              true,
+	"mremap":                 true,
+	"msgctl":                 true,
+	"msgget":                 true,
+	"msgrcv":                 true,
+	"msgsnd":                 true,
+	"msync":                  true,
+	"munlock":                true,
+	"munlockall":             true,
+	"munmap":                 true,
+	"name_to_handle_at":      true,
+	"nanosleep":              true,
+	"newfstatat":             true,
+	"nfsservctl":             true,
+	"open_by_handle_at":      true,
+	"open_tree":              true,
+	"openat":                 true,
+	"openat2":                true,
+	"perf_event_open":        true,
+	"personality":            true,
+	"pidfd_getfd":            true,
+	"pidfd_open":             true,
+	"pidfd_send_signal":      true,
+	"pipe2":                  true,
+	"pivot_root":             true,
+	"pkey_alloc":             true,
+	"pkey_free":              true,
+	"pkey_mprotect":          true,
+	"ppoll":                  true,
+	"prctl":                  true,
+	"pread64":                true,
+	"preadv":                 true,
+	"preadv2":                true,
+	"prlimit64":              true,
+	"process_madvise":        true,
+	"process_vm_readv":       true,
+	"process_vm_writev":      true,
+	"pselect6":               true,
+	"ptrace":                 true,
+	"pwrite64":               true,
+	"pwritev":                true,
+	"pwritev2":               true,
+	"quotactl":               true,
+	"read":                   true,
+	"readahead":              true,
+	"readlinkat":             true,
+	"readv":                  true,
+	"reboot":                 true,
+	"recvfrom":               true,
+	"recvmmsg":               true,
+	"recvmsg":                true,
+	"remap_file_pages":       true,
+	"removexattr":            true,
+	"renameat":               true,
+	"renameat2":              true,
+	"request_key":            true,
+	"restart_syscall":        true,
+	"rseq":                   true,
+	"rt_sigaction":           true,
+	"rt_sigpending":          true,
+	"rt_sigprocmask":         true,
+	"rt_sigqueueinfo":        true,
+	"rt_sigreturn":           true,
+	"rt_sigsuspend":          true,
+	"rt_sigtimedwait":        true,
+	"rt_tgsigqueueinfo":      true,
+	"sched_get_priority_max": true,
+	"sched_get_priority_min": true,
+	"sched_getaffinity":      true,
+	"sched_getattr":          true,
+	"sched_getparam":         true,
+	"sched_getscheduler":     true,
+	"sched_rr_get_interval":  true,
+	"sched_setaffinity":      true,
+	"sched_setattr":          true,
+	"sched_setparam":         true,
+	"sched_setscheduler":     true,
+	"sched_yield":            true,
+	"seccomp":                true,
+	"semctl":                 true,
+	"semget":                 true,
+	"semop":                  true,
+	"semtimedop":             true,
+	"sendfile":               true,
+	"sendmmsg":               true,
+	"sendmsg":                true,
+	"sendto":                 true,
+	"set_mempolicy":          true,
+	"set_robust_list":        true,
+	"set_tid_address":        true,
+	"setdomainname":          true,
+	"setfsgid":               true,
+	"setfsuid":               true,
+	"setgid":                 true,
+	"setgroups":              true,
+	"sethostname":            true,
+	"setitimer":              true,
+	"setns":                  true,
+	"setpgid":                true,
+	"setpriority":            true,
+	"setregid":               true,
+	"setresgid":              true,
+	"setresuid":              true,
+	"setreuid":               true,
+	"setrlimit":              true,
+	"setsid":                 true,
+	"setsockopt":             true,
+	"settimeofday":           true,
+	"setuid":                 true,
+	"setxattr":               true,
+	"shmat":                  true,
+	"shmctl":                 true,
+	"shmdt":                  true,
+	"shmget":                 true,
+	"shutdown":               true,
+	"sigaltstack":            true,
+	"signalfd4":              true,
+	"socket":                 true,
+	"socketpair":             true,
+	"splice":                 true,
+	"statfs":                 true,
+	"statx":                  true,
+	"swapoff":                true,
+	"swapon":                 true,
+	"symlinkat":              true,
+	"sync":                   true,
+	"sync_file_range":        true,
+	"syncfs":                 true,
+	"sysinfo":                true,
+	"syslog":                 true,
+	"tee":                    true,
+	"tgkill":                 true,
+	"timer_create":           true,
+	"timer_delete":           true,
+	"timer_getoverrun":       true,
+	"timer_gettime":          true,
+	"timer_settime":          true,
+	"timerfd_create":         true,
+	"timerfd_gettime":        true,
+	"timerfd_settime":        true,
+	"times":                  true,
+	"tkill":                  true,
+	"truncate":               true,
+	"umask":                  true,
+	"umount2":                true,
+	"uname":                  true,
+	"unlinkat":               true,
+	"unshare":                true,
+	"userfaultfd":            true,
+	"utimensat":              true,
+	"vhangup":                true,
+	"vmsplice":               true,
+	"wait4":                  true,
+	"waitid":                 true,
+	"write":                  true,
+	"writev":                 true,
+}
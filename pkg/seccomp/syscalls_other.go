@@ -0,0 +1,8 @@
+//go:build !amd64 && !arm64
+// +build !amd64,!arm64
+
+package seccomp
+
+// No syscall table for the architecture, profiles aren't
+// checked against the host syscalls.
+var hostSyscalls map[string]bool
@@ -0,0 +1,36 @@
+package seccomp
+
+// Syscalls newer than the golang.org/x/sys version the tables are
+// generated from. Since 5.1 (io_uring) the new syscalls get the same
+// name (and number) on all the architectures.
+var recentSyscalls = []string{
+	"quotactl_fd",
+	"landlock_create_ruleset",
+	"landlock_add_rule",
+	"landlock_restrict_self",
+	"memfd_secret",
+	"process_mrelease",
+	"futex_waitv",
+	"set_mempolicy_home_node",
+	"cachestat",
+	"fchmodat2",
+	"map_shadow_stack",
+	"futex_wake",
+	"futex_wait",
+	"futex_requeue",
+	"statmount",
+	"listmount",
+	"lsm_get_self_attr",
+	"lsm_set_self_attr",
+	"lsm_list_modules",
+	"mseal",
+}
+
+func init() {
+	if hostSyscalls == nil {
+		return
+	}
+	for _, name := range recentSyscalls {
+		hostSyscalls[name] = true
+	}
+}
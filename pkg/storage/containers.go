@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path"
 
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
@@ -36,6 +38,9 @@ type ContainerStore interface {
 
 	GetContainer(container.ID) (*ContainerHandle, error)
 
+	// ContainerSpec reads the OCI runtime spec from the container bundle.
+	ContainerSpec(container.ID) (*rspec.Spec, error)
+
 	// Removes <container_dir>.
 	DeleteContainer(container.ID) error
 
@@ -123,6 +128,27 @@ func (s *containerStore) GetContainer(
 	return nil, nil
 }
 
+func (s *containerStore) ContainerSpec(id container.ID) (*rspec.Spec, error) {
+	h, err := s.GetContainer(id)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, errors.New("container directory not found")
+	}
+
+	data, err := ioutil.ReadFile(h.RuntimeSpecFile())
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read OCI runtime spec file")
+	}
+
+	var spec rspec.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, errors.Wrap(err, "can't parse OCI runtime spec file")
+	}
+	return &spec, nil
+}
+
 func (s *containerStore) DeleteContainer(id container.ID) error {
 	return errors.Wrap(os.RemoveAll(s.containerDir(id)),
 		"can't remove container directory")
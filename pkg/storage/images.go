@@ -0,0 +1,408 @@
+package storage
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/fsutil"
+)
+
+var ErrImageNotFound = errors.New("image not found")
+
+// Image is a locally stored image. Its ID is the hex part
+// of the image config digest.
+type Image struct {
+	ID        string        `json:"id"`
+	Names     []string      `json:"names,omitempty"`
+	Manifest  digest.Digest `json:"manifest"`
+	CreatedAt time.Time     `json:"createdAt"`
+	// Total size of the layers.
+	Size int64 `json:"size"`
+}
+
+// ImageStore keeps the image blobs (layers, configs, and manifests)
+// in a content-addressable manner. Every image also has an unpacked
+// (flattened) rootfs, so containers can be created from it right away.
+type ImageStore interface {
+	RootDir() string
+
+	// PutBlob stores the content from r and returns its descriptor.
+	PutBlob(mediaType string, r io.Reader) (ispec.Descriptor, error)
+
+	// OpenBlob opens a previously stored blob for reading.
+	OpenBlob(digest.Digest) (io.ReadCloser, error)
+
+	// CreateImage registers a new image. The layers must be already
+	// stored via PutBlob(). The populate callback fills the image
+	// rootfs directory in. The names (name:tag) are moved from the
+	// images having them, if any.
+	CreateImage(
+		config ispec.Image,
+		layers []ispec.Descriptor,
+		names []string,
+		populate func(rootfs string) error,
+	) (*Image, error)
+
+	// GetImage resolves an image reference: a name, an ID,
+	// or a unique ID prefix.
+	GetImage(ref string) (*Image, error)
+
+	ListImages() ([]*Image, error)
+
+	ImageManifest(*Image) (*ispec.Manifest, error)
+
+	ImageConfig(*Image) (*ispec.Image, error)
+
+	ImageRootfsDir(*Image) string
+}
+
+func NewImageStore(rootdir string) ImageStore {
+	return &imageStore{
+		rootdir: rootdir,
+	}
+}
+
+type imageStore struct {
+	// Serializes the image creation to keep the names unique.
+	mu      sync.Mutex
+	rootdir string
+}
+
+func (s *imageStore) RootDir() string {
+	return s.rootdir
+}
+
+func (s *imageStore) PutBlob(
+	mediaType string,
+	r io.Reader,
+) (ispec.Descriptor, error) {
+	dir := s.blobsDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return ispec.Descriptor{}, errors.Wrap(err, "can't create blobs directory")
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".writing-")
+	if err != nil {
+		return ispec.Descriptor{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(tmp, digester.Hash()), r)
+	if err != nil {
+		return ispec.Descriptor{}, errors.Wrap(err, "can't write blob")
+	}
+	if err := tmp.Close(); err != nil {
+		return ispec.Descriptor{}, err
+	}
+
+	dgst := digester.Digest()
+	if err := os.Rename(tmp.Name(), s.blobFile(dgst)); err != nil {
+		return ispec.Descriptor{}, err
+	}
+	return ispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      size,
+	}, nil
+}
+
+func (s *imageStore) OpenBlob(dgst digest.Digest) (io.ReadCloser, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+	return os.Open(s.blobFile(dgst))
+}
+
+func (s *imageStore) CreateImage(
+	config ispec.Image,
+	layers []ispec.Descriptor,
+	names []string,
+	populate func(rootfs string) error,
+) (img *Image, err error) {
+	for i, name := range names {
+		if names[i], err = NormalizeImageName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	configDesc, err := s.putJSON(ispec.MediaTypeImageConfig, config)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestDesc, err := s.putJSON(ispec.MediaTypeImageManifest, ispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    configDesc,
+		Layers:    layers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	img = &Image{
+		ID:        configDesc.Digest.Hex(),
+		Manifest:  manifestDesc.Digest,
+		CreatedAt: time.Now(),
+	}
+	for _, l := range layers {
+		img.Size += l.Size
+	}
+
+	dir := s.imageDir(img.ID)
+	if ok, err := fsutil.Exists(dir); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.Errorf("image %s already exists", img.ID)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "can't create image directory")
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	rootfs := s.ImageRootfsDir(img)
+	if err := os.Mkdir(rootfs, 0755); err != nil {
+		return nil, err
+	}
+	if err := populate(rootfs); err != nil {
+		return nil, errors.Wrap(err, "can't populate image rootfs")
+	}
+
+	if err := s.untagNoLock(names); err != nil {
+		return nil, err
+	}
+	img.Names = names
+
+	// The image becomes visible only after the metadata is written.
+	if err := s.writeImage(img); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func (s *imageStore) GetImage(ref string) (*Image, error) {
+	imgs, err := s.ListImages()
+	if err != nil {
+		return nil, err
+	}
+
+	if name, err := NormalizeImageName(ref); err == nil {
+		for _, img := range imgs {
+			for _, n := range img.Names {
+				if n == name {
+					return img, nil
+				}
+			}
+		}
+	}
+
+	ref = strings.TrimPrefix(ref, string(digest.Canonical)+":")
+	if ref == "" {
+		return nil, ErrImageNotFound
+	}
+
+	var found *Image
+	for _, img := range imgs {
+		if img.ID == ref {
+			return img, nil
+		}
+		if strings.HasPrefix(img.ID, ref) {
+			if found != nil {
+				return nil, errors.Errorf("image reference %q is ambiguous", ref)
+			}
+			found = img
+		}
+	}
+	if found == nil {
+		return nil, ErrImageNotFound
+	}
+	return found, nil
+}
+
+func (s *imageStore) ListImages() ([]*Image, error) {
+	files, err := ioutil.ReadDir(s.imagesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var imgs []*Image
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(s.imageFile(f.Name()))
+		if os.IsNotExist(err) {
+			continue // incomplete
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var img Image
+		if err := json.Unmarshal(data, &img); err != nil {
+			logrus.WithError(err).
+				Warn("image store: broken image metadata ", f.Name())
+			continue
+		}
+		imgs = append(imgs, &img)
+	}
+
+	sort.Slice(imgs, func(i, j int) bool {
+		return imgs[i].CreatedAt.Before(imgs[j].CreatedAt)
+	})
+	return imgs, nil
+}
+
+func (s *imageStore) ImageManifest(img *Image) (*ispec.Manifest, error) {
+	var manifest ispec.Manifest
+	if err := s.readJSON(img.Manifest, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func (s *imageStore) ImageConfig(img *Image) (*ispec.Image, error) {
+	manifest, err := s.ImageManifest(img)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ispec.Image
+	if err := s.readJSON(manifest.Config.Digest, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (s *imageStore) ImageRootfsDir(img *Image) string {
+	return path.Join(s.imageDir(img.ID), "rootfs")
+}
+
+// untagNoLock removes the names from the existing images.
+func (s *imageStore) untagNoLock(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	imgs, err := s.ListImages()
+	if err != nil {
+		return err
+	}
+
+	drop := make(map[string]bool)
+	for _, n := range names {
+		drop[n] = true
+	}
+
+	for _, img := range imgs {
+		var kept []string
+		for _, n := range img.Names {
+			if !drop[n] {
+				kept = append(kept, n)
+			}
+		}
+		if len(kept) != len(img.Names) {
+			img.Names = kept
+			if err := s.writeImage(img); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *imageStore) writeImage(img *Image) error {
+	data, err := json.Marshal(img)
+	if err != nil {
+		return err
+	}
+
+	file := s.imageFile(img.ID)
+	tmpfile := file + ".writing"
+	if err := ioutil.WriteFile(tmpfile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpfile, file)
+}
+
+func (s *imageStore) putJSON(mediaType string, v interface{}) (ispec.Descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ispec.Descriptor{}, err
+	}
+	return s.PutBlob(mediaType, strings.NewReader(string(data)))
+}
+
+func (s *imageStore) readJSON(dgst digest.Digest, v interface{}) error {
+	r, err := s.OpenBlob(dgst)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return json.NewDecoder(r).Decode(v)
+}
+
+func (s *imageStore) blobsDir() string {
+	return path.Join(s.rootdir, "blobs", string(digest.Canonical))
+}
+
+func (s *imageStore) blobFile(dgst digest.Digest) string {
+	return path.Join(s.blobsDir(), dgst.Hex())
+}
+
+func (s *imageStore) imagesDir() string {
+	return path.Join(s.rootdir, "images")
+}
+
+func (s *imageStore) imageDir(id string) string {
+	return path.Join(s.imagesDir(), id)
+}
+
+func (s *imageStore) imageFile(id string) string {
+	return path.Join(s.imageDir(id), "image.json")
+}
+
+var (
+	imageNameRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[._/-][a-z0-9]+)*$`)
+	imageTagRegexp  = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// NormalizeImageName validates a name:tag image name.
+// The tag defaults to latest.
+func NormalizeImageName(name string) (string, error) {
+	repo, tag := name, "latest"
+	if i := strings.LastIndexByte(name, ':'); i != -1 {
+		repo, tag = name[:i], name[i+1:]
+	}
+	if !imageNameRegexp.MatchString(repo) || !imageTagRegexp.MatchString(tag) {
+		return "", errors.Errorf("invalid image name %q", name)
+	}
+	return repo + ":" + tag, nil
+}
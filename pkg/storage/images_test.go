@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	ispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestCreateImage(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := NewImageStore(dir)
+
+	layer, err := s.PutBlob(ispec.MediaTypeImageLayer, strings.NewReader("not really a tar"))
+	if err != nil {
+		t.Fatal("PutBlob() failed", err)
+	}
+
+	config := ispec.Image{OS: "linux", Architecture: "amd64"}
+	config.Config.Cmd = []string{"/bin/sh"}
+	img, err := s.CreateImage(config, []ispec.Descriptor{layer}, []string{"foo"}, func(rootfs string) error {
+		return ioutil.WriteFile(path.Join(rootfs, "a.txt"), []byte("foo"), 0644)
+	})
+	if err != nil {
+		t.Fatal("CreateImage() failed", err)
+	}
+
+	for _, ref := range []string{"foo", "foo:latest", img.ID, img.ID[:8], "sha256:" + img.ID} {
+		found, err := s.GetImage(ref)
+		if err != nil || found.ID != img.ID {
+			t.Fatalf("GetImage(%q) failed: %v", ref, err)
+		}
+	}
+	if _, err := s.GetImage("bar"); err != ErrImageNotFound {
+		t.Fatal("GetImage() must fail with ErrImageNotFound, got", err)
+	}
+
+	actual, err := s.ImageConfig(img)
+	if err != nil || len(actual.Config.Cmd) != 1 || actual.Config.Cmd[0] != "/bin/sh" {
+		t.Fatalf("Unexpected image config %+v: %v", actual, err)
+	}
+	if _, err := os.Stat(path.Join(s.ImageRootfsDir(img), "a.txt")); err != nil {
+		t.Fatal("Image rootfs hasn't been populated", err)
+	}
+}
+
+func TestCreateImageMovesNames(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := NewImageStore(dir)
+
+	nop := func(string) error { return nil }
+	img1, err := s.CreateImage(ispec.Image{Author: "1"}, nil, []string{"foo:v1", "bar"}, nop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img2, err := s.CreateImage(ispec.Image{Author: "2"}, nil, []string{"foo:v1"}, nop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.GetImage("foo:v1")
+	if err != nil || found.ID != img2.ID {
+		t.Fatal("The name must have been moved to the new image", err)
+	}
+	found, err = s.GetImage("bar")
+	if err != nil || found.ID != img1.ID || len(found.Names) != 1 {
+		t.Fatalf("Unexpected image %+v: %v", found, err)
+	}
+}
+
+func TestNormalizeImageName(t *testing.T) {
+	cases := map[string]string{
+		"alpine":            "alpine:latest",
+		"alpine:3.14":       "alpine:3.14",
+		"iximiuz/conman:v1": "iximiuz/conman:v1",
+		"Alpine":            "",
+		"alpine:":           "",
+		"alpine:-x":         "",
+	}
+	for name, expected := range cases {
+		actual, err := NormalizeImageName(name)
+		if expected == "" && err == nil {
+			t.Errorf("NormalizeImageName(%q) must fail", name)
+		}
+		if expected != "" && actual != expected {
+			t.Errorf("NormalizeImageName(%q) = %q, expected %q (%v)", name, actual, expected, err)
+		}
+	}
+}
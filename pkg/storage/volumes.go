@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iximiuz/conman/pkg/container"
+	"github.com/iximiuz/conman/pkg/fsutil"
+)
+
+var ErrVolumeNotFound = errors.New("volume not found")
+
+// Volume is a named directory managed by conman that
+// can be mounted into containers.
+type Volume struct {
+	Name      string            `json:"name"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Set once the volume has been mounted for the first time,
+	// see SeedVolume().
+	Seeded bool `json:"seeded,omitempty"`
+}
+
+// VolumeStore keeps every volume in <rootdir>/volumes/<name>. The
+// volume metadata goes to volume.json and the content to _data.
+type VolumeStore interface {
+	RootDir() string
+
+	CreateVolume(name string, labels map[string]string) (*Volume, error)
+
+	GetVolume(name string) (*Volume, error)
+
+	ListVolumes() ([]*Volume, error)
+
+	// SeedVolume copies the content of the src directory to the volume
+	// if the volume has never been seeded and is empty. Missing src is
+	// fine. Either way, the volume is marked as seeded.
+	SeedVolume(v *Volume, src string) error
+
+	RemoveVolume(name string) error
+
+	VolumeDataDir(*Volume) string
+}
+
+func NewVolumeStore(rootdir string) VolumeStore {
+	return &volumeStore{
+		rootdir: rootdir,
+	}
+}
+
+type volumeStore struct {
+	mu      sync.Mutex
+	rootdir string
+}
+
+func (s *volumeStore) RootDir() string {
+	return s.rootdir
+}
+
+func (s *volumeStore) CreateVolume(
+	name string,
+	labels map[string]string,
+) (vol *Volume, err error) {
+	if !container.IsValidVolumeName(name) {
+		return nil, errors.Errorf("invalid volume name %q", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.volumeDir(name)
+	if ok, err := fsutil.Exists(dir); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.Errorf("volume %s already exists", name)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "can't create volume directory")
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	vol = &Volume{
+		Name:      name,
+		CreatedAt: time.Now(),
+		Labels:    labels,
+	}
+	if err := os.Mkdir(s.VolumeDataDir(vol), 0755); err != nil {
+		return nil, err
+	}
+
+	// The volume becomes visible only after the metadata is written.
+	if err := s.writeVolume(vol); err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+func (s *volumeStore) GetVolume(name string) (*Volume, error) {
+	if !container.IsValidVolumeName(name) {
+		return nil, ErrVolumeNotFound
+	}
+
+	data, err := ioutil.ReadFile(s.volumeFile(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrVolumeNotFound
+		}
+		return nil, err
+	}
+
+	var vol Volume
+	if err := json.Unmarshal(data, &vol); err != nil {
+		return nil, errors.Wrap(err, "broken volume metadata")
+	}
+	return &vol, nil
+}
+
+func (s *volumeStore) ListVolumes() ([]*Volume, error) {
+	files, err := ioutil.ReadDir(s.volumesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var vols []*Volume
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+
+		vol, err := s.GetVolume(f.Name())
+		if err == ErrVolumeNotFound {
+			continue // incomplete
+		}
+		if err != nil {
+			logrus.WithError(err).
+				Warn("volume store: can't read volume ", f.Name())
+			continue
+		}
+		vols = append(vols, vol)
+	}
+
+	sort.Slice(vols, func(i, j int) bool {
+		return vols[i].Name < vols[j].Name
+	})
+	return vols, nil
+}
+
+func (s *volumeStore) SeedVolume(vol *Volume, src string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The passed in volume might be stale.
+	cur, err := s.GetVolume(vol.Name)
+	if err != nil {
+		return err
+	}
+	if cur.Seeded {
+		return nil
+	}
+
+	data := s.VolumeDataDir(cur)
+	empty, err := isEmptyDir(data)
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(src); empty && err == nil && fi.IsDir() {
+		if err := fsutil.CopyDir(src+"/.", data); err != nil {
+			return errors.Wrap(err, "can't seed volume")
+		}
+	}
+
+	cur.Seeded = true
+	if err := s.writeVolume(cur); err != nil {
+		return err
+	}
+	vol.Seeded = true
+	return nil
+}
+
+func (s *volumeStore) RemoveVolume(name string) error {
+	if !container.IsValidVolumeName(name) {
+		return ErrVolumeNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.volumeDir(name)
+	if ok, err := fsutil.Exists(dir); !ok || err != nil {
+		if err != nil {
+			return err
+		}
+		return ErrVolumeNotFound
+	}
+
+	// The volume disappears atomically, then the leftovers are removed.
+	if err := os.Remove(s.volumeFile(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *volumeStore) VolumeDataDir(vol *Volume) string {
+	return path.Join(s.volumeDir(vol.Name), "_data")
+}
+
+func (s *volumeStore) writeVolume(vol *Volume) error {
+	data, err := json.Marshal(vol)
+	if err != nil {
+		return err
+	}
+
+	file := s.volumeFile(vol.Name)
+	tmpfile := file + ".writing"
+	if err := ioutil.WriteFile(tmpfile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpfile, file)
+}
+
+func (s *volumeStore) volumesDir() string {
+	return path.Join(s.rootdir, "volumes")
+}
+
+func (s *volumeStore) volumeDir(name string) string {
+	return path.Join(s.volumesDir(), name)
+}
+
+func (s *volumeStore) volumeFile(name string) string {
+	return path.Join(s.volumeDir(name), "volume.json")
+}
+
+func isEmptyDir(dir string) (bool, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	_, err = f.Readdirnames(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/testutil"
+)
+
+func TestVolumeLifecycle(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := NewVolumeStore(dir)
+
+	vol, err := s.CreateVolume("data", map[string]string{"app": "db"})
+	if err != nil {
+		t.Fatal("CreateVolume() failed", err)
+	}
+	if _, err := s.CreateVolume("data", nil); err == nil {
+		t.Fatal("duplicate volume created")
+	}
+	if _, err := s.CreateVolume("../data", nil); err == nil {
+		t.Fatal("volume with bad name created")
+	}
+
+	found, err := s.GetVolume("data")
+	if err != nil || found.Labels["app"] != "db" {
+		t.Fatalf("GetVolume() failed: %v %+v", err, found)
+	}
+
+	if _, err := s.CreateVolume("cache", nil); err != nil {
+		t.Fatal("CreateVolume() failed", err)
+	}
+	vols, err := s.ListVolumes()
+	if err != nil || len(vols) != 2 || vols[0].Name != "cache" || vols[1].Name != "data" {
+		t.Fatalf("ListVolumes() failed: %v %+v", err, vols)
+	}
+
+	if err := s.RemoveVolume(vol.Name); err != nil {
+		t.Fatal("RemoveVolume() failed", err)
+	}
+	if _, err := s.GetVolume("data"); err != ErrVolumeNotFound {
+		t.Fatalf("expected ErrVolumeNotFound, got %v", err)
+	}
+	if err := s.RemoveVolume(vol.Name); err != ErrVolumeNotFound {
+		t.Fatalf("expected ErrVolumeNotFound, got %v", err)
+	}
+}
+
+func TestSeedVolume(t *testing.T) {
+	dir := testutil.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := NewVolumeStore(dir)
+
+	src := path.Join(dir, "src")
+	if err := os.MkdirAll(path.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(src, "sub", "a.txt"), []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	vol, err := s.CreateVolume("data", nil)
+	if err != nil {
+		t.Fatal("CreateVolume() failed", err)
+	}
+	if err := s.SeedVolume(vol, src); err != nil {
+		t.Fatal("SeedVolume() failed", err)
+	}
+	if !vol.Seeded {
+		t.Fatal("volume is not marked seeded")
+	}
+	data, err := ioutil.ReadFile(path.Join(s.VolumeDataDir(vol), "sub", "a.txt"))
+	if err != nil || string(data) != "foo" {
+		t.Fatalf("volume is not seeded: %v %q", err, data)
+	}
+
+	// Seeding happens only once.
+	if err := os.Remove(path.Join(s.VolumeDataDir(vol), "sub", "a.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SeedVolume(vol, src); err != nil {
+		t.Fatal("SeedVolume() failed", err)
+	}
+	if _, err := os.Stat(path.Join(s.VolumeDataDir(vol), "sub", "a.txt")); !os.IsNotExist(err) {
+		t.Fatal("volume seeded twice")
+	}
+
+	// Missing source is fine.
+	vol2, err := s.CreateVolume("data2", nil)
+	if err != nil {
+		t.Fatal("CreateVolume() failed", err)
+	}
+	if err := s.SeedVolume(vol2, path.Join(dir, "missing")); err != nil {
+		t.Fatal("SeedVolume() failed", err)
+	}
+	if found, _ := s.GetVolume("data2"); !found.Seeded {
+		t.Fatal("volume is not marked seeded")
+	}
+}
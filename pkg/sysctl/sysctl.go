@@ -0,0 +1,89 @@
+// Package sysctl validates the container sysctls. Only the namespaced
+// sysctls can be set, i.e. the ones not affecting the host or the
+// other containers.
+package sysctl
+
+import (
+	"regexp"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// Safe sysctls are allowed by default. Same as the Kubernetes ones.
+var Safe = []string{
+	"kernel.shm_rmid_forced",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.ip_local_reserved_ports",
+	"net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.ping_group_range",
+	"net.ipv4.tcp_fin_timeout",
+	"net.ipv4.tcp_keepalive_intvl",
+	"net.ipv4.tcp_keepalive_probes",
+	"net.ipv4.tcp_keepalive_time",
+	"net.ipv4.tcp_syncookies",
+}
+
+// The namespaced sysctls (and prefixes, ending with a dot).
+var namespaced = []struct {
+	name string
+	ns   rspec.LinuxNamespaceType
+}{
+	{"kernel.msgmax", rspec.IPCNamespace},
+	{"kernel.msgmnb", rspec.IPCNamespace},
+	{"kernel.msgmni", rspec.IPCNamespace},
+	{"kernel.sem", rspec.IPCNamespace},
+	{"kernel.shmall", rspec.IPCNamespace},
+	{"kernel.shmmax", rspec.IPCNamespace},
+	{"kernel.shmmni", rspec.IPCNamespace},
+	{"kernel.shm_rmid_forced", rspec.IPCNamespace},
+	{"fs.mqueue.", rspec.IPCNamespace},
+	{"net.", rspec.NetworkNamespace},
+}
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-zA-Z0-9_-]+)+$`)
+
+// Namespace returns the namespace the sysctl belongs to.
+func Namespace(name string) (rspec.LinuxNamespaceType, bool) {
+	for _, n := range namespaced {
+		if name == n.name || (strings.HasSuffix(n.name, ".") && strings.HasPrefix(name, n.name)) {
+			return n.ns, true
+		}
+	}
+	return "", false
+}
+
+// IsValidPattern checks the syntax of an allowed sysctl pattern:
+// a sysctl name or a prefix followed by *, e.g. net.core.*.
+func IsValidPattern(pattern string) bool {
+	name := strings.TrimSuffix(pattern, "*")
+	if name != pattern && strings.HasSuffix(name, ".") {
+		name += "x"
+	}
+	return nameRegexp.MatchString(name)
+}
+
+// Validate checks that the sysctl is namespaced and either safe
+// or matched by one of the allowed unsafe patterns.
+func Validate(name string, allowedUnsafe []string) (rspec.LinuxNamespaceType, error) {
+	if !nameRegexp.MatchString(name) {
+		return "", errors.Errorf("bad sysctl name %q", name)
+	}
+	ns, ok := Namespace(name)
+	if !ok {
+		return "", errors.Errorf("sysctl %s is not namespaced", name)
+	}
+
+	for _, s := range Safe {
+		if name == s {
+			return ns, nil
+		}
+	}
+	for _, p := range allowedUnsafe {
+		if name == p || (strings.HasSuffix(p, "*") && strings.HasPrefix(name, strings.TrimSuffix(p, "*"))) {
+			return ns, nil
+		}
+	}
+	return "", errors.Errorf("sysctl %s is unsafe and not allowed by the daemon", name)
+}
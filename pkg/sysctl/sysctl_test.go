@@ -0,0 +1,60 @@
+package sysctl_test
+
+import (
+	"strings"
+	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/iximiuz/conman/pkg/sysctl"
+)
+
+func TestValidate(t *testing.T) {
+	unsafe := []string{"net.core.somaxconn", "kernel.msg*", "vm.*"}
+
+	cases := []struct {
+		name string
+		ns   rspec.LinuxNamespaceType
+		err  string
+	}{
+		{name: "net.ipv4.ip_local_port_range", ns: rspec.NetworkNamespace},
+		{name: "kernel.shm_rmid_forced", ns: rspec.IPCNamespace},
+		{name: "net.core.somaxconn", ns: rspec.NetworkNamespace},
+		{name: "kernel.msgmax", ns: rspec.IPCNamespace},
+		{name: "net.ipv4.ip_forward", err: "unsafe"},
+		{name: "kernel.shmmax", err: "unsafe"},
+		{name: "fs.mqueue.msg_max", err: "unsafe"},
+		// Allowed, but not namespaced.
+		{name: "vm.swappiness", err: "not namespaced"},
+		{name: "kernel.hostname", err: "not namespaced"},
+		{name: "net", err: "bad sysctl name"},
+		{name: "net.ipv4..tcp", err: "bad sysctl name"},
+		{name: "net/ipv4/tcp_syncookies", err: "bad sysctl name"},
+	}
+
+	for _, c := range cases {
+		ns, err := sysctl.Validate(c.name, unsafe)
+		if c.err == "" {
+			if err != nil || ns != c.ns {
+				t.Errorf("%s: unexpected namespace %q, error %v", c.name, ns, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected error %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestIsValidPattern(t *testing.T) {
+	for _, p := range []string{"net.core.somaxconn", "net.core.*", "kernel.shm*"} {
+		if !sysctl.IsValidPattern(p) {
+			t.Errorf("pattern %q is expected to be valid", p)
+		}
+	}
+	for _, p := range []string{"", "*", "net.*.somaxconn", "net", "net core"} {
+		if sysctl.IsValidPattern(p) {
+			t.Errorf("pattern %q is expected to be invalid", p)
+		}
+	}
+}
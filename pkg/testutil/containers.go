@@ -9,7 +9,7 @@ import (
 func NewContainer() *container.Container {
 	id := container.RandID()
 	name := "name_" + string(id[:8])
-	c, err := container.New(id, name, "")
+	c, err := container.New(id, name, "", nil, nil)
 	if err != nil {
 		log.Fatalf("Unexpected error during creation of test "+
 			"container: %v\n id=%v name=%v\n", err, id, name)
@@ -11,9 +11,20 @@ func WithTimeout(d time.Duration, fn func() error) error {
 	ctx, cancel := context.WithTimeout(context.Background(), d)
 	defer cancel()
 
+	err := WithContext(ctx, fn)
+	if err == context.DeadlineExceeded {
+		return errors.Wrap(err, "Timed out")
+	}
+	return err
+}
+
+// WithContext runs fn in a separate goroutine and waits either for fn
+// to return or for ctx to be done. In the latter case, fn keeps running
+// in the background and its result is discarded.
+func WithContext(ctx context.Context, fn func() error) error {
 	ch := make(chan error, 1)
 
-	func() {
+	go func() {
 		ch <- fn()
 	}()
 
@@ -21,6 +32,20 @@ func WithTimeout(d time.Duration, fn func() error) error {
 	case err := <-ch:
 		return err
 	case <-ctx.Done():
-		return errors.Wrap(ctx.Err(), "Timed out")
+		return ctx.Err()
+	}
+}
+
+// Sleep pauses the current goroutine for the duration d
+// or until ctx is done, whatever happens first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
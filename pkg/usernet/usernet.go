@@ -0,0 +1,153 @@
+// Package usernet connects the network namespaces of the rootless
+// containers to the host network with a user-mode network stack
+// (pasta or slirp4netns). An unprivileged user can't create veth
+// pairs, hence no bridge networking for them.
+package usernet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DriverAuto        = "auto"
+	DriverNone        = "none"
+	DriverPasta       = "pasta"
+	DriverSlirp4netns = "slirp4netns"
+)
+
+// How long the helper is given to configure the network namespace.
+const readyTimeout = 10 * time.Second
+
+// Resolve turns the auto driver into the first helper found
+// in PATH, or none if there are no helpers.
+func Resolve(driver string) (string, error) {
+	switch driver {
+	case DriverNone:
+		return DriverNone, nil
+	case DriverPasta, DriverSlirp4netns:
+		if _, err := exec.LookPath(driver); err != nil {
+			return "", errors.Wrapf(err, "network helper %s", driver)
+		}
+		return driver, nil
+	case DriverAuto, "":
+		for _, d := range []string{DriverPasta, DriverSlirp4netns} {
+			if _, err := exec.LookPath(d); err == nil {
+				return d, nil
+			}
+		}
+		return DriverNone, nil
+	}
+	return "", errors.Errorf(
+		"unknown network helper %q, expected %s, %s, %s, or %s",
+		driver, DriverAuto, DriverPasta, DriverSlirp4netns, DriverNone,
+	)
+}
+
+// Start configures the network namespace of the process pid and
+// keeps the helper running in the background. The helper PID is
+// written to pidfile, so that Stop() can find it later on.
+func Start(driver string, pid int, pidfile string) error {
+	switch driver {
+	case DriverNone:
+		return nil
+	case DriverPasta:
+		return startPasta(pid, pidfile)
+	case DriverSlirp4netns:
+		return startSlirp4netns(pid, pidfile)
+	}
+	return errors.Errorf("unknown network helper %q", driver)
+}
+
+// Stop kills the helper started by Start(). A missing pidfile
+// (or an already gone helper) isn't an error.
+func Stop(pidfile string) error {
+	data, err := ioutil.ReadFile(pidfile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return errors.Wrapf(err, "bad network helper pidfile %s", pidfile)
+	}
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return os.Remove(pidfile)
+}
+
+// pasta daemonizes itself once the namespace is configured,
+// and quits when the namespace is gone.
+func startPasta(pid int, pidfile string) error {
+	cmd := exec.Command(
+		DriverPasta,
+		"--config-net",
+		"--quiet",
+		"--pid", pidfile,
+		strconv.Itoa(pid),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "pasta failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// slirp4netns stays in the foreground, so it's started detached
+// and reports the readiness through a pipe.
+func startSlirp4netns(pid int, pidfile string) error {
+	readyr, readyw, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyr.Close()
+	defer readyw.Close()
+
+	cmd := exec.Command(
+		DriverSlirp4netns,
+		"--configure",
+		"--mtu=65520",
+		"--disable-host-loopback",
+		"--ready-fd=3",
+		strconv.Itoa(pid),
+		"tap0",
+	)
+	cmd.ExtraFiles = []*os.File{readyw}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "start slirp4netns")
+	}
+	readyw.Close()
+
+	// Reaps the helper whenever it exits.
+	go cmd.Wait()
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := readyr.Read(buf)
+		ready <- err
+	}()
+
+	select {
+	case err = <-ready:
+	case <-time.After(readyTimeout):
+		err = errors.New("timed out")
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		return errors.Wrap(err, "slirp4netns isn't ready")
+	}
+
+	return ioutil.WriteFile(pidfile, []byte(fmt.Sprintf("%d\n", cmd.Process.Pid)), 0644)
+}
@@ -0,0 +1,64 @@
+package usernet_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/iximiuz/conman/pkg/usernet"
+)
+
+func TestResolve(t *testing.T) {
+	bin := t.TempDir()
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", bin)
+
+	for _, driver := range []string{"", usernet.DriverAuto, usernet.DriverNone} {
+		if d, err := usernet.Resolve(driver); err != nil || d != usernet.DriverNone {
+			t.Errorf("unexpected %q resolution %q, error %v", driver, d, err)
+		}
+	}
+	if _, err := usernet.Resolve(usernet.DriverPasta); err == nil {
+		t.Error("expected error for missing pasta")
+	}
+	if _, err := usernet.Resolve("vpnkit"); err == nil {
+		t.Error("expected error for unknown driver")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(bin, "slirp4netns"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := usernet.Resolve(usernet.DriverAuto); err != nil || d != usernet.DriverSlirp4netns {
+		t.Errorf("unexpected auto resolution %q, error %v", d, err)
+	}
+}
+
+func TestStop(t *testing.T) {
+	pidfile := filepath.Join(t.TempDir(), "usernet.pid")
+	if err := usernet.Stop(pidfile); err != nil {
+		t.Fatal("missing pidfile", err)
+	}
+
+	cmd := exec.Command("sleep", "100")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(pidfile, []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := usernet.Stop(pidfile); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Wait()
+	if ee, ok := err.(*exec.ExitError); !ok || ee.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+		t.Errorf("unexpected helper termination %v", err)
+	}
+	if _, err := os.Stat(pidfile); !os.IsNotExist(err) {
+		t.Errorf("pidfile is still there, error %v", err)
+	}
+}
@@ -1,13 +1,20 @@
 package server
 
 import (
+	"errors"
 	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
+	"github.com/iximiuz/conman/pkg/archive"
 	"github.com/iximiuz/conman/pkg/container"
 	"github.com/iximiuz/conman/pkg/cri"
+	"github.com/iximiuz/conman/pkg/idmap"
+	"github.com/iximiuz/conman/pkg/rlimit"
+	"github.com/iximiuz/conman/pkg/storage"
 )
 
 func (s *conmanServer) Version(
@@ -28,15 +35,8 @@ func (s *conmanServer) CreateContainer(
 	defer func() { traceResponse("CreateContainer", resp, err) }()
 
 	cont, err := s.runtimeSrv.CreateContainer(
-		cri.ContainerOptions{
-			Name:           req.Name,
-			Command:        req.Command,
-			Args:           req.Args,
-			RootfsPath:     req.RootfsPath,
-			RootfsReadonly: req.RootfsReadonly,
-			Stdin:          req.Stdin,
-			StdinOnce:      req.StdinOnce,
-		},
+		ctx,
+		fromPbCreateContainerRequest(req),
 	)
 	if err == nil {
 		resp = &CreateContainerResponse{
@@ -53,15 +53,55 @@ func (s *conmanServer) StartContainer(
 	traceRequest("StartContainer", req)
 	defer func() { traceResponse("StartContainer", resp, err) }()
 
-	err = s.runtimeSrv.StartContainer(
-		container.ID(req.ContainerId),
-	)
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.runtimeSrv.StartContainer(ctx, id)
 	if err == nil {
 		resp = &StartContainerResponse{}
 	}
 	return
 }
 
+func (s *conmanServer) RunContainer(
+	ctx context.Context,
+	req *RunContainerRequest,
+) (resp *RunContainerResponse, err error) {
+	traceRequest("RunContainer", req)
+	defer func() { traceResponse("RunContainer", resp, err) }()
+
+	if req.Container == nil {
+		return nil, errors.New("container config is required")
+	}
+
+	opts := fromPbCreateContainerRequest(req.Container)
+	opts.AutoRemove = req.AutoRemove
+
+	cont, err := s.runtimeSrv.RunContainer(ctx, opts, req.Attach)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &RunContainerResponse{
+		ContainerId: string(cont.ID()),
+	}
+	if req.Attach {
+		r, err := s.streamingSrv.GetAttach(&criapi.AttachRequest{
+			ContainerId: string(cont.ID()),
+			Stdin:       req.Container.Stdin,
+			Stdout:      true,
+			Stderr:      true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.Url = r.Url
+	}
+	return resp, nil
+}
+
 func (s *conmanServer) StopContainer(
 	ctx context.Context,
 	req *StopContainerRequest,
@@ -69,8 +109,14 @@ func (s *conmanServer) StopContainer(
 	traceRequest("StopContainer", req)
 	defer func() { traceResponse("StopContainer", resp, err) }()
 
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
 	err = s.runtimeSrv.StopContainer(
-		container.ID(req.ContainerId),
+		ctx,
+		id,
 		time.Duration(req.Timeout)*time.Second,
 	)
 	if err == nil {
@@ -86,9 +132,16 @@ func (s *conmanServer) RemoveContainer(
 	traceRequest("RemoveContainer", req)
 	defer func() { traceResponse("RemoveContainer", resp, err) }()
 
-	err = s.runtimeSrv.RemoveContainer(
-		container.ID(req.ContainerId),
-	)
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err == container.ErrNotFound {
+		// Removal is idempotent.
+		return &RemoveContainerResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.runtimeSrv.RemoveContainer(ctx, id)
 	if err == nil {
 		resp = &RemoveContainerResponse{}
 	}
@@ -102,7 +155,10 @@ func (s *conmanServer) ListContainers(
 	traceRequest("ListContainers", req)
 	defer func() { traceResponse("ListContainers", resp, err) }()
 
-	cs, err := s.runtimeSrv.ListContainers()
+	cs, err := s.runtimeSrv.ListContainers(
+		ctx,
+		fromPbContainerFilter(req.Filter),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -119,27 +175,285 @@ func (s *conmanServer) ContainerStatus(
 	traceRequest("ContainerStatus", req)
 	defer func() { traceResponse("ContainerStatus", resp, err) }()
 
-	cont, err := s.runtimeSrv.GetContainer(
-		container.ID(req.ContainerId),
-	)
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	cont, err := s.runtimeSrv.GetContainer(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ContainerStatusResponse{
 		Status: &ContainerStatus{
-			ContainerId:   string(cont.ID()),
-			ContainerName: string(cont.Name()),
-			State:         toPbContainerState(cont.Status()),
-			CreatedAt:     cont.CreatedAtNano(),
-			StartedAt:     cont.StartedAtNano(),
-			FinishedAt:    cont.FinishedAtNano(),
-			ExitCode:      cont.ExitCode(),
-			LogPath:       cont.LogPath(),
+			ContainerId:     string(cont.ID()),
+			ContainerName:   string(cont.Name()),
+			State:           toPbContainerState(cont.Status()),
+			CreatedAt:       cont.CreatedAtNano(),
+			StartedAt:       cont.StartedAtNano(),
+			FinishedAt:      cont.FinishedAtNano(),
+			ExitCode:        cont.ExitCode(),
+			Message:         cont.Message(),
+			Reason:          cont.Reason(),
+			Signal:          cont.Signal(),
+			LogPath:         cont.LogPath(),
+			Labels:          cont.Labels(),
+			Annotations:     cont.Annotations(),
+			Image:           containerImage(cont),
+			Mounts:          toPbMounts(cont.Mounts()),
+			SeccompProfile:  cont.SeccompProfile(),
+			Capabilities:    cont.Capabilities(),
+			Privileged:      cont.Privileged(),
+			NoNewPrivileges: cont.NoNewPrivileges(),
+			UidMappings:     toPbIDMappings(cont.UIDMappings()),
+			GidMappings:     toPbIDMappings(cont.GIDMappings()),
+
+			NamespaceOptions: toPbNamespaceOptions(cont.Namespaces()),
+			MaskedPaths:      cont.MaskedPaths(),
+			ReadonlyPaths:    cont.ReadonlyPaths(),
+			Sysctls:          cont.Sysctls(),
+			Devices:          toPbDevices(cont.Devices()),
+			CdiDevices:       cont.CDIDevices(),
+			Rlimits:          toPbRlimits(cont.Rlimits()),
+			OomScoreAdj:      int32(cont.OOMScoreAdj()),
+
+			TerminationMessagePath: cont.TerminationMessagePath(),
 		},
 	}, nil
 }
 
+func (s *conmanServer) WaitContainer(
+	ctx context.Context,
+	req *WaitContainerRequest,
+) (resp *WaitContainerResponse, err error) {
+	traceRequest("WaitContainer", req)
+	defer func() { traceResponse("WaitContainer", resp, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := container.Stopped
+	if req.Condition != nil {
+		// UNKNOWN is never reached, the wait would block forever.
+		condition = fromPbContainerState(req.Condition.State)
+		if condition == container.Unknown {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"unsupported wait condition %s", req.Condition.State)
+		}
+	}
+
+	cont, err := s.runtimeSrv.WaitContainer(ctx, id, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WaitContainerResponse{
+		ContainerId: string(cont.ID()),
+		State:       toPbContainerState(cont.Status()),
+		ExitCode:    cont.ExitCode(),
+		Signal:      cont.Signal(),
+		FinishedAt:  cont.FinishedAtNano(),
+		Reason:      cont.Reason(),
+		Message:     cont.Message(),
+	}, nil
+}
+
+func (s *conmanServer) CopyFromContainer(
+	req *CopyFromContainerRequest,
+	stream Conman_CopyFromContainerServer,
+) (err error) {
+	traceRequest("CopyFromContainer", req)
+	defer func() { traceResponse("CopyFromContainer", nil, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return err
+	}
+
+	w := &chunkWriter{send: func(data []byte) error {
+		return stream.Send(&CopyChunk{Data: data})
+	}}
+	return s.runtimeSrv.CopyFromContainer(stream.Context(), id, req.Path, w)
+}
+
+func (s *conmanServer) CopyToContainer(
+	stream Conman_CopyToContainerServer,
+) (err error) {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	traceRequest("CopyToContainer", &CopyToContainerRequest{
+		ContainerId: req.ContainerId,
+		Path:        req.Path,
+	})
+	defer func() { traceResponse("CopyToContainer", nil, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return err
+	}
+
+	r := &chunkReader{buf: req.Data, recv: func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return req.Data, nil
+	}}
+	if err := s.runtimeSrv.CopyToContainer(stream.Context(), id, req.Path, r); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&CopyToContainerResponse{})
+}
+
+func (s *conmanServer) ContainerChanges(
+	ctx context.Context,
+	req *ContainerChangesRequest,
+) (resp *ContainerChangesResponse, err error) {
+	traceRequest("ContainerChanges", req)
+	defer func() { traceResponse("ContainerChanges", resp, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.runtimeSrv.ContainerChanges(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &ContainerChangesResponse{}
+	for _, c := range changes {
+		resp.Changes = append(resp.Changes, &ContainerChange{
+			Path: c.Path,
+			Kind: toPbChangeKind(c.Kind),
+		})
+	}
+	return resp, nil
+}
+
+func (s *conmanServer) ContainerTop(
+	ctx context.Context,
+	req *ContainerTopRequest,
+) (resp *ContainerTopResponse, err error) {
+	traceRequest("ContainerTop", req)
+	defer func() { traceResponse("ContainerTop", resp, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	procs, err := s.runtimeSrv.ContainerTop(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &ContainerTopResponse{}
+	for _, p := range procs {
+		resp.Processes = append(resp.Processes, &Process{
+			Pid:     int32(p.Pid),
+			NsPid:   int32(p.NsPid),
+			Uid:     p.UID,
+			User:    p.User,
+			CpuTime: int64(p.CPUTime),
+			Rss:     p.RSS,
+			Command: p.Command(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *conmanServer) ExportContainer(
+	req *ExportContainerRequest,
+	stream Conman_ExportContainerServer,
+) (err error) {
+	traceRequest("ExportContainer", req)
+	defer func() { traceResponse("ExportContainer", nil, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return err
+	}
+
+	w := &chunkWriter{send: func(data []byte) error {
+		return stream.Send(&CopyChunk{Data: data})
+	}}
+	return s.runtimeSrv.ExportContainer(stream.Context(), id, w)
+}
+
+func (s *conmanServer) CommitContainer(
+	ctx context.Context,
+	req *CommitContainerRequest,
+) (resp *CommitContainerResponse, err error) {
+	traceRequest("CommitContainer", req)
+	defer func() { traceResponse("CommitContainer", resp, err) }()
+
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := s.runtimeSrv.CommitContainer(ctx, id, cri.CommitOptions{
+		Name:    req.ImageName,
+		Labels:  req.Labels,
+		Author:  req.Author,
+		Message: req.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CommitContainerResponse{ImageId: img.ID}, nil
+}
+
+func (s *conmanServer) ListImages(
+	ctx context.Context,
+	req *ListImagesRequest,
+) (resp *ListImagesResponse, err error) {
+	traceRequest("ListImages", req)
+	defer func() { traceResponse("ListImages", resp, err) }()
+
+	imgs, err := s.imageSrv.ListImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListImagesResponse{
+		Images: toPbImages(imgs),
+	}, nil
+}
+
+func (s *conmanServer) ImportImage(
+	stream Conman_ImportImageServer,
+) (err error) {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	traceRequest("ImportImage", &ImportImageRequest{ImageName: req.ImageName})
+	defer func() { traceResponse("ImportImage", nil, err) }()
+
+	r := &chunkReader{buf: req.Data, recv: func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return req.Data, nil
+	}}
+	img, err := s.imageSrv.ImportImage(stream.Context(), r, req.ImageName)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&ImportImageResponse{ImageId: img.ID})
+}
+
 func (s *conmanServer) Attach(
 	ctx context.Context,
 	req *AttachRequest,
@@ -147,8 +461,13 @@ func (s *conmanServer) Attach(
 	traceRequest("Attach", req)
 	defer func() { traceResponse("Attach", resp, err) }()
 
+	id, err := s.runtimeSrv.ResolveContainerID(req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := s.streamingSrv.GetAttach(&criapi.AttachRequest{
-		ContainerId: req.ContainerId,
+		ContainerId: string(id),
 		Tty:         req.Tty,
 		Stdin:       req.Stdin,
 		Stdout:      req.Stdout,
@@ -160,6 +479,291 @@ func (s *conmanServer) Attach(
 	return &AttachResponse{Url: r.Url}, err
 }
 
+func (s *conmanServer) CreateVolume(
+	ctx context.Context,
+	req *CreateVolumeRequest,
+) (resp *CreateVolumeResponse, err error) {
+	traceRequest("CreateVolume", req)
+	defer func() { traceResponse("CreateVolume", resp, err) }()
+
+	vol, err := s.runtimeSrv.CreateVolume(ctx, req.Name, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateVolumeResponse{
+		Volume: toPbVolume(vol),
+	}, nil
+}
+
+func (s *conmanServer) ListVolumes(
+	ctx context.Context,
+	req *ListVolumesRequest,
+) (resp *ListVolumesResponse, err error) {
+	traceRequest("ListVolumes", req)
+	defer func() { traceResponse("ListVolumes", resp, err) }()
+
+	vols, err := s.runtimeSrv.ListVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &ListVolumesResponse{}
+	for _, v := range vols {
+		resp.Volumes = append(resp.Volumes, toPbVolume(v))
+	}
+	return resp, nil
+}
+
+func (s *conmanServer) InspectVolume(
+	ctx context.Context,
+	req *InspectVolumeRequest,
+) (resp *InspectVolumeResponse, err error) {
+	traceRequest("InspectVolume", req)
+	defer func() { traceResponse("InspectVolume", resp, err) }()
+
+	vol, err := s.runtimeSrv.GetVolume(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InspectVolumeResponse{
+		Volume: toPbVolume(vol),
+	}, nil
+}
+
+func (s *conmanServer) RemoveVolume(
+	ctx context.Context,
+	req *RemoveVolumeRequest,
+) (resp *RemoveVolumeResponse, err error) {
+	traceRequest("RemoveVolume", req)
+	defer func() { traceResponse("RemoveVolume", resp, err) }()
+
+	if err := s.runtimeSrv.RemoveVolume(ctx, req.Name); err != nil {
+		return nil, err
+	}
+	return &RemoveVolumeResponse{}, nil
+}
+
+func fromPbCreateContainerRequest(req *CreateContainerRequest) cri.ContainerOptions {
+	return cri.ContainerOptions{
+		Name:            req.Name,
+		Command:         req.Command,
+		Args:            req.Args,
+		Image:           req.Image,
+		RootfsPath:      req.RootfsPath,
+		RootfsReadonly:  req.RootfsReadonly,
+		Stdin:           req.Stdin,
+		StdinOnce:       req.StdinOnce,
+		Labels:          req.Labels,
+		Annotations:     req.Annotations,
+		Mounts:          fromPbMounts(req.Mounts),
+		SeccompProfile:  req.SeccompProfile,
+		CapAdd:          req.CapAdd,
+		CapDrop:         req.CapDrop,
+		Privileged:      req.Privileged,
+		NoNewPrivileges: req.NoNewPrivileges,
+		UserNamespace:   req.UserNamespace,
+		UIDMappings:     fromPbIDMappings(req.UidMappings),
+		GIDMappings:     fromPbIDMappings(req.GidMappings),
+		Namespaces:      fromPbNamespaceOptions(req.NamespaceOptions),
+		ProcMount:       fromPbProcMount(req.ProcMount),
+		MaskedPaths:     req.MaskedPaths,
+		ReadonlyPaths:   req.ReadonlyPaths,
+		Sysctls:         req.Sysctls,
+		Devices:         fromPbDevices(req.Devices),
+		CDIDevices:      req.CdiDevices,
+		Rlimits:         fromPbRlimits(req.Rlimits),
+		OOMScoreAdj:     int(req.OomScoreAdj),
+
+		TerminationMessagePath: req.TerminationMessagePath,
+	}
+}
+
+func fromPbRlimits(rlimits []*Rlimit) (rv []rlimit.Rlimit) {
+	for _, r := range rlimits {
+		rv = append(rv, rlimit.Rlimit{Type: r.Type, Soft: r.Soft, Hard: r.Hard})
+	}
+	return rv
+}
+
+func toPbRlimits(rlimits []rlimit.Rlimit) (rv []*Rlimit) {
+	for _, r := range rlimits {
+		rv = append(rv, &Rlimit{Type: r.Type, Soft: r.Soft, Hard: r.Hard})
+	}
+	return rv
+}
+
+func fromPbDevices(devices []*Device) (rv []container.Device) {
+	for _, d := range devices {
+		rv = append(rv, container.Device{
+			HostPath:      d.HostPath,
+			ContainerPath: d.ContainerPath,
+			Permissions:   d.Permissions,
+		})
+	}
+	return rv
+}
+
+func toPbDevices(devices []container.Device) (rv []*Device) {
+	for _, d := range devices {
+		rv = append(rv, &Device{
+			HostPath:      d.HostPath,
+			ContainerPath: d.ContainerPath,
+			Permissions:   d.Permissions,
+		})
+	}
+	return rv
+}
+
+func fromPbProcMount(m ProcMount) string {
+	switch m {
+	case ProcMount_DEFAULT:
+		return cri.ProcMountDefault
+	case ProcMount_UNMASKED:
+		return cri.ProcMountUnmasked
+	}
+	// Rejected by the validation.
+	return m.String()
+}
+
+var mountTypes = map[MountType]container.MountType{
+	MountType_BIND:   container.MountBind,
+	MountType_VOLUME: container.MountVolume,
+	MountType_TMPFS:  container.MountTmpfs,
+}
+
+var mountPropagations = map[MountPropagation]string{
+	MountPropagation_PROPAGATION_PRIVATE:           container.PropagationPrivate,
+	MountPropagation_PROPAGATION_HOST_TO_CONTAINER: container.PropagationSlave,
+	MountPropagation_PROPAGATION_BIDIRECTIONAL:     container.PropagationShared,
+}
+
+func fromPbNamespaceOptions(opts *NamespaceOption) container.NamespaceOptions {
+	if opts == nil {
+		return container.NamespaceOptions{}
+	}
+	return container.NamespaceOptions{
+		Network:  fromPbNamespaceMode(opts.Network),
+		PID:      fromPbNamespaceMode(opts.Pid),
+		IPC:      fromPbNamespaceMode(opts.Ipc),
+		UTS:      fromPbNamespaceMode(opts.Uts),
+		TargetID: container.ID(opts.TargetId),
+	}
+}
+
+func fromPbNamespaceMode(m NamespaceMode) container.NamespaceMode {
+	switch m {
+	case NamespaceMode_POD, NamespaceMode_CONTAINER:
+		return container.NamespacePrivate
+	case NamespaceMode_NODE:
+		return container.NamespaceHost
+	case NamespaceMode_TARGET:
+		return container.NamespaceTarget
+	}
+	// Rejected by the validation.
+	return container.NamespaceMode(m.String())
+}
+
+func toPbNamespaceOptions(opts container.NamespaceOptions) *NamespaceOption {
+	if opts == (container.NamespaceOptions{}) {
+		return nil
+	}
+	return &NamespaceOption{
+		Network:  toPbNamespaceMode(opts.Network),
+		Pid:      toPbNamespaceMode(opts.PID),
+		Ipc:      toPbNamespaceMode(opts.IPC),
+		Uts:      toPbNamespaceMode(opts.UTS),
+		TargetId: string(opts.TargetID),
+	}
+}
+
+func toPbNamespaceMode(m container.NamespaceMode) NamespaceMode {
+	switch m {
+	case container.NamespaceHost:
+		return NamespaceMode_NODE
+	case container.NamespaceTarget:
+		return NamespaceMode_TARGET
+	}
+	return NamespaceMode_CONTAINER
+}
+
+func fromPbIDMappings(mappings []*IDMapping) (rv []idmap.Mapping) {
+	for _, m := range mappings {
+		rv = append(rv, idmap.Mapping{
+			ContainerID: m.ContainerId,
+			HostID:      m.HostId,
+			Size:        m.Size,
+		})
+	}
+	return rv
+}
+
+func toPbIDMappings(mappings []idmap.Mapping) (rv []*IDMapping) {
+	for _, m := range mappings {
+		rv = append(rv, &IDMapping{
+			ContainerId: m.ContainerID,
+			HostId:      m.HostID,
+			Size:        m.Size,
+		})
+	}
+	return rv
+}
+
+func fromPbMounts(mounts []*Mount) (rv []container.Mount) {
+	for _, m := range mounts {
+		rv = append(rv, container.Mount{
+			Type:        mountTypes[m.Type],
+			Source:      m.Source,
+			Destination: m.Destination,
+			Readonly:    m.Readonly,
+			Propagation: mountPropagations[m.Propagation],
+			Size:        m.Size,
+
+			RecursiveReadonly: m.RecursiveReadOnly,
+		})
+	}
+	return
+}
+
+func toPbMounts(mounts []container.Mount) (rv []*Mount) {
+	for _, m := range mounts {
+		pm := &Mount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			Readonly:    m.Readonly,
+			Size:        m.Size,
+
+			RecursiveReadOnly: m.RecursiveReadonly,
+		}
+		for k, v := range mountTypes {
+			if v == m.Type {
+				pm.Type = k
+			}
+		}
+		for k, v := range mountPropagations {
+			if v == m.Propagation {
+				pm.Propagation = k
+			}
+		}
+		rv = append(rv, pm)
+	}
+	return
+}
+
+func toPbVolume(v *cri.Volume) *Volume {
+	pv := &Volume{
+		Name:      v.Name,
+		CreatedAt: v.CreatedAt.UnixNano(),
+		Labels:    v.Labels,
+		Path:      v.Path,
+	}
+	for _, id := range v.Containers {
+		pv.ContainerIds = append(pv.ContainerIds, string(id))
+	}
+	return pv
+}
+
 func toPbContainerState(s container.Status) ContainerState {
 	switch s {
 	case container.Created:
@@ -172,14 +776,79 @@ func toPbContainerState(s container.Status) ContainerState {
 	return ContainerState_UNKNOWN
 }
 
+func toPbChangeKind(k archive.ChangeKind) ChangeKind {
+	switch k {
+	case archive.ChangeAdd:
+		return ChangeKind_ADDED
+	case archive.ChangeDelete:
+		return ChangeKind_DELETED
+	}
+	return ChangeKind_MODIFIED
+}
+
+func fromPbContainerState(s ContainerState) container.Status {
+	switch s {
+	case ContainerState_CREATED:
+		return container.Created
+	case ContainerState_RUNNING:
+		return container.Running
+	case ContainerState_EXITED:
+		return container.Stopped
+	}
+	return container.Unknown
+}
+
+func fromPbContainerFilter(f *ContainerFilter) *cri.ContainerFilter {
+	if f == nil {
+		return nil
+	}
+
+	filter := &cri.ContainerFilter{
+		IDPrefix:      f.Id,
+		Name:          f.Name,
+		PodSandboxID:  f.PodSandboxId,
+		LabelSelector: f.LabelSelector,
+	}
+	if f.State != nil {
+		state := fromPbContainerState(f.State.State)
+		filter.State = &state
+	}
+	return filter
+}
+
 func toPbContainers(cs []*container.Container) (rv []*Container) {
 	for _, c := range cs {
 		rv = append(rv, &Container{
-			Id:        string(c.ID()),
-			Name:      string(c.Name()),
-			CreatedAt: c.CreatedAtNano(),
-			State:     toPbContainerState(c.Status()),
+			Id:          string(c.ID()),
+			Name:        string(c.Name()),
+			CreatedAt:   c.CreatedAtNano(),
+			State:       toPbContainerState(c.Status()),
+			Labels:      c.Labels(),
+			Annotations: c.Annotations(),
+			ExitCode:    c.ExitCode(),
+			Image:       containerImage(c),
 		})
 	}
 	return
 }
+
+func toPbImages(imgs []*storage.Image) (rv []*Image) {
+	for _, img := range imgs {
+		rv = append(rv, &Image{
+			Id:        img.ID,
+			Names:     img.Names,
+			CreatedAt: img.CreatedAt.UnixNano(),
+			Size:      img.Size,
+		})
+	}
+	return
+}
+
+// containerImage returns the image reference the container has been
+// created from, or its rootfs path if it's not a stored image.
+func containerImage(c *container.Container) string {
+	if c.Image() != "" {
+		return c.Image()
+	}
+	return c.Rootfs()
+}
@@ -23,6 +23,149 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Mirrors the CRI procMount types.
+type ProcMount int32
+
+const (
+	ProcMount_DEFAULT  ProcMount = 0
+	ProcMount_UNMASKED ProcMount = 1
+)
+
+var ProcMount_name = map[int32]string{
+	0: "DEFAULT",
+	1: "UNMASKED",
+}
+var ProcMount_value = map[string]int32{
+	"DEFAULT":  0,
+	"UNMASKED": 1,
+}
+
+func (x ProcMount) String() string {
+	return proto.EnumName(ProcMount_name, int32(x))
+}
+func (ProcMount) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{0}
+}
+
+type MountType int32
+
+const (
+	MountType_BIND MountType = 0
+	// A named volume. Created on the fly if missing. An empty volume
+	// is populated with the image content at the mount destination
+	// when the volume is used for the first time.
+	MountType_VOLUME MountType = 1
+	MountType_TMPFS  MountType = 2
+)
+
+var MountType_name = map[int32]string{
+	0: "BIND",
+	1: "VOLUME",
+	2: "TMPFS",
+}
+var MountType_value = map[string]int32{
+	"BIND":   0,
+	"VOLUME": 1,
+	"TMPFS":  2,
+}
+
+func (x MountType) String() string {
+	return proto.EnumName(MountType_name, int32(x))
+}
+func (MountType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{1}
+}
+
+// Mirrors the CRI namespace modes. There are no pods (sandboxes)
+// so far, hence POD is the same as CONTAINER.
+type NamespaceMode int32
+
+const (
+	// A new namespace.
+	NamespaceMode_POD       NamespaceMode = 0
+	NamespaceMode_CONTAINER NamespaceMode = 1
+	// The host namespace.
+	NamespaceMode_NODE NamespaceMode = 2
+	// The namespace of the target container.
+	NamespaceMode_TARGET NamespaceMode = 3
+)
+
+var NamespaceMode_name = map[int32]string{
+	0: "POD",
+	1: "CONTAINER",
+	2: "NODE",
+	3: "TARGET",
+}
+var NamespaceMode_value = map[string]int32{
+	"POD":       0,
+	"CONTAINER": 1,
+	"NODE":      2,
+	"TARGET":    3,
+}
+
+func (x NamespaceMode) String() string {
+	return proto.EnumName(NamespaceMode_name, int32(x))
+}
+func (NamespaceMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{2}
+}
+
+// Mirrors the CRI mount propagation modes.
+type MountPropagation int32
+
+const (
+	// rprivate
+	MountPropagation_PROPAGATION_PRIVATE MountPropagation = 0
+	// rslave
+	MountPropagation_PROPAGATION_HOST_TO_CONTAINER MountPropagation = 1
+	// rshared
+	MountPropagation_PROPAGATION_BIDIRECTIONAL MountPropagation = 2
+)
+
+var MountPropagation_name = map[int32]string{
+	0: "PROPAGATION_PRIVATE",
+	1: "PROPAGATION_HOST_TO_CONTAINER",
+	2: "PROPAGATION_BIDIRECTIONAL",
+}
+var MountPropagation_value = map[string]int32{
+	"PROPAGATION_PRIVATE":           0,
+	"PROPAGATION_HOST_TO_CONTAINER": 1,
+	"PROPAGATION_BIDIRECTIONAL":     2,
+}
+
+func (x MountPropagation) String() string {
+	return proto.EnumName(MountPropagation_name, int32(x))
+}
+func (MountPropagation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{3}
+}
+
+type ChangeKind int32
+
+const (
+	ChangeKind_MODIFIED ChangeKind = 0
+	ChangeKind_ADDED    ChangeKind = 1
+	ChangeKind_DELETED  ChangeKind = 2
+)
+
+var ChangeKind_name = map[int32]string{
+	0: "MODIFIED",
+	1: "ADDED",
+	2: "DELETED",
+}
+var ChangeKind_value = map[string]int32{
+	"MODIFIED": 0,
+	"ADDED":    1,
+	"DELETED":  2,
+}
+
+func (x ChangeKind) String() string {
+	return proto.EnumName(ChangeKind_name, int32(x))
+}
+func (ChangeKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{4}
+}
+
 type ContainerState int32
 
 const (
@@ -49,7 +192,7 @@ func (x ContainerState) String() string {
 	return proto.EnumName(ContainerState_name, int32(x))
 }
 func (ContainerState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{0}
+	return fileDescriptor_conman_a4cc490026972f9a, []int{5}
 }
 
 type VersionRequest struct {
@@ -62,7 +205,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{0}
+	return fileDescriptor_conman_a4cc490026972f9a, []int{0}
 }
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionRequest.Unmarshal(m, b)
@@ -95,7 +238,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{1}
+	return fileDescriptor_conman_a4cc490026972f9a, []int{1}
 }
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionResponse.Unmarshal(m, b)
@@ -145,17 +288,63 @@ type CreateContainerRequest struct {
 	// Keep container's STDIN open.
 	Stdin bool `protobuf:"varint,6,opt,name=stdin" json:"stdin,omitempty"`
 	// If true, STDIN will be closed after the first attach session completes.
-	StdinOnce            bool     `protobuf:"varint,7,opt,name=stdin_once,json=stdinOnce" json:"stdin_once,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	StdinOnce bool `protobuf:"varint,7,opt,name=stdin_once,json=stdinOnce" json:"stdin_once,omitempty"`
+	// Arbitrary metadata to group and select containers.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Arbitrary metadata, also passed to the OCI runtime spec.
+	Annotations map[string]string `protobuf:"bytes,9,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Stored image reference (name:tag, ID, or unique ID prefix).
+	// Mutually exclusive with rootfs_path.
+	Image  string   `protobuf:"bytes,10,opt,name=image" json:"image,omitempty"`
+	Mounts []*Mount `protobuf:"bytes,11,rep,name=mounts" json:"mounts,omitempty"`
+	// Seccomp profile: unconfined, runtime/default (if empty),
+	// or localhost/<absolute path to a JSON profile>.
+	SeccompProfile string `protobuf:"bytes,12,opt,name=seccomp_profile,json=seccompProfile" json:"seccomp_profile,omitempty"`
+	// Added to (dropped from) the daemon default capabilities, e.g.
+	// NET_ADMIN or CAP_NET_ADMIN. ALL stands for every capability.
+	CapAdd  []string `protobuf:"bytes,13,rep,name=cap_add,json=capAdd" json:"cap_add,omitempty"`
+	CapDrop []string `protobuf:"bytes,14,rep,name=cap_drop,json=capDrop" json:"cap_drop,omitempty"`
+	// All capabilities, host devices, and unmasked /proc and /sys.
+	Privileged      bool `protobuf:"varint,15,opt,name=privileged" json:"privileged,omitempty"`
+	NoNewPrivileges bool `protobuf:"varint,16,opt,name=no_new_privileges,json=noNewPrivileges" json:"no_new_privileges,omitempty"`
+	// Run the container in its own user namespace. The host IDs of
+	// the mappings are offsets in the block of subordinate IDs
+	// allocated for the container. The UID mappings default to
+	// 0:0:65536, the GID mappings default to the UID ones.
+	UserNamespace bool         `protobuf:"varint,17,opt,name=user_namespace,json=userNamespace" json:"user_namespace,omitempty"`
+	UidMappings   []*IDMapping `protobuf:"bytes,18,rep,name=uid_mappings,json=uidMappings" json:"uid_mappings,omitempty"`
+	GidMappings   []*IDMapping `protobuf:"bytes,19,rep,name=gid_mappings,json=gidMappings" json:"gid_mappings,omitempty"`
+	// Namespaces shared with the host or another container.
+	NamespaceOptions *NamespaceOption `protobuf:"bytes,20,opt,name=namespace_options,json=namespaceOptions" json:"namespace_options,omitempty"`
+	// Replace the daemon default masked (read-only) paths if set.
+	MaskedPaths   []string `protobuf:"bytes,21,rep,name=masked_paths,json=maskedPaths" json:"masked_paths,omitempty"`
+	ReadonlyPaths []string `protobuf:"bytes,22,rep,name=readonly_paths,json=readonlyPaths" json:"readonly_paths,omitempty"`
+	// UNMASKED leaves nothing masked or read-only in /proc and /sys.
+	ProcMount ProcMount `protobuf:"varint,23,opt,name=proc_mount,json=procMount,enum=ProcMount" json:"proc_mount,omitempty"`
+	// Namespaced sysctls, e.g. net.core.somaxconn. Only the safe ones
+	// and the ones allowed by the daemon can be set.
+	Sysctls map[string]string `protobuf:"bytes,24,rep,name=sysctls" json:"sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Host devices, and the CDI devices by the
+	// qualified names, e.g. vendor.com/class=name.
+	Devices    []*Device `protobuf:"bytes,25,rep,name=devices" json:"devices,omitempty"`
+	CdiDevices []string  `protobuf:"bytes,26,rep,name=cdi_devices,json=cdiDevices" json:"cdi_devices,omitempty"`
+	// Override the daemon default rlimits of the same types.
+	Rlimits []*Rlimit `protobuf:"bytes,27,rep,name=rlimits" json:"rlimits,omitempty"`
+	// [-1000, 1000], 0 leaves the OCI runtime one.
+	OomScoreAdj int32 `protobuf:"varint,28,opt,name=oom_score_adj,json=oomScoreAdj" json:"oom_score_adj,omitempty"`
+	// Container path of the file the termination message is read
+	// from once the container exits. Defaults to /dev/termination-log.
+	TerminationMessagePath string   `protobuf:"bytes,29,opt,name=termination_message_path,json=terminationMessagePath" json:"termination_message_path,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *CreateContainerRequest) Reset()         { *m = CreateContainerRequest{} }
 func (m *CreateContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateContainerRequest) ProtoMessage()    {}
 func (*CreateContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{2}
+	return fileDescriptor_conman_a4cc490026972f9a, []int{2}
 }
 func (m *CreateContainerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateContainerRequest.Unmarshal(m, b)
@@ -224,699 +413,3006 @@ func (m *CreateContainerRequest) GetStdinOnce() bool {
 	return false
 }
 
-type CreateContainerResponse struct {
-	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CreateContainerRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
 
-func (m *CreateContainerResponse) Reset()         { *m = CreateContainerResponse{} }
-func (m *CreateContainerResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateContainerResponse) ProtoMessage()    {}
-func (*CreateContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{3}
+func (m *CreateContainerRequest) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
 }
-func (m *CreateContainerResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateContainerResponse.Unmarshal(m, b)
+
+func (m *CreateContainerRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
 }
-func (m *CreateContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateContainerResponse.Marshal(b, m, deterministic)
+
+func (m *CreateContainerRequest) GetMounts() []*Mount {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
 }
-func (dst *CreateContainerResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateContainerResponse.Merge(dst, src)
+
+func (m *CreateContainerRequest) GetSeccompProfile() string {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return ""
 }
-func (m *CreateContainerResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateContainerResponse.Size(m)
+
+func (m *CreateContainerRequest) GetCapAdd() []string {
+	if m != nil {
+		return m.CapAdd
+	}
+	return nil
 }
-func (m *CreateContainerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateContainerResponse.DiscardUnknown(m)
+
+func (m *CreateContainerRequest) GetCapDrop() []string {
+	if m != nil {
+		return m.CapDrop
+	}
+	return nil
 }
 
-var xxx_messageInfo_CreateContainerResponse proto.InternalMessageInfo
+func (m *CreateContainerRequest) GetPrivileged() bool {
+	if m != nil {
+		return m.Privileged
+	}
+	return false
+}
 
-func (m *CreateContainerResponse) GetContainerId() string {
+func (m *CreateContainerRequest) GetNoNewPrivileges() bool {
 	if m != nil {
-		return m.ContainerId
+		return m.NoNewPrivileges
 	}
-	return ""
+	return false
 }
 
-type StartContainerRequest struct {
-	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CreateContainerRequest) GetUserNamespace() bool {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return false
 }
 
-func (m *StartContainerRequest) Reset()         { *m = StartContainerRequest{} }
-func (m *StartContainerRequest) String() string { return proto.CompactTextString(m) }
-func (*StartContainerRequest) ProtoMessage()    {}
-func (*StartContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{4}
+func (m *CreateContainerRequest) GetUidMappings() []*IDMapping {
+	if m != nil {
+		return m.UidMappings
+	}
+	return nil
 }
-func (m *StartContainerRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartContainerRequest.Unmarshal(m, b)
+
+func (m *CreateContainerRequest) GetGidMappings() []*IDMapping {
+	if m != nil {
+		return m.GidMappings
+	}
+	return nil
 }
-func (m *StartContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartContainerRequest.Marshal(b, m, deterministic)
+
+func (m *CreateContainerRequest) GetNamespaceOptions() *NamespaceOption {
+	if m != nil {
+		return m.NamespaceOptions
+	}
+	return nil
 }
-func (dst *StartContainerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartContainerRequest.Merge(dst, src)
+
+func (m *CreateContainerRequest) GetMaskedPaths() []string {
+	if m != nil {
+		return m.MaskedPaths
+	}
+	return nil
 }
-func (m *StartContainerRequest) XXX_Size() int {
-	return xxx_messageInfo_StartContainerRequest.Size(m)
+
+func (m *CreateContainerRequest) GetReadonlyPaths() []string {
+	if m != nil {
+		return m.ReadonlyPaths
+	}
+	return nil
 }
-func (m *StartContainerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartContainerRequest.DiscardUnknown(m)
+
+func (m *CreateContainerRequest) GetProcMount() ProcMount {
+	if m != nil {
+		return m.ProcMount
+	}
+	return ProcMount_DEFAULT
 }
 
-var xxx_messageInfo_StartContainerRequest proto.InternalMessageInfo
+func (m *CreateContainerRequest) GetSysctls() map[string]string {
+	if m != nil {
+		return m.Sysctls
+	}
+	return nil
+}
 
-func (m *StartContainerRequest) GetContainerId() string {
+func (m *CreateContainerRequest) GetDevices() []*Device {
 	if m != nil {
-		return m.ContainerId
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetCdiDevices() []string {
+	if m != nil {
+		return m.CdiDevices
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetRlimits() []*Rlimit {
+	if m != nil {
+		return m.Rlimits
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetOomScoreAdj() int32 {
+	if m != nil {
+		return m.OomScoreAdj
+	}
+	return 0
+}
+
+func (m *CreateContainerRequest) GetTerminationMessagePath() string {
+	if m != nil {
+		return m.TerminationMessagePath
 	}
 	return ""
 }
 
-type StartContainerResponse struct {
+type Rlimit struct {
+	// RLIMIT_NOFILE, RLIMIT_NPROC, etc.
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// 18446744073709551615 (2^64-1) stands for unlimited.
+	Soft                 uint64   `protobuf:"varint,2,opt,name=soft" json:"soft,omitempty"`
+	Hard                 uint64   `protobuf:"varint,3,opt,name=hard" json:"hard,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StartContainerResponse) Reset()         { *m = StartContainerResponse{} }
-func (m *StartContainerResponse) String() string { return proto.CompactTextString(m) }
-func (*StartContainerResponse) ProtoMessage()    {}
-func (*StartContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{5}
+func (m *Rlimit) Reset()         { *m = Rlimit{} }
+func (m *Rlimit) String() string { return proto.CompactTextString(m) }
+func (*Rlimit) ProtoMessage()    {}
+func (*Rlimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{3}
 }
-func (m *StartContainerResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartContainerResponse.Unmarshal(m, b)
+func (m *Rlimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rlimit.Unmarshal(m, b)
 }
-func (m *StartContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartContainerResponse.Marshal(b, m, deterministic)
+func (m *Rlimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rlimit.Marshal(b, m, deterministic)
 }
-func (dst *StartContainerResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartContainerResponse.Merge(dst, src)
+func (dst *Rlimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rlimit.Merge(dst, src)
 }
-func (m *StartContainerResponse) XXX_Size() int {
-	return xxx_messageInfo_StartContainerResponse.Size(m)
+func (m *Rlimit) XXX_Size() int {
+	return xxx_messageInfo_Rlimit.Size(m)
 }
-func (m *StartContainerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartContainerResponse.DiscardUnknown(m)
+func (m *Rlimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rlimit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StartContainerResponse proto.InternalMessageInfo
+var xxx_messageInfo_Rlimit proto.InternalMessageInfo
 
-type StopContainerRequest struct {
-	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	// Timeout in seconds before forcebly killing container (SIGKILL)
-	Timeout              int64    `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+func (m *Rlimit) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Rlimit) GetSoft() uint64 {
+	if m != nil {
+		return m.Soft
+	}
+	return 0
+}
+
+func (m *Rlimit) GetHard() uint64 {
+	if m != nil {
+		return m.Hard
+	}
+	return 0
+}
+
+// Mirrors the CRI Device.
+type Device struct {
+	ContainerPath string `protobuf:"bytes,1,opt,name=container_path,json=containerPath" json:"container_path,omitempty"`
+	HostPath      string `protobuf:"bytes,2,opt,name=host_path,json=hostPath" json:"host_path,omitempty"`
+	// Any combination of r, w, and m.
+	Permissions          string   `protobuf:"bytes,3,opt,name=permissions" json:"permissions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopContainerRequest) Reset()         { *m = StopContainerRequest{} }
-func (m *StopContainerRequest) String() string { return proto.CompactTextString(m) }
-func (*StopContainerRequest) ProtoMessage()    {}
-func (*StopContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{6}
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{4}
 }
-func (m *StopContainerRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopContainerRequest.Unmarshal(m, b)
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Device.Unmarshal(m, b)
 }
-func (m *StopContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopContainerRequest.Marshal(b, m, deterministic)
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Device.Marshal(b, m, deterministic)
 }
-func (dst *StopContainerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopContainerRequest.Merge(dst, src)
+func (dst *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(dst, src)
 }
-func (m *StopContainerRequest) XXX_Size() int {
-	return xxx_messageInfo_StopContainerRequest.Size(m)
+func (m *Device) XXX_Size() int {
+	return xxx_messageInfo_Device.Size(m)
 }
-func (m *StopContainerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopContainerRequest.DiscardUnknown(m)
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StopContainerRequest proto.InternalMessageInfo
+var xxx_messageInfo_Device proto.InternalMessageInfo
 
-func (m *StopContainerRequest) GetContainerId() string {
+func (m *Device) GetContainerPath() string {
 	if m != nil {
-		return m.ContainerId
+		return m.ContainerPath
 	}
 	return ""
 }
 
-func (m *StopContainerRequest) GetTimeout() int64 {
+func (m *Device) GetHostPath() string {
 	if m != nil {
-		return m.Timeout
+		return m.HostPath
 	}
-	return 0
+	return ""
 }
 
-type StopContainerResponse struct {
+func (m *Device) GetPermissions() string {
+	if m != nil {
+		return m.Permissions
+	}
+	return ""
+}
+
+type IDMapping struct {
+	ContainerId          uint32   `protobuf:"varint,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	HostId               uint32   `protobuf:"varint,2,opt,name=host_id,json=hostId" json:"host_id,omitempty"`
+	Size                 uint32   `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopContainerResponse) Reset()         { *m = StopContainerResponse{} }
-func (m *StopContainerResponse) String() string { return proto.CompactTextString(m) }
-func (*StopContainerResponse) ProtoMessage()    {}
-func (*StopContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{7}
+func (m *IDMapping) Reset()         { *m = IDMapping{} }
+func (m *IDMapping) String() string { return proto.CompactTextString(m) }
+func (*IDMapping) ProtoMessage()    {}
+func (*IDMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{5}
 }
-func (m *StopContainerResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopContainerResponse.Unmarshal(m, b)
+func (m *IDMapping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDMapping.Unmarshal(m, b)
 }
-func (m *StopContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopContainerResponse.Marshal(b, m, deterministic)
+func (m *IDMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDMapping.Marshal(b, m, deterministic)
 }
-func (dst *StopContainerResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopContainerResponse.Merge(dst, src)
+func (dst *IDMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDMapping.Merge(dst, src)
 }
-func (m *StopContainerResponse) XXX_Size() int {
-	return xxx_messageInfo_StopContainerResponse.Size(m)
+func (m *IDMapping) XXX_Size() int {
+	return xxx_messageInfo_IDMapping.Size(m)
 }
-func (m *StopContainerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopContainerResponse.DiscardUnknown(m)
+func (m *IDMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDMapping.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StopContainerResponse proto.InternalMessageInfo
+var xxx_messageInfo_IDMapping proto.InternalMessageInfo
 
-type RemoveContainerRequest struct {
-	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *IDMapping) GetContainerId() uint32 {
+	if m != nil {
+		return m.ContainerId
+	}
+	return 0
 }
 
-func (m *RemoveContainerRequest) Reset()         { *m = RemoveContainerRequest{} }
-func (m *RemoveContainerRequest) String() string { return proto.CompactTextString(m) }
-func (*RemoveContainerRequest) ProtoMessage()    {}
-func (*RemoveContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{8}
+func (m *IDMapping) GetHostId() uint32 {
+	if m != nil {
+		return m.HostId
+	}
+	return 0
 }
-func (m *RemoveContainerRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveContainerRequest.Unmarshal(m, b)
+
+func (m *IDMapping) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
-func (m *RemoveContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveContainerRequest.Marshal(b, m, deterministic)
+
+// Mirrors the CRI NamespaceOption, plus the UTS namespace.
+type NamespaceOption struct {
+	Network NamespaceMode `protobuf:"varint,1,opt,name=network,enum=NamespaceMode" json:"network,omitempty"`
+	Pid     NamespaceMode `protobuf:"varint,2,opt,name=pid,enum=NamespaceMode" json:"pid,omitempty"`
+	Ipc     NamespaceMode `protobuf:"varint,3,opt,name=ipc,enum=NamespaceMode" json:"ipc,omitempty"`
+	// The container the TARGET namespaces are shared with
+	// (ID, name, or unique ID prefix).
+	TargetId             string        `protobuf:"bytes,4,opt,name=target_id,json=targetId" json:"target_id,omitempty"`
+	Uts                  NamespaceMode `protobuf:"varint,5,opt,name=uts,enum=NamespaceMode" json:"uts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (dst *RemoveContainerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveContainerRequest.Merge(dst, src)
+
+func (m *NamespaceOption) Reset()         { *m = NamespaceOption{} }
+func (m *NamespaceOption) String() string { return proto.CompactTextString(m) }
+func (*NamespaceOption) ProtoMessage()    {}
+func (*NamespaceOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{6}
 }
-func (m *RemoveContainerRequest) XXX_Size() int {
-	return xxx_messageInfo_RemoveContainerRequest.Size(m)
+func (m *NamespaceOption) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceOption.Unmarshal(m, b)
 }
-func (m *RemoveContainerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveContainerRequest.DiscardUnknown(m)
+func (m *NamespaceOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceOption.Marshal(b, m, deterministic)
+}
+func (dst *NamespaceOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceOption.Merge(dst, src)
+}
+func (m *NamespaceOption) XXX_Size() int {
+	return xxx_messageInfo_NamespaceOption.Size(m)
+}
+func (m *NamespaceOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceOption.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemoveContainerRequest proto.InternalMessageInfo
+var xxx_messageInfo_NamespaceOption proto.InternalMessageInfo
 
-func (m *RemoveContainerRequest) GetContainerId() string {
+func (m *NamespaceOption) GetNetwork() NamespaceMode {
 	if m != nil {
-		return m.ContainerId
+		return m.Network
 	}
-	return ""
+	return NamespaceMode_POD
 }
 
-type RemoveContainerResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *NamespaceOption) GetPid() NamespaceMode {
+	if m != nil {
+		return m.Pid
+	}
+	return NamespaceMode_POD
 }
 
-func (m *RemoveContainerResponse) Reset()         { *m = RemoveContainerResponse{} }
-func (m *RemoveContainerResponse) String() string { return proto.CompactTextString(m) }
-func (*RemoveContainerResponse) ProtoMessage()    {}
-func (*RemoveContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{9}
-}
-func (m *RemoveContainerResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveContainerResponse.Unmarshal(m, b)
-}
-func (m *RemoveContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveContainerResponse.Marshal(b, m, deterministic)
-}
-func (dst *RemoveContainerResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveContainerResponse.Merge(dst, src)
-}
-func (m *RemoveContainerResponse) XXX_Size() int {
-	return xxx_messageInfo_RemoveContainerResponse.Size(m)
-}
-func (m *RemoveContainerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveContainerResponse.DiscardUnknown(m)
+func (m *NamespaceOption) GetIpc() NamespaceMode {
+	if m != nil {
+		return m.Ipc
+	}
+	return NamespaceMode_POD
 }
 
-var xxx_messageInfo_RemoveContainerResponse proto.InternalMessageInfo
+func (m *NamespaceOption) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
 
-type ListContainersRequest struct {
+func (m *NamespaceOption) GetUts() NamespaceMode {
+	if m != nil {
+		return m.Uts
+	}
+	return NamespaceMode_POD
+}
+
+type Mount struct {
+	Type MountType `protobuf:"varint,1,opt,name=type,enum=MountType" json:"type,omitempty"`
+	// Host path for bind mounts, volume name for volume mounts.
+	Source      string           `protobuf:"bytes,2,opt,name=source" json:"source,omitempty"`
+	Destination string           `protobuf:"bytes,3,opt,name=destination" json:"destination,omitempty"`
+	Readonly    bool             `protobuf:"varint,4,opt,name=readonly" json:"readonly,omitempty"`
+	Propagation MountPropagation `protobuf:"varint,5,opt,name=propagation,enum=MountPropagation" json:"propagation,omitempty"`
+	// Max size in bytes of a tmpfs mount. Unlimited if 0.
+	Size int64 `protobuf:"varint,6,opt,name=size" json:"size,omitempty"`
+	// Makes the bind mount submounts read-only too. Requires
+	// readonly and PROPAGATION_PRIVATE.
+	RecursiveReadOnly    bool     `protobuf:"varint,7,opt,name=recursive_read_only,json=recursiveReadOnly" json:"recursive_read_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListContainersRequest) Reset()         { *m = ListContainersRequest{} }
-func (m *ListContainersRequest) String() string { return proto.CompactTextString(m) }
-func (*ListContainersRequest) ProtoMessage()    {}
-func (*ListContainersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{10}
+func (m *Mount) Reset()         { *m = Mount{} }
+func (m *Mount) String() string { return proto.CompactTextString(m) }
+func (*Mount) ProtoMessage()    {}
+func (*Mount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{7}
 }
-func (m *ListContainersRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListContainersRequest.Unmarshal(m, b)
+func (m *Mount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Mount.Unmarshal(m, b)
 }
-func (m *ListContainersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListContainersRequest.Marshal(b, m, deterministic)
+func (m *Mount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Mount.Marshal(b, m, deterministic)
 }
-func (dst *ListContainersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListContainersRequest.Merge(dst, src)
+func (dst *Mount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mount.Merge(dst, src)
 }
-func (m *ListContainersRequest) XXX_Size() int {
-	return xxx_messageInfo_ListContainersRequest.Size(m)
+func (m *Mount) XXX_Size() int {
+	return xxx_messageInfo_Mount.Size(m)
 }
-func (m *ListContainersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListContainersRequest.DiscardUnknown(m)
+func (m *Mount) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListContainersRequest proto.InternalMessageInfo
+var xxx_messageInfo_Mount proto.InternalMessageInfo
 
-type ListContainersResponse struct {
-	Containers           []*Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+func (m *Mount) GetType() MountType {
+	if m != nil {
+		return m.Type
+	}
+	return MountType_BIND
 }
 
-func (m *ListContainersResponse) Reset()         { *m = ListContainersResponse{} }
-func (m *ListContainersResponse) String() string { return proto.CompactTextString(m) }
-func (*ListContainersResponse) ProtoMessage()    {}
-func (*ListContainersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{11}
-}
-func (m *ListContainersResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListContainersResponse.Unmarshal(m, b)
-}
-func (m *ListContainersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListContainersResponse.Marshal(b, m, deterministic)
+func (m *Mount) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
 }
-func (dst *ListContainersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListContainersResponse.Merge(dst, src)
+
+func (m *Mount) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
 }
-func (m *ListContainersResponse) XXX_Size() int {
-	return xxx_messageInfo_ListContainersResponse.Size(m)
+
+func (m *Mount) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
+	}
+	return false
 }
-func (m *ListContainersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListContainersResponse.DiscardUnknown(m)
+
+func (m *Mount) GetPropagation() MountPropagation {
+	if m != nil {
+		return m.Propagation
+	}
+	return MountPropagation_PROPAGATION_PRIVATE
 }
 
-var xxx_messageInfo_ListContainersResponse proto.InternalMessageInfo
+func (m *Mount) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
 
-func (m *ListContainersResponse) GetContainers() []*Container {
+func (m *Mount) GetRecursiveReadOnly() bool {
 	if m != nil {
-		return m.Containers
+		return m.RecursiveReadOnly
 	}
-	return nil
+	return false
 }
 
-type ContainerStatusRequest struct {
+type CreateContainerResponse struct {
 	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ContainerStatusRequest) Reset()         { *m = ContainerStatusRequest{} }
-func (m *ContainerStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*ContainerStatusRequest) ProtoMessage()    {}
-func (*ContainerStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{12}
+func (m *CreateContainerResponse) Reset()         { *m = CreateContainerResponse{} }
+func (m *CreateContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateContainerResponse) ProtoMessage()    {}
+func (*CreateContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{8}
 }
-func (m *ContainerStatusRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ContainerStatusRequest.Unmarshal(m, b)
+func (m *CreateContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateContainerResponse.Unmarshal(m, b)
 }
-func (m *ContainerStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ContainerStatusRequest.Marshal(b, m, deterministic)
+func (m *CreateContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateContainerResponse.Marshal(b, m, deterministic)
 }
-func (dst *ContainerStatusRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ContainerStatusRequest.Merge(dst, src)
+func (dst *CreateContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateContainerResponse.Merge(dst, src)
 }
-func (m *ContainerStatusRequest) XXX_Size() int {
-	return xxx_messageInfo_ContainerStatusRequest.Size(m)
+func (m *CreateContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateContainerResponse.Size(m)
 }
-func (m *ContainerStatusRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ContainerStatusRequest.DiscardUnknown(m)
+func (m *CreateContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateContainerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ContainerStatusRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateContainerResponse proto.InternalMessageInfo
 
-func (m *ContainerStatusRequest) GetContainerId() string {
+func (m *CreateContainerResponse) GetContainerId() string {
 	if m != nil {
 		return m.ContainerId
 	}
 	return ""
 }
 
-type ContainerStatusResponse struct {
-	Status               *ContainerStatus `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type StartContainerRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ContainerStatusResponse) Reset()         { *m = ContainerStatusResponse{} }
-func (m *ContainerStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*ContainerStatusResponse) ProtoMessage()    {}
-func (*ContainerStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{13}
+func (m *StartContainerRequest) Reset()         { *m = StartContainerRequest{} }
+func (m *StartContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*StartContainerRequest) ProtoMessage()    {}
+func (*StartContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{9}
 }
-func (m *ContainerStatusResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ContainerStatusResponse.Unmarshal(m, b)
+func (m *StartContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartContainerRequest.Unmarshal(m, b)
 }
-func (m *ContainerStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ContainerStatusResponse.Marshal(b, m, deterministic)
+func (m *StartContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartContainerRequest.Marshal(b, m, deterministic)
 }
-func (dst *ContainerStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ContainerStatusResponse.Merge(dst, src)
+func (dst *StartContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartContainerRequest.Merge(dst, src)
 }
-func (m *ContainerStatusResponse) XXX_Size() int {
-	return xxx_messageInfo_ContainerStatusResponse.Size(m)
+func (m *StartContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_StartContainerRequest.Size(m)
 }
-func (m *ContainerStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ContainerStatusResponse.DiscardUnknown(m)
+func (m *StartContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartContainerRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ContainerStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_StartContainerRequest proto.InternalMessageInfo
 
-func (m *ContainerStatusResponse) GetStatus() *ContainerStatus {
+func (m *StartContainerRequest) GetContainerId() string {
 	if m != nil {
-		return m.Status
+		return m.ContainerId
 	}
-	return nil
+	return ""
 }
 
-type Container struct {
-	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// Unix time in nanoseconds
-	CreatedAt            int64          `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	State                ContainerState `protobuf:"varint,4,opt,name=state,enum=ContainerState" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type StartContainerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Container) Reset()         { *m = Container{} }
-func (m *Container) String() string { return proto.CompactTextString(m) }
-func (*Container) ProtoMessage()    {}
-func (*Container) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{14}
+func (m *StartContainerResponse) Reset()         { *m = StartContainerResponse{} }
+func (m *StartContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*StartContainerResponse) ProtoMessage()    {}
+func (*StartContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{10}
 }
-func (m *Container) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Container.Unmarshal(m, b)
+func (m *StartContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartContainerResponse.Unmarshal(m, b)
 }
-func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+func (m *StartContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartContainerResponse.Marshal(b, m, deterministic)
 }
-func (dst *Container) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Container.Merge(dst, src)
+func (dst *StartContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartContainerResponse.Merge(dst, src)
 }
-func (m *Container) XXX_Size() int {
-	return xxx_messageInfo_Container.Size(m)
+func (m *StartContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_StartContainerResponse.Size(m)
 }
-func (m *Container) XXX_DiscardUnknown() {
-	xxx_messageInfo_Container.DiscardUnknown(m)
+func (m *StartContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartContainerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Container proto.InternalMessageInfo
+var xxx_messageInfo_StartContainerResponse proto.InternalMessageInfo
 
-func (m *Container) GetId() string {
-	if m != nil {
-		return m.Id
-	}
-	return ""
+type RunContainerRequest struct {
+	Container *CreateContainerRequest `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	// If true, the container start is postponed until the first
+	// attach session is established via the returned URL. If nobody
+	// attaches in time, the container is removed.
+	Attach bool `protobuf:"varint,2,opt,name=attach" json:"attach,omitempty"`
+	// Remove the container once it has stopped.
+	AutoRemove           bool     `protobuf:"varint,3,opt,name=auto_remove,json=autoRemove" json:"auto_remove,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Container) GetName() string {
+func (m *RunContainerRequest) Reset()         { *m = RunContainerRequest{} }
+func (m *RunContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*RunContainerRequest) ProtoMessage()    {}
+func (*RunContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{11}
+}
+func (m *RunContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunContainerRequest.Unmarshal(m, b)
+}
+func (m *RunContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunContainerRequest.Merge(dst, src)
+}
+func (m *RunContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_RunContainerRequest.Size(m)
+}
+func (m *RunContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunContainerRequest proto.InternalMessageInfo
+
+func (m *RunContainerRequest) GetContainer() *CreateContainerRequest {
 	if m != nil {
-		return m.Name
+		return m.Container
 	}
-	return ""
+	return nil
 }
 
-func (m *Container) GetCreatedAt() int64 {
+func (m *RunContainerRequest) GetAttach() bool {
 	if m != nil {
-		return m.CreatedAt
+		return m.Attach
 	}
-	return 0
+	return false
 }
 
-func (m *Container) GetState() ContainerState {
+func (m *RunContainerRequest) GetAutoRemove() bool {
 	if m != nil {
-		return m.State
+		return m.AutoRemove
 	}
-	return ContainerState_CREATED
+	return false
 }
 
-type ContainerStatus struct {
-	ContainerId   string         `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	ContainerName string         `protobuf:"bytes,2,opt,name=container_name,json=containerName" json:"container_name,omitempty"`
-	State         ContainerState `protobuf:"varint,3,opt,name=state,enum=ContainerState" json:"state,omitempty"`
-	// Unix time in nanoseconds
-	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	// Unix time in nanoseconds
-	StartedAt int64 `protobuf:"varint,5,opt,name=started_at,json=startedAt" json:"started_at,omitempty"`
-	// Unix time in nanoseconds
-	FinishedAt int64 `protobuf:"varint,6,opt,name=finished_at,json=finishedAt" json:"finished_at,omitempty"`
-	// Exit code, relevant only if finished_at != 0.
-	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
-	// Human-readable note on the current container state.
-	Message string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
-	// Relative to conman's log dir path to container's log file.
-	LogPath              string   `protobuf:"bytes,9,opt,name=log_path,json=logPath" json:"log_path,omitempty"`
+type RunContainerResponse struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// Attach URL, set only if attach has been requested.
+	Url                  string   `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ContainerStatus) Reset()         { *m = ContainerStatus{} }
-func (m *ContainerStatus) String() string { return proto.CompactTextString(m) }
-func (*ContainerStatus) ProtoMessage()    {}
-func (*ContainerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{15}
+func (m *RunContainerResponse) Reset()         { *m = RunContainerResponse{} }
+func (m *RunContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*RunContainerResponse) ProtoMessage()    {}
+func (*RunContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{12}
 }
-func (m *ContainerStatus) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ContainerStatus.Unmarshal(m, b)
+func (m *RunContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunContainerResponse.Unmarshal(m, b)
 }
-func (m *ContainerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ContainerStatus.Marshal(b, m, deterministic)
+func (m *RunContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunContainerResponse.Marshal(b, m, deterministic)
 }
-func (dst *ContainerStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ContainerStatus.Merge(dst, src)
+func (dst *RunContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunContainerResponse.Merge(dst, src)
 }
-func (m *ContainerStatus) XXX_Size() int {
-	return xxx_messageInfo_ContainerStatus.Size(m)
+func (m *RunContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_RunContainerResponse.Size(m)
 }
-func (m *ContainerStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_ContainerStatus.DiscardUnknown(m)
+func (m *RunContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunContainerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ContainerStatus proto.InternalMessageInfo
+var xxx_messageInfo_RunContainerResponse proto.InternalMessageInfo
 
-func (m *ContainerStatus) GetContainerId() string {
+func (m *RunContainerResponse) GetContainerId() string {
 	if m != nil {
 		return m.ContainerId
 	}
 	return ""
 }
 
-func (m *ContainerStatus) GetContainerName() string {
+func (m *RunContainerResponse) GetUrl() string {
 	if m != nil {
-		return m.ContainerName
+		return m.Url
 	}
 	return ""
 }
 
-func (m *ContainerStatus) GetState() ContainerState {
-	if m != nil {
-		return m.State
-	}
-	return ContainerState_CREATED
+type StopContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// Timeout in seconds before forcebly killing container (SIGKILL)
+	Timeout              int64    `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ContainerStatus) GetCreatedAt() int64 {
-	if m != nil {
-		return m.CreatedAt
-	}
-	return 0
+func (m *StopContainerRequest) Reset()         { *m = StopContainerRequest{} }
+func (m *StopContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*StopContainerRequest) ProtoMessage()    {}
+func (*StopContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{13}
 }
-
-func (m *ContainerStatus) GetStartedAt() int64 {
-	if m != nil {
-		return m.StartedAt
-	}
-	return 0
+func (m *StopContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopContainerRequest.Unmarshal(m, b)
 }
-
-func (m *ContainerStatus) GetFinishedAt() int64 {
-	if m != nil {
-		return m.FinishedAt
-	}
-	return 0
+func (m *StopContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopContainerRequest.Marshal(b, m, deterministic)
 }
-
-func (m *ContainerStatus) GetExitCode() int32 {
-	if m != nil {
-		return m.ExitCode
-	}
-	return 0
+func (dst *StopContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopContainerRequest.Merge(dst, src)
+}
+func (m *StopContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_StopContainerRequest.Size(m)
+}
+func (m *StopContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopContainerRequest.DiscardUnknown(m)
 }
 
-func (m *ContainerStatus) GetMessage() string {
+var xxx_messageInfo_StopContainerRequest proto.InternalMessageInfo
+
+func (m *StopContainerRequest) GetContainerId() string {
 	if m != nil {
-		return m.Message
+		return m.ContainerId
 	}
 	return ""
 }
 
-func (m *ContainerStatus) GetLogPath() string {
+func (m *StopContainerRequest) GetTimeout() int64 {
 	if m != nil {
-		return m.LogPath
+		return m.Timeout
 	}
-	return ""
+	return 0
 }
 
-type AttachRequest struct {
-	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
-	Tty                  bool     `protobuf:"varint,2,opt,name=tty" json:"tty,omitempty"`
-	Stdin                bool     `protobuf:"varint,3,opt,name=stdin" json:"stdin,omitempty"`
-	Stdout               bool     `protobuf:"varint,4,opt,name=stdout" json:"stdout,omitempty"`
-	Stderr               bool     `protobuf:"varint,5,opt,name=stderr" json:"stderr,omitempty"`
+type StopContainerResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AttachRequest) Reset()         { *m = AttachRequest{} }
-func (m *AttachRequest) String() string { return proto.CompactTextString(m) }
-func (*AttachRequest) ProtoMessage()    {}
-func (*AttachRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{16}
+func (m *StopContainerResponse) Reset()         { *m = StopContainerResponse{} }
+func (m *StopContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*StopContainerResponse) ProtoMessage()    {}
+func (*StopContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{14}
 }
-func (m *AttachRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AttachRequest.Unmarshal(m, b)
+func (m *StopContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopContainerResponse.Unmarshal(m, b)
 }
-func (m *AttachRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AttachRequest.Marshal(b, m, deterministic)
+func (m *StopContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopContainerResponse.Marshal(b, m, deterministic)
 }
-func (dst *AttachRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AttachRequest.Merge(dst, src)
+func (dst *StopContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopContainerResponse.Merge(dst, src)
 }
-func (m *AttachRequest) XXX_Size() int {
-	return xxx_messageInfo_AttachRequest.Size(m)
+func (m *StopContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_StopContainerResponse.Size(m)
 }
-func (m *AttachRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AttachRequest.DiscardUnknown(m)
+func (m *StopContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopContainerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AttachRequest proto.InternalMessageInfo
+var xxx_messageInfo_StopContainerResponse proto.InternalMessageInfo
 
-func (m *AttachRequest) GetContainerId() string {
-	if m != nil {
-		return m.ContainerId
-	}
-	return ""
+type RemoveContainerRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AttachRequest) GetTty() bool {
-	if m != nil {
-		return m.Tty
-	}
-	return false
+func (m *RemoveContainerRequest) Reset()         { *m = RemoveContainerRequest{} }
+func (m *RemoveContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveContainerRequest) ProtoMessage()    {}
+func (*RemoveContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{15}
 }
-
-func (m *AttachRequest) GetStdin() bool {
-	if m != nil {
-		return m.Stdin
-	}
-	return false
+func (m *RemoveContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveContainerRequest.Unmarshal(m, b)
 }
-
-func (m *AttachRequest) GetStdout() bool {
-	if m != nil {
-		return m.Stdout
-	}
-	return false
+func (m *RemoveContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveContainerRequest.Merge(dst, src)
+}
+func (m *RemoveContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveContainerRequest.Size(m)
+}
+func (m *RemoveContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveContainerRequest.DiscardUnknown(m)
 }
 
-func (m *AttachRequest) GetStderr() bool {
+var xxx_messageInfo_RemoveContainerRequest proto.InternalMessageInfo
+
+func (m *RemoveContainerRequest) GetContainerId() string {
 	if m != nil {
-		return m.Stderr
+		return m.ContainerId
 	}
-	return false
+	return ""
 }
 
-type AttachResponse struct {
-	Url                  string   `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+type RemoveContainerResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AttachResponse) Reset()         { *m = AttachResponse{} }
-func (m *AttachResponse) String() string { return proto.CompactTextString(m) }
-func (*AttachResponse) ProtoMessage()    {}
-func (*AttachResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_conman_882700af8b15a59a, []int{17}
+func (m *RemoveContainerResponse) Reset()         { *m = RemoveContainerResponse{} }
+func (m *RemoveContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveContainerResponse) ProtoMessage()    {}
+func (*RemoveContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{16}
 }
-func (m *AttachResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AttachResponse.Unmarshal(m, b)
+func (m *RemoveContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveContainerResponse.Unmarshal(m, b)
 }
-func (m *AttachResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AttachResponse.Marshal(b, m, deterministic)
+func (m *RemoveContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveContainerResponse.Marshal(b, m, deterministic)
 }
-func (dst *AttachResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AttachResponse.Merge(dst, src)
+func (dst *RemoveContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveContainerResponse.Merge(dst, src)
 }
-func (m *AttachResponse) XXX_Size() int {
-	return xxx_messageInfo_AttachResponse.Size(m)
+func (m *RemoveContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveContainerResponse.Size(m)
 }
-func (m *AttachResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AttachResponse.DiscardUnknown(m)
+func (m *RemoveContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveContainerResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AttachResponse proto.InternalMessageInfo
+var xxx_messageInfo_RemoveContainerResponse proto.InternalMessageInfo
 
-func (m *AttachResponse) GetUrl() string {
-	if m != nil {
-		return m.Url
-	}
-	return ""
+type ListContainersRequest struct {
+	Filter               *ContainerFilter `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func init() {
+func (m *ListContainersRequest) Reset()         { *m = ListContainersRequest{} }
+func (m *ListContainersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListContainersRequest) ProtoMessage()    {}
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{17}
+}
+func (m *ListContainersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersRequest.Unmarshal(m, b)
+}
+func (m *ListContainersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListContainersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersRequest.Merge(dst, src)
+}
+func (m *ListContainersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListContainersRequest.Size(m)
+}
+func (m *ListContainersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersRequest proto.InternalMessageInfo
+
+func (m *ListContainersRequest) GetFilter() *ContainerFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// All the specified conditions are ANDed.
+type ContainerFilter struct {
+	// Prefix of the container ID.
+	Id           string               `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	State        *ContainerStateValue `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+	PodSandboxId string               `protobuf:"bytes,3,opt,name=pod_sandbox_id,json=podSandboxId" json:"pod_sandbox_id,omitempty"`
+	// Containers must have all of these labels set to exactly these values.
+	LabelSelector map[string]string `protobuf:"bytes,4,rep,name=label_selector,json=labelSelector" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Exact container name.
+	Name                 string   `protobuf:"bytes,5,opt,name=name" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerFilter) Reset()         { *m = ContainerFilter{} }
+func (m *ContainerFilter) String() string { return proto.CompactTextString(m) }
+func (*ContainerFilter) ProtoMessage()    {}
+func (*ContainerFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{18}
+}
+func (m *ContainerFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerFilter.Unmarshal(m, b)
+}
+func (m *ContainerFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerFilter.Marshal(b, m, deterministic)
+}
+func (dst *ContainerFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerFilter.Merge(dst, src)
+}
+func (m *ContainerFilter) XXX_Size() int {
+	return xxx_messageInfo_ContainerFilter.Size(m)
+}
+func (m *ContainerFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerFilter proto.InternalMessageInfo
+
+func (m *ContainerFilter) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ContainerFilter) GetState() *ContainerStateValue {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *ContainerFilter) GetPodSandboxId() string {
+	if m != nil {
+		return m.PodSandboxId
+	}
+	return ""
+}
+
+func (m *ContainerFilter) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *ContainerFilter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// Wrapper to tell an unset state from CREATED.
+type ContainerStateValue struct {
+	State                ContainerState `protobuf:"varint,1,opt,name=state,enum=ContainerState" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ContainerStateValue) Reset()         { *m = ContainerStateValue{} }
+func (m *ContainerStateValue) String() string { return proto.CompactTextString(m) }
+func (*ContainerStateValue) ProtoMessage()    {}
+func (*ContainerStateValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{19}
+}
+func (m *ContainerStateValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerStateValue.Unmarshal(m, b)
+}
+func (m *ContainerStateValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerStateValue.Marshal(b, m, deterministic)
+}
+func (dst *ContainerStateValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerStateValue.Merge(dst, src)
+}
+func (m *ContainerStateValue) XXX_Size() int {
+	return xxx_messageInfo_ContainerStateValue.Size(m)
+}
+func (m *ContainerStateValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerStateValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerStateValue proto.InternalMessageInfo
+
+func (m *ContainerStateValue) GetState() ContainerState {
+	if m != nil {
+		return m.State
+	}
+	return ContainerState_CREATED
+}
+
+type ListContainersResponse struct {
+	Containers           []*Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListContainersResponse) Reset()         { *m = ListContainersResponse{} }
+func (m *ListContainersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListContainersResponse) ProtoMessage()    {}
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{20}
+}
+func (m *ListContainersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersResponse.Unmarshal(m, b)
+}
+func (m *ListContainersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListContainersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersResponse.Merge(dst, src)
+}
+func (m *ListContainersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListContainersResponse.Size(m)
+}
+func (m *ListContainersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersResponse proto.InternalMessageInfo
+
+func (m *ListContainersResponse) GetContainers() []*Container {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+type ContainerStatusRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerStatusRequest) Reset()         { *m = ContainerStatusRequest{} }
+func (m *ContainerStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ContainerStatusRequest) ProtoMessage()    {}
+func (*ContainerStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{21}
+}
+func (m *ContainerStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerStatusRequest.Unmarshal(m, b)
+}
+func (m *ContainerStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContainerStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerStatusRequest.Merge(dst, src)
+}
+func (m *ContainerStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ContainerStatusRequest.Size(m)
+}
+func (m *ContainerStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerStatusRequest proto.InternalMessageInfo
+
+func (m *ContainerStatusRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+type ContainerStatusResponse struct {
+	Status               *ContainerStatus `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ContainerStatusResponse) Reset()         { *m = ContainerStatusResponse{} }
+func (m *ContainerStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ContainerStatusResponse) ProtoMessage()    {}
+func (*ContainerStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{22}
+}
+func (m *ContainerStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerStatusResponse.Unmarshal(m, b)
+}
+func (m *ContainerStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *ContainerStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerStatusResponse.Merge(dst, src)
+}
+func (m *ContainerStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ContainerStatusResponse.Size(m)
+}
+func (m *ContainerStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerStatusResponse proto.InternalMessageInfo
+
+func (m *ContainerStatusResponse) GetStatus() *ContainerStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type WaitContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// State to wait for (or any later one). Defaults to EXITED.
+	Condition            *ContainerStateValue `protobuf:"bytes,2,opt,name=condition" json:"condition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WaitContainerRequest) Reset()         { *m = WaitContainerRequest{} }
+func (m *WaitContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*WaitContainerRequest) ProtoMessage()    {}
+func (*WaitContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{23}
+}
+func (m *WaitContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitContainerRequest.Unmarshal(m, b)
+}
+func (m *WaitContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *WaitContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitContainerRequest.Merge(dst, src)
+}
+func (m *WaitContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_WaitContainerRequest.Size(m)
+}
+func (m *WaitContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitContainerRequest proto.InternalMessageInfo
+
+func (m *WaitContainerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *WaitContainerRequest) GetCondition() *ContainerStateValue {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+type WaitContainerResponse struct {
+	ContainerId string         `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	State       ContainerState `protobuf:"varint,2,opt,name=state,enum=ContainerState" json:"state,omitempty"`
+	// Exit code, relevant only if state is EXITED.
+	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	// Number of the signal that killed the container, 0 otherwise.
+	Signal int32 `protobuf:"varint,4,opt,name=signal" json:"signal,omitempty"`
+	// Unix time in nanoseconds
+	FinishedAt int64 `protobuf:"varint,5,opt,name=finished_at,json=finishedAt" json:"finished_at,omitempty"`
+	// Termination reason, e.g. OOMKilled.
+	Reason string `protobuf:"bytes,6,opt,name=reason" json:"reason,omitempty"`
+	// Termination message (see ContainerStatus).
+	Message              string   `protobuf:"bytes,7,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WaitContainerResponse) Reset()         { *m = WaitContainerResponse{} }
+func (m *WaitContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*WaitContainerResponse) ProtoMessage()    {}
+func (*WaitContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{24}
+}
+func (m *WaitContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitContainerResponse.Unmarshal(m, b)
+}
+func (m *WaitContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitContainerResponse.Marshal(b, m, deterministic)
+}
+func (dst *WaitContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitContainerResponse.Merge(dst, src)
+}
+func (m *WaitContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_WaitContainerResponse.Size(m)
+}
+func (m *WaitContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitContainerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitContainerResponse proto.InternalMessageInfo
+
+func (m *WaitContainerResponse) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *WaitContainerResponse) GetState() ContainerState {
+	if m != nil {
+		return m.State
+	}
+	return ContainerState_CREATED
+}
+
+func (m *WaitContainerResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *WaitContainerResponse) GetSignal() int32 {
+	if m != nil {
+		return m.Signal
+	}
+	return 0
+}
+
+func (m *WaitContainerResponse) GetFinishedAt() int64 {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return 0
+}
+
+func (m *WaitContainerResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *WaitContainerResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type CopyFromContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// Absolute path inside of the container rootfs.
+	Path                 string   `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyFromContainerRequest) Reset()         { *m = CopyFromContainerRequest{} }
+func (m *CopyFromContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyFromContainerRequest) ProtoMessage()    {}
+func (*CopyFromContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{25}
+}
+func (m *CopyFromContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyFromContainerRequest.Unmarshal(m, b)
+}
+func (m *CopyFromContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyFromContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *CopyFromContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyFromContainerRequest.Merge(dst, src)
+}
+func (m *CopyFromContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyFromContainerRequest.Size(m)
+}
+func (m *CopyFromContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyFromContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyFromContainerRequest proto.InternalMessageInfo
+
+func (m *CopyFromContainerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *CopyFromContainerRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CopyChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyChunk) Reset()         { *m = CopyChunk{} }
+func (m *CopyChunk) String() string { return proto.CompactTextString(m) }
+func (*CopyChunk) ProtoMessage()    {}
+func (*CopyChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{26}
+}
+func (m *CopyChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyChunk.Unmarshal(m, b)
+}
+func (m *CopyChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyChunk.Marshal(b, m, deterministic)
+}
+func (dst *CopyChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyChunk.Merge(dst, src)
+}
+func (m *CopyChunk) XXX_Size() int {
+	return xxx_messageInfo_CopyChunk.Size(m)
+}
+func (m *CopyChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyChunk proto.InternalMessageInfo
+
+func (m *CopyChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CopyToContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// Absolute path inside of the container rootfs. If it's an existing
+	// directory, the archive content is placed inside of it.
+	Path                 string   `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyToContainerRequest) Reset()         { *m = CopyToContainerRequest{} }
+func (m *CopyToContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyToContainerRequest) ProtoMessage()    {}
+func (*CopyToContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{27}
+}
+func (m *CopyToContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyToContainerRequest.Unmarshal(m, b)
+}
+func (m *CopyToContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyToContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *CopyToContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyToContainerRequest.Merge(dst, src)
+}
+func (m *CopyToContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyToContainerRequest.Size(m)
+}
+func (m *CopyToContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyToContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyToContainerRequest proto.InternalMessageInfo
+
+func (m *CopyToContainerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *CopyToContainerRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CopyToContainerRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CopyToContainerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyToContainerResponse) Reset()         { *m = CopyToContainerResponse{} }
+func (m *CopyToContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*CopyToContainerResponse) ProtoMessage()    {}
+func (*CopyToContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{28}
+}
+func (m *CopyToContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyToContainerResponse.Unmarshal(m, b)
+}
+func (m *CopyToContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyToContainerResponse.Marshal(b, m, deterministic)
+}
+func (dst *CopyToContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyToContainerResponse.Merge(dst, src)
+}
+func (m *CopyToContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_CopyToContainerResponse.Size(m)
+}
+func (m *CopyToContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyToContainerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyToContainerResponse proto.InternalMessageInfo
+
+type ContainerChangesRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerChangesRequest) Reset()         { *m = ContainerChangesRequest{} }
+func (m *ContainerChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*ContainerChangesRequest) ProtoMessage()    {}
+func (*ContainerChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{29}
+}
+func (m *ContainerChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerChangesRequest.Unmarshal(m, b)
+}
+func (m *ContainerChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerChangesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContainerChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerChangesRequest.Merge(dst, src)
+}
+func (m *ContainerChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_ContainerChangesRequest.Size(m)
+}
+func (m *ContainerChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerChangesRequest proto.InternalMessageInfo
+
+func (m *ContainerChangesRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+type ContainerChangesResponse struct {
+	// Sorted by path.
+	Changes              []*ContainerChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ContainerChangesResponse) Reset()         { *m = ContainerChangesResponse{} }
+func (m *ContainerChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*ContainerChangesResponse) ProtoMessage()    {}
+func (*ContainerChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{30}
+}
+func (m *ContainerChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerChangesResponse.Unmarshal(m, b)
+}
+func (m *ContainerChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerChangesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ContainerChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerChangesResponse.Merge(dst, src)
+}
+func (m *ContainerChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_ContainerChangesResponse.Size(m)
+}
+func (m *ContainerChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerChangesResponse proto.InternalMessageInfo
+
+func (m *ContainerChangesResponse) GetChanges() []*ContainerChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type ContainerChange struct {
+	Path                 string     `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Kind                 ChangeKind `protobuf:"varint,2,opt,name=kind,enum=ChangeKind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ContainerChange) Reset()         { *m = ContainerChange{} }
+func (m *ContainerChange) String() string { return proto.CompactTextString(m) }
+func (*ContainerChange) ProtoMessage()    {}
+func (*ContainerChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{31}
+}
+func (m *ContainerChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerChange.Unmarshal(m, b)
+}
+func (m *ContainerChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerChange.Marshal(b, m, deterministic)
+}
+func (dst *ContainerChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerChange.Merge(dst, src)
+}
+func (m *ContainerChange) XXX_Size() int {
+	return xxx_messageInfo_ContainerChange.Size(m)
+}
+func (m *ContainerChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerChange proto.InternalMessageInfo
+
+func (m *ContainerChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ContainerChange) GetKind() ChangeKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ChangeKind_MODIFIED
+}
+
+type ContainerTopRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerTopRequest) Reset()         { *m = ContainerTopRequest{} }
+func (m *ContainerTopRequest) String() string { return proto.CompactTextString(m) }
+func (*ContainerTopRequest) ProtoMessage()    {}
+func (*ContainerTopRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{32}
+}
+func (m *ContainerTopRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerTopRequest.Unmarshal(m, b)
+}
+func (m *ContainerTopRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerTopRequest.Marshal(b, m, deterministic)
+}
+func (dst *ContainerTopRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerTopRequest.Merge(dst, src)
+}
+func (m *ContainerTopRequest) XXX_Size() int {
+	return xxx_messageInfo_ContainerTopRequest.Size(m)
+}
+func (m *ContainerTopRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerTopRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerTopRequest proto.InternalMessageInfo
+
+func (m *ContainerTopRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+type ContainerTopResponse struct {
+	Processes            []*Process `protobuf:"bytes,1,rep,name=processes" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ContainerTopResponse) Reset()         { *m = ContainerTopResponse{} }
+func (m *ContainerTopResponse) String() string { return proto.CompactTextString(m) }
+func (*ContainerTopResponse) ProtoMessage()    {}
+func (*ContainerTopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{33}
+}
+func (m *ContainerTopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerTopResponse.Unmarshal(m, b)
+}
+func (m *ContainerTopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerTopResponse.Marshal(b, m, deterministic)
+}
+func (dst *ContainerTopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerTopResponse.Merge(dst, src)
+}
+func (m *ContainerTopResponse) XXX_Size() int {
+	return xxx_messageInfo_ContainerTopResponse.Size(m)
+}
+func (m *ContainerTopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerTopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerTopResponse proto.InternalMessageInfo
+
+func (m *ContainerTopResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type Process struct {
+	// PID in the host PID namespace.
+	Pid int32 `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
+	// PID in the container PID namespace.
+	NsPid int32  `protobuf:"varint,2,opt,name=ns_pid,json=nsPid" json:"ns_pid,omitempty"`
+	Uid   uint32 `protobuf:"varint,3,opt,name=uid" json:"uid,omitempty"`
+	User  string `protobuf:"bytes,4,opt,name=user" json:"user,omitempty"`
+	// Total (user + system) CPU time in nanoseconds.
+	CpuTime int64 `protobuf:"varint,5,opt,name=cpu_time,json=cpuTime" json:"cpu_time,omitempty"`
+	// Resident set size in bytes.
+	Rss                  int64    `protobuf:"varint,6,opt,name=rss" json:"rss,omitempty"`
+	Command              string   `protobuf:"bytes,7,opt,name=command" json:"command,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()    {}
+func (*Process) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{34}
+}
+func (m *Process) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Process.Unmarshal(m, b)
+}
+func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+}
+func (dst *Process) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Process.Merge(dst, src)
+}
+func (m *Process) XXX_Size() int {
+	return xxx_messageInfo_Process.Size(m)
+}
+func (m *Process) XXX_DiscardUnknown() {
+	xxx_messageInfo_Process.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Process proto.InternalMessageInfo
+
+func (m *Process) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Process) GetNsPid() int32 {
+	if m != nil {
+		return m.NsPid
+	}
+	return 0
+}
+
+func (m *Process) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *Process) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Process) GetCpuTime() int64 {
+	if m != nil {
+		return m.CpuTime
+	}
+	return 0
+}
+
+func (m *Process) GetRss() int64 {
+	if m != nil {
+		return m.Rss
+	}
+	return 0
+}
+
+func (m *Process) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+type ExportContainerRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportContainerRequest) Reset()         { *m = ExportContainerRequest{} }
+func (m *ExportContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportContainerRequest) ProtoMessage()    {}
+func (*ExportContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{35}
+}
+func (m *ExportContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportContainerRequest.Unmarshal(m, b)
+}
+func (m *ExportContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportContainerRequest.Merge(dst, src)
+}
+func (m *ExportContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportContainerRequest.Size(m)
+}
+func (m *ExportContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportContainerRequest proto.InternalMessageInfo
+
+func (m *ExportContainerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+type CommitContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// Optional name:tag of the new image.
+	ImageName string `protobuf:"bytes,2,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+	// Added to the image config labels.
+	Labels               map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Author               string            `protobuf:"bytes,4,opt,name=author" json:"author,omitempty"`
+	Message              string            `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CommitContainerRequest) Reset()         { *m = CommitContainerRequest{} }
+func (m *CommitContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitContainerRequest) ProtoMessage()    {}
+func (*CommitContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{36}
+}
+func (m *CommitContainerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitContainerRequest.Unmarshal(m, b)
+}
+func (m *CommitContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitContainerRequest.Marshal(b, m, deterministic)
+}
+func (dst *CommitContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitContainerRequest.Merge(dst, src)
+}
+func (m *CommitContainerRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitContainerRequest.Size(m)
+}
+func (m *CommitContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitContainerRequest proto.InternalMessageInfo
+
+func (m *CommitContainerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *CommitContainerRequest) GetImageName() string {
+	if m != nil {
+		return m.ImageName
+	}
+	return ""
+}
+
+func (m *CommitContainerRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *CommitContainerRequest) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *CommitContainerRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type CommitContainerResponse struct {
+	ImageId              string   `protobuf:"bytes,1,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitContainerResponse) Reset()         { *m = CommitContainerResponse{} }
+func (m *CommitContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitContainerResponse) ProtoMessage()    {}
+func (*CommitContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{37}
+}
+func (m *CommitContainerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitContainerResponse.Unmarshal(m, b)
+}
+func (m *CommitContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitContainerResponse.Marshal(b, m, deterministic)
+}
+func (dst *CommitContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitContainerResponse.Merge(dst, src)
+}
+func (m *CommitContainerResponse) XXX_Size() int {
+	return xxx_messageInfo_CommitContainerResponse.Size(m)
+}
+func (m *CommitContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitContainerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitContainerResponse proto.InternalMessageInfo
+
+func (m *CommitContainerResponse) GetImageId() string {
+	if m != nil {
+		return m.ImageId
+	}
+	return ""
+}
+
+type ListImagesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListImagesRequest) Reset()         { *m = ListImagesRequest{} }
+func (m *ListImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListImagesRequest) ProtoMessage()    {}
+func (*ListImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{38}
+}
+func (m *ListImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListImagesRequest.Unmarshal(m, b)
+}
+func (m *ListImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListImagesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListImagesRequest.Merge(dst, src)
+}
+func (m *ListImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListImagesRequest.Size(m)
+}
+func (m *ListImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListImagesRequest proto.InternalMessageInfo
+
+type ListImagesResponse struct {
+	Images               []*Image `protobuf:"bytes,1,rep,name=images" json:"images,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListImagesResponse) Reset()         { *m = ListImagesResponse{} }
+func (m *ListImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListImagesResponse) ProtoMessage()    {}
+func (*ListImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{39}
+}
+func (m *ListImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListImagesResponse.Unmarshal(m, b)
+}
+func (m *ListImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListImagesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListImagesResponse.Merge(dst, src)
+}
+func (m *ListImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListImagesResponse.Size(m)
+}
+func (m *ListImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListImagesResponse proto.InternalMessageInfo
+
+func (m *ListImagesResponse) GetImages() []*Image {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type ImportImageRequest struct {
+	// Optional name:tag of the new image.
+	ImageName            string   `protobuf:"bytes,1,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportImageRequest) Reset()         { *m = ImportImageRequest{} }
+func (m *ImportImageRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportImageRequest) ProtoMessage()    {}
+func (*ImportImageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{40}
+}
+func (m *ImportImageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportImageRequest.Unmarshal(m, b)
+}
+func (m *ImportImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportImageRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportImageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportImageRequest.Merge(dst, src)
+}
+func (m *ImportImageRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportImageRequest.Size(m)
+}
+func (m *ImportImageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportImageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportImageRequest proto.InternalMessageInfo
+
+func (m *ImportImageRequest) GetImageName() string {
+	if m != nil {
+		return m.ImageName
+	}
+	return ""
+}
+
+func (m *ImportImageRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportImageResponse struct {
+	ImageId              string   `protobuf:"bytes,1,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportImageResponse) Reset()         { *m = ImportImageResponse{} }
+func (m *ImportImageResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportImageResponse) ProtoMessage()    {}
+func (*ImportImageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{41}
+}
+func (m *ImportImageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportImageResponse.Unmarshal(m, b)
+}
+func (m *ImportImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportImageResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportImageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportImageResponse.Merge(dst, src)
+}
+func (m *ImportImageResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportImageResponse.Size(m)
+}
+func (m *ImportImageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportImageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportImageResponse proto.InternalMessageInfo
+
+func (m *ImportImageResponse) GetImageId() string {
+	if m != nil {
+		return m.ImageId
+	}
+	return ""
+}
+
+type Image struct {
+	Id        string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Names     []string `protobuf:"bytes,2,rep,name=names" json:"names,omitempty"`
+	CreatedAt int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	// Total size of the image layers.
+	Size                 int64    `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Image) Reset()         { *m = Image{} }
+func (m *Image) String() string { return proto.CompactTextString(m) }
+func (*Image) ProtoMessage()    {}
+func (*Image) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{42}
+}
+func (m *Image) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Image.Unmarshal(m, b)
+}
+func (m *Image) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Image.Marshal(b, m, deterministic)
+}
+func (dst *Image) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Image.Merge(dst, src)
+}
+func (m *Image) XXX_Size() int {
+	return xxx_messageInfo_Image.Size(m)
+}
+func (m *Image) XXX_DiscardUnknown() {
+	xxx_messageInfo_Image.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Image proto.InternalMessageInfo
+
+func (m *Image) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Image) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *Image) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Image) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type Container struct {
+	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Unix time in nanoseconds
+	CreatedAt   int64             `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	State       ContainerState    `protobuf:"varint,4,opt,name=state,enum=ContainerState" json:"state,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Exit code, relevant only if state is EXITED.
+	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	// Rootfs (image) the container has been created from.
+	Image                string   `protobuf:"bytes,8,opt,name=image" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Container) Reset()         { *m = Container{} }
+func (m *Container) String() string { return proto.CompactTextString(m) }
+func (*Container) ProtoMessage()    {}
+func (*Container) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{43}
+}
+func (m *Container) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Container.Unmarshal(m, b)
+}
+func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+}
+func (dst *Container) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Container.Merge(dst, src)
+}
+func (m *Container) XXX_Size() int {
+	return xxx_messageInfo_Container.Size(m)
+}
+func (m *Container) XXX_DiscardUnknown() {
+	xxx_messageInfo_Container.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Container proto.InternalMessageInfo
+
+func (m *Container) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Container) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Container) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Container) GetState() ContainerState {
+	if m != nil {
+		return m.State
+	}
+	return ContainerState_CREATED
+}
+
+func (m *Container) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Container) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Container) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *Container) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+type ContainerStatus struct {
+	ContainerId   string         `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	ContainerName string         `protobuf:"bytes,2,opt,name=container_name,json=containerName" json:"container_name,omitempty"`
+	State         ContainerState `protobuf:"varint,3,opt,name=state,enum=ContainerState" json:"state,omitempty"`
+	// Unix time in nanoseconds
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	// Unix time in nanoseconds
+	StartedAt int64 `protobuf:"varint,5,opt,name=started_at,json=startedAt" json:"started_at,omitempty"`
+	// Unix time in nanoseconds
+	FinishedAt int64 `protobuf:"varint,6,opt,name=finished_at,json=finishedAt" json:"finished_at,omitempty"`
+	// Exit code, relevant only if finished_at != 0.
+	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	// Termination message left by the container process in
+	// the termination message file or, if there is none and
+	// the container has failed, the tail of its log.
+	Message string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
+	// Relative to conman's log dir path to container's log file.
+	LogPath     string            `protobuf:"bytes,9,opt,name=log_path,json=logPath" json:"log_path,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,10,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations map[string]string `protobuf:"bytes,11,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Rootfs (image) the container has been created from.
+	Image          string   `protobuf:"bytes,12,opt,name=image" json:"image,omitempty"`
+	Mounts         []*Mount `protobuf:"bytes,13,rep,name=mounts" json:"mounts,omitempty"`
+	SeccompProfile string   `protobuf:"bytes,14,opt,name=seccomp_profile,json=seccompProfile" json:"seccomp_profile,omitempty"`
+	// Bounding, effective, permitted, and inheritable sets.
+	Capabilities    []string `protobuf:"bytes,15,rep,name=capabilities" json:"capabilities,omitempty"`
+	Privileged      bool     `protobuf:"varint,16,opt,name=privileged" json:"privileged,omitempty"`
+	NoNewPrivileges bool     `protobuf:"varint,17,opt,name=no_new_privileges,json=noNewPrivileges" json:"no_new_privileges,omitempty"`
+	// Set if the container has its own user namespace.
+	UidMappings []*IDMapping `protobuf:"bytes,18,rep,name=uid_mappings,json=uidMappings" json:"uid_mappings,omitempty"`
+	GidMappings []*IDMapping `protobuf:"bytes,19,rep,name=gid_mappings,json=gidMappings" json:"gid_mappings,omitempty"`
+	// The target_id is the full container ID.
+	NamespaceOptions *NamespaceOption  `protobuf:"bytes,20,opt,name=namespace_options,json=namespaceOptions" json:"namespace_options,omitempty"`
+	MaskedPaths      []string          `protobuf:"bytes,21,rep,name=masked_paths,json=maskedPaths" json:"masked_paths,omitempty"`
+	ReadonlyPaths    []string          `protobuf:"bytes,22,rep,name=readonly_paths,json=readonlyPaths" json:"readonly_paths,omitempty"`
+	Sysctls          map[string]string `protobuf:"bytes,23,rep,name=sysctls" json:"sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Devices          []*Device         `protobuf:"bytes,24,rep,name=devices" json:"devices,omitempty"`
+	CdiDevices       []string          `protobuf:"bytes,25,rep,name=cdi_devices,json=cdiDevices" json:"cdi_devices,omitempty"`
+	// The daemon defaults included.
+	Rlimits     []*Rlimit `protobuf:"bytes,26,rep,name=rlimits" json:"rlimits,omitempty"`
+	OomScoreAdj int32     `protobuf:"varint,27,opt,name=oom_score_adj,json=oomScoreAdj" json:"oom_score_adj,omitempty"`
+	// Brief CamelCase reason of the container termination: Completed,
+	// Error, OOMKilled, Signaled, or ContainerCannotRun.
+	Reason string `protobuf:"bytes,28,opt,name=reason" json:"reason,omitempty"`
+	// Number of the signal that killed the container, 0 otherwise.
+	Signal                 int32    `protobuf:"varint,29,opt,name=signal" json:"signal,omitempty"`
+	TerminationMessagePath string   `protobuf:"bytes,30,opt,name=termination_message_path,json=terminationMessagePath" json:"termination_message_path,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ContainerStatus) Reset()         { *m = ContainerStatus{} }
+func (m *ContainerStatus) String() string { return proto.CompactTextString(m) }
+func (*ContainerStatus) ProtoMessage()    {}
+func (*ContainerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{44}
+}
+func (m *ContainerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerStatus.Unmarshal(m, b)
+}
+func (m *ContainerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerStatus.Marshal(b, m, deterministic)
+}
+func (dst *ContainerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerStatus.Merge(dst, src)
+}
+func (m *ContainerStatus) XXX_Size() int {
+	return xxx_messageInfo_ContainerStatus.Size(m)
+}
+func (m *ContainerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerStatus proto.InternalMessageInfo
+
+func (m *ContainerStatus) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetContainerName() string {
+	if m != nil {
+		return m.ContainerName
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetState() ContainerState {
+	if m != nil {
+		return m.State
+	}
+	return ContainerState_CREATED
+}
+
+func (m *ContainerStatus) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetFinishedAt() int64 {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetLogPath() string {
+	if m != nil {
+		return m.LogPath
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetMounts() []*Mount {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetSeccompProfile() string {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetPrivileged() bool {
+	if m != nil {
+		return m.Privileged
+	}
+	return false
+}
+
+func (m *ContainerStatus) GetNoNewPrivileges() bool {
+	if m != nil {
+		return m.NoNewPrivileges
+	}
+	return false
+}
+
+func (m *ContainerStatus) GetUidMappings() []*IDMapping {
+	if m != nil {
+		return m.UidMappings
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetGidMappings() []*IDMapping {
+	if m != nil {
+		return m.GidMappings
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetNamespaceOptions() *NamespaceOption {
+	if m != nil {
+		return m.NamespaceOptions
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetMaskedPaths() []string {
+	if m != nil {
+		return m.MaskedPaths
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetReadonlyPaths() []string {
+	if m != nil {
+		return m.ReadonlyPaths
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetSysctls() map[string]string {
+	if m != nil {
+		return m.Sysctls
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetCdiDevices() []string {
+	if m != nil {
+		return m.CdiDevices
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetRlimits() []*Rlimit {
+	if m != nil {
+		return m.Rlimits
+	}
+	return nil
+}
+
+func (m *ContainerStatus) GetOomScoreAdj() int32 {
+	if m != nil {
+		return m.OomScoreAdj
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ContainerStatus) GetSignal() int32 {
+	if m != nil {
+		return m.Signal
+	}
+	return 0
+}
+
+func (m *ContainerStatus) GetTerminationMessagePath() string {
+	if m != nil {
+		return m.TerminationMessagePath
+	}
+	return ""
+}
+
+type AttachRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	Tty                  bool     `protobuf:"varint,2,opt,name=tty" json:"tty,omitempty"`
+	Stdin                bool     `protobuf:"varint,3,opt,name=stdin" json:"stdin,omitempty"`
+	Stdout               bool     `protobuf:"varint,4,opt,name=stdout" json:"stdout,omitempty"`
+	Stderr               bool     `protobuf:"varint,5,opt,name=stderr" json:"stderr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttachRequest) Reset()         { *m = AttachRequest{} }
+func (m *AttachRequest) String() string { return proto.CompactTextString(m) }
+func (*AttachRequest) ProtoMessage()    {}
+func (*AttachRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{45}
+}
+func (m *AttachRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachRequest.Unmarshal(m, b)
+}
+func (m *AttachRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachRequest.Marshal(b, m, deterministic)
+}
+func (dst *AttachRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachRequest.Merge(dst, src)
+}
+func (m *AttachRequest) XXX_Size() int {
+	return xxx_messageInfo_AttachRequest.Size(m)
+}
+func (m *AttachRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachRequest proto.InternalMessageInfo
+
+func (m *AttachRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *AttachRequest) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *AttachRequest) GetStdin() bool {
+	if m != nil {
+		return m.Stdin
+	}
+	return false
+}
+
+func (m *AttachRequest) GetStdout() bool {
+	if m != nil {
+		return m.Stdout
+	}
+	return false
+}
+
+func (m *AttachRequest) GetStderr() bool {
+	if m != nil {
+		return m.Stderr
+	}
+	return false
+}
+
+type AttachResponse struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttachResponse) Reset()         { *m = AttachResponse{} }
+func (m *AttachResponse) String() string { return proto.CompactTextString(m) }
+func (*AttachResponse) ProtoMessage()    {}
+func (*AttachResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{46}
+}
+func (m *AttachResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachResponse.Unmarshal(m, b)
+}
+func (m *AttachResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachResponse.Marshal(b, m, deterministic)
+}
+func (dst *AttachResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachResponse.Merge(dst, src)
+}
+func (m *AttachResponse) XXX_Size() int {
+	return xxx_messageInfo_AttachResponse.Size(m)
+}
+func (m *AttachResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachResponse proto.InternalMessageInfo
+
+func (m *AttachResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type Volume struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Unix time in nanoseconds
+	CreatedAt int64             `protobuf:"varint,2,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	Labels    map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Host path of the volume content.
+	Path string `protobuf:"bytes,4,opt,name=path" json:"path,omitempty"`
+	// Containers referencing the volume.
+	ContainerIds         []string `protobuf:"bytes,5,rep,name=container_ids,json=containerIds" json:"container_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{47}
+}
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Volume.Unmarshal(m, b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+}
+func (dst *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(dst, src)
+}
+func (m *Volume) XXX_Size() int {
+	return xxx_messageInfo_Volume.Size(m)
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Volume) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Volume) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Volume) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Volume) GetContainerIds() []string {
+	if m != nil {
+		return m.ContainerIds
+	}
+	return nil
+}
+
+type CreateVolumeRequest struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CreateVolumeRequest) Reset()         { *m = CreateVolumeRequest{} }
+func (m *CreateVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateVolumeRequest) ProtoMessage()    {}
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{48}
+}
+func (m *CreateVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateVolumeRequest.Unmarshal(m, b)
+}
+func (m *CreateVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateVolumeRequest.Merge(dst, src)
+}
+func (m *CreateVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateVolumeRequest.Size(m)
+}
+func (m *CreateVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateVolumeRequest proto.InternalMessageInfo
+
+func (m *CreateVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateVolumeRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type CreateVolumeResponse struct {
+	Volume               *Volume  `protobuf:"bytes,1,opt,name=volume" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateVolumeResponse) Reset()         { *m = CreateVolumeResponse{} }
+func (m *CreateVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateVolumeResponse) ProtoMessage()    {}
+func (*CreateVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{49}
+}
+func (m *CreateVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateVolumeResponse.Unmarshal(m, b)
+}
+func (m *CreateVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateVolumeResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateVolumeResponse.Merge(dst, src)
+}
+func (m *CreateVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateVolumeResponse.Size(m)
+}
+func (m *CreateVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateVolumeResponse proto.InternalMessageInfo
+
+func (m *CreateVolumeResponse) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type ListVolumesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumesRequest) Reset()         { *m = ListVolumesRequest{} }
+func (m *ListVolumesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesRequest) ProtoMessage()    {}
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{50}
+}
+func (m *ListVolumesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesRequest.Unmarshal(m, b)
+}
+func (m *ListVolumesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListVolumesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesRequest.Merge(dst, src)
+}
+func (m *ListVolumesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesRequest.Size(m)
+}
+func (m *ListVolumesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesRequest proto.InternalMessageInfo
+
+type ListVolumesResponse struct {
+	Volumes              []*Volume `protobuf:"bytes,1,rep,name=volumes" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListVolumesResponse) Reset()         { *m = ListVolumesResponse{} }
+func (m *ListVolumesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesResponse) ProtoMessage()    {}
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{51}
+}
+func (m *ListVolumesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesResponse.Unmarshal(m, b)
+}
+func (m *ListVolumesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListVolumesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesResponse.Merge(dst, src)
+}
+func (m *ListVolumesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesResponse.Size(m)
+}
+func (m *ListVolumesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesResponse proto.InternalMessageInfo
+
+func (m *ListVolumesResponse) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type InspectVolumeRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectVolumeRequest) Reset()         { *m = InspectVolumeRequest{} }
+func (m *InspectVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectVolumeRequest) ProtoMessage()    {}
+func (*InspectVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{52}
+}
+func (m *InspectVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectVolumeRequest.Unmarshal(m, b)
+}
+func (m *InspectVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *InspectVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectVolumeRequest.Merge(dst, src)
+}
+func (m *InspectVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectVolumeRequest.Size(m)
+}
+func (m *InspectVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectVolumeRequest proto.InternalMessageInfo
+
+func (m *InspectVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type InspectVolumeResponse struct {
+	Volume               *Volume  `protobuf:"bytes,1,opt,name=volume" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectVolumeResponse) Reset()         { *m = InspectVolumeResponse{} }
+func (m *InspectVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectVolumeResponse) ProtoMessage()    {}
+func (*InspectVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{53}
+}
+func (m *InspectVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectVolumeResponse.Unmarshal(m, b)
+}
+func (m *InspectVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectVolumeResponse.Marshal(b, m, deterministic)
+}
+func (dst *InspectVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectVolumeResponse.Merge(dst, src)
+}
+func (m *InspectVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectVolumeResponse.Size(m)
+}
+func (m *InspectVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectVolumeResponse proto.InternalMessageInfo
+
+func (m *InspectVolumeResponse) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type RemoveVolumeRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveVolumeRequest) Reset()         { *m = RemoveVolumeRequest{} }
+func (m *RemoveVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveVolumeRequest) ProtoMessage()    {}
+func (*RemoveVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{54}
+}
+func (m *RemoveVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveVolumeRequest.Unmarshal(m, b)
+}
+func (m *RemoveVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveVolumeRequest.Merge(dst, src)
+}
+func (m *RemoveVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveVolumeRequest.Size(m)
+}
+func (m *RemoveVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveVolumeRequest proto.InternalMessageInfo
+
+func (m *RemoveVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RemoveVolumeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveVolumeResponse) Reset()         { *m = RemoveVolumeResponse{} }
+func (m *RemoveVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveVolumeResponse) ProtoMessage()    {}
+func (*RemoveVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_conman_a4cc490026972f9a, []int{55}
+}
+func (m *RemoveVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveVolumeResponse.Unmarshal(m, b)
+}
+func (m *RemoveVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveVolumeResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemoveVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveVolumeResponse.Merge(dst, src)
+}
+func (m *RemoveVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveVolumeResponse.Size(m)
+}
+func (m *RemoveVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveVolumeResponse proto.InternalMessageInfo
+
+func init() {
 	proto.RegisterType((*VersionRequest)(nil), "VersionRequest")
 	proto.RegisterType((*VersionResponse)(nil), "VersionResponse")
 	proto.RegisterType((*CreateContainerRequest)(nil), "CreateContainerRequest")
+	proto.RegisterMapType((map[string]string)(nil), "CreateContainerRequest.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "CreateContainerRequest.LabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "CreateContainerRequest.SysctlsEntry")
+	proto.RegisterType((*Rlimit)(nil), "Rlimit")
+	proto.RegisterType((*Device)(nil), "Device")
+	proto.RegisterType((*IDMapping)(nil), "IDMapping")
+	proto.RegisterType((*NamespaceOption)(nil), "NamespaceOption")
+	proto.RegisterType((*Mount)(nil), "Mount")
 	proto.RegisterType((*CreateContainerResponse)(nil), "CreateContainerResponse")
 	proto.RegisterType((*StartContainerRequest)(nil), "StartContainerRequest")
 	proto.RegisterType((*StartContainerResponse)(nil), "StartContainerResponse")
+	proto.RegisterType((*RunContainerRequest)(nil), "RunContainerRequest")
+	proto.RegisterType((*RunContainerResponse)(nil), "RunContainerResponse")
 	proto.RegisterType((*StopContainerRequest)(nil), "StopContainerRequest")
 	proto.RegisterType((*StopContainerResponse)(nil), "StopContainerResponse")
 	proto.RegisterType((*RemoveContainerRequest)(nil), "RemoveContainerRequest")
 	proto.RegisterType((*RemoveContainerResponse)(nil), "RemoveContainerResponse")
 	proto.RegisterType((*ListContainersRequest)(nil), "ListContainersRequest")
+	proto.RegisterType((*ContainerFilter)(nil), "ContainerFilter")
+	proto.RegisterMapType((map[string]string)(nil), "ContainerFilter.LabelSelectorEntry")
+	proto.RegisterType((*ContainerStateValue)(nil), "ContainerStateValue")
 	proto.RegisterType((*ListContainersResponse)(nil), "ListContainersResponse")
 	proto.RegisterType((*ContainerStatusRequest)(nil), "ContainerStatusRequest")
 	proto.RegisterType((*ContainerStatusResponse)(nil), "ContainerStatusResponse")
+	proto.RegisterType((*WaitContainerRequest)(nil), "WaitContainerRequest")
+	proto.RegisterType((*WaitContainerResponse)(nil), "WaitContainerResponse")
+	proto.RegisterType((*CopyFromContainerRequest)(nil), "CopyFromContainerRequest")
+	proto.RegisterType((*CopyChunk)(nil), "CopyChunk")
+	proto.RegisterType((*CopyToContainerRequest)(nil), "CopyToContainerRequest")
+	proto.RegisterType((*CopyToContainerResponse)(nil), "CopyToContainerResponse")
+	proto.RegisterType((*ContainerChangesRequest)(nil), "ContainerChangesRequest")
+	proto.RegisterType((*ContainerChangesResponse)(nil), "ContainerChangesResponse")
+	proto.RegisterType((*ContainerChange)(nil), "ContainerChange")
+	proto.RegisterType((*ContainerTopRequest)(nil), "ContainerTopRequest")
+	proto.RegisterType((*ContainerTopResponse)(nil), "ContainerTopResponse")
+	proto.RegisterType((*Process)(nil), "Process")
+	proto.RegisterType((*ExportContainerRequest)(nil), "ExportContainerRequest")
+	proto.RegisterType((*CommitContainerRequest)(nil), "CommitContainerRequest")
+	proto.RegisterMapType((map[string]string)(nil), "CommitContainerRequest.LabelsEntry")
+	proto.RegisterType((*CommitContainerResponse)(nil), "CommitContainerResponse")
+	proto.RegisterType((*ListImagesRequest)(nil), "ListImagesRequest")
+	proto.RegisterType((*ListImagesResponse)(nil), "ListImagesResponse")
+	proto.RegisterType((*ImportImageRequest)(nil), "ImportImageRequest")
+	proto.RegisterType((*ImportImageResponse)(nil), "ImportImageResponse")
+	proto.RegisterType((*Image)(nil), "Image")
 	proto.RegisterType((*Container)(nil), "Container")
+	proto.RegisterMapType((map[string]string)(nil), "Container.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "Container.LabelsEntry")
 	proto.RegisterType((*ContainerStatus)(nil), "ContainerStatus")
+	proto.RegisterMapType((map[string]string)(nil), "ContainerStatus.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "ContainerStatus.LabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "ContainerStatus.SysctlsEntry")
 	proto.RegisterType((*AttachRequest)(nil), "AttachRequest")
 	proto.RegisterType((*AttachResponse)(nil), "AttachResponse")
+	proto.RegisterType((*Volume)(nil), "Volume")
+	proto.RegisterMapType((map[string]string)(nil), "Volume.LabelsEntry")
+	proto.RegisterType((*CreateVolumeRequest)(nil), "CreateVolumeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "CreateVolumeRequest.LabelsEntry")
+	proto.RegisterType((*CreateVolumeResponse)(nil), "CreateVolumeResponse")
+	proto.RegisterType((*ListVolumesRequest)(nil), "ListVolumesRequest")
+	proto.RegisterType((*ListVolumesResponse)(nil), "ListVolumesResponse")
+	proto.RegisterType((*InspectVolumeRequest)(nil), "InspectVolumeRequest")
+	proto.RegisterType((*InspectVolumeResponse)(nil), "InspectVolumeResponse")
+	proto.RegisterType((*RemoveVolumeRequest)(nil), "RemoveVolumeRequest")
+	proto.RegisterType((*RemoveVolumeResponse)(nil), "RemoveVolumeResponse")
+	proto.RegisterEnum("ProcMount", ProcMount_name, ProcMount_value)
+	proto.RegisterEnum("MountType", MountType_name, MountType_value)
+	proto.RegisterEnum("NamespaceMode", NamespaceMode_name, NamespaceMode_value)
+	proto.RegisterEnum("MountPropagation", MountPropagation_name, MountPropagation_value)
+	proto.RegisterEnum("ChangeKind", ChangeKind_name, ChangeKind_value)
 	proto.RegisterEnum("ContainerState", ContainerState_name, ContainerState_value)
 }
 
@@ -930,91 +3426,338 @@ const _ = grpc.SupportPackageIsVersion4
 
 // Client API for Conman service
 
-type ConmanClient interface {
-	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
-	CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error)
-	StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error)
-	StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error)
-	RemoveContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*RemoveContainerResponse, error)
-	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
-	ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error)
-	Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error)
+type ConmanClient interface {
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error)
+	StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error)
+	RunContainer(ctx context.Context, in *RunContainerRequest, opts ...grpc.CallOption) (*RunContainerResponse, error)
+	StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error)
+	RemoveContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*RemoveContainerResponse, error)
+	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
+	ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error)
+	// Long-poll: blocks until the requested condition is met.
+	WaitContainer(ctx context.Context, in *WaitContainerRequest, opts ...grpc.CallOption) (*WaitContainerResponse, error)
+	// Streams a tar archive of a container path.
+	CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (Conman_CopyFromContainerClient, error)
+	// Extracts a streamed tar archive to a container path. The first
+	// message must specify the container and the path.
+	CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (Conman_CopyToContainerClient, error)
+	// Lists paths changed in the container rootfs since its creation.
+	ContainerChanges(ctx context.Context, in *ContainerChangesRequest, opts ...grpc.CallOption) (*ContainerChangesResponse, error)
+	// Lists processes of a running container.
+	ContainerTop(ctx context.Context, in *ContainerTopRequest, opts ...grpc.CallOption) (*ContainerTopResponse, error)
+	// Streams a tar archive of the whole container rootfs.
+	ExportContainer(ctx context.Context, in *ExportContainerRequest, opts ...grpc.CallOption) (Conman_ExportContainerClient, error)
+	// Creates a new image from the container rootfs.
+	CommitContainer(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error)
+	Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error)
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
+	// Creates a single-layer image from a streamed (possibly gzip-ed)
+	// rootfs tar archive. The first message may specify the image name.
+	ImportImage(ctx context.Context, opts ...grpc.CallOption) (Conman_ImportImageClient, error)
+	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error)
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	InspectVolume(ctx context.Context, in *InspectVolumeRequest, opts ...grpc.CallOption) (*InspectVolumeResponse, error)
+	// Fails if the volume is referenced by a container (in any state).
+	RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*RemoveVolumeResponse, error)
+}
+
+type conmanClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewConmanClient(cc *grpc.ClientConn) ConmanClient {
+	return &conmanClient{cc}
+}
+
+func (c *conmanClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := grpc.Invoke(ctx, "/Conman/Version", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error) {
+	out := new(CreateContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/CreateContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error) {
+	out := new(StartContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/StartContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) RunContainer(ctx context.Context, in *RunContainerRequest, opts ...grpc.CallOption) (*RunContainerResponse, error) {
+	out := new(RunContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/RunContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error) {
+	out := new(StopContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/StopContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) RemoveContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*RemoveContainerResponse, error) {
+	out := new(RemoveContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/RemoveContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
+	out := new(ListContainersResponse)
+	err := grpc.Invoke(ctx, "/Conman/ListContainers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error) {
+	out := new(ContainerStatusResponse)
+	err := grpc.Invoke(ctx, "/Conman/ContainerStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) WaitContainer(ctx context.Context, in *WaitContainerRequest, opts ...grpc.CallOption) (*WaitContainerResponse, error) {
+	out := new(WaitContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/WaitContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (Conman_CopyFromContainerClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Conman_serviceDesc.Streams[0], c.cc, "/Conman/CopyFromContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &conmanCopyFromContainerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Conman_CopyFromContainerClient interface {
+	Recv() (*CopyChunk, error)
+	grpc.ClientStream
+}
+
+type conmanCopyFromContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *conmanCopyFromContainerClient) Recv() (*CopyChunk, error) {
+	m := new(CopyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *conmanClient) CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (Conman_CopyToContainerClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Conman_serviceDesc.Streams[1], c.cc, "/Conman/CopyToContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &conmanCopyToContainerClient{stream}
+	return x, nil
+}
+
+type Conman_CopyToContainerClient interface {
+	Send(*CopyToContainerRequest) error
+	CloseAndRecv() (*CopyToContainerResponse, error)
+	grpc.ClientStream
+}
+
+type conmanCopyToContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *conmanCopyToContainerClient) Send(m *CopyToContainerRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *conmanCopyToContainerClient) CloseAndRecv() (*CopyToContainerResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyToContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *conmanClient) ContainerChanges(ctx context.Context, in *ContainerChangesRequest, opts ...grpc.CallOption) (*ContainerChangesResponse, error) {
+	out := new(ContainerChangesResponse)
+	err := grpc.Invoke(ctx, "/Conman/ContainerChanges", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) ContainerTop(ctx context.Context, in *ContainerTopRequest, opts ...grpc.CallOption) (*ContainerTopResponse, error) {
+	out := new(ContainerTopResponse)
+	err := grpc.Invoke(ctx, "/Conman/ContainerTop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conmanClient) ExportContainer(ctx context.Context, in *ExportContainerRequest, opts ...grpc.CallOption) (Conman_ExportContainerClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Conman_serviceDesc.Streams[2], c.cc, "/Conman/ExportContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &conmanExportContainerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Conman_ExportContainerClient interface {
+	Recv() (*CopyChunk, error)
+	grpc.ClientStream
 }
 
-type conmanClient struct {
-	cc *grpc.ClientConn
+type conmanExportContainerClient struct {
+	grpc.ClientStream
 }
 
-func NewConmanClient(cc *grpc.ClientConn) ConmanClient {
-	return &conmanClient{cc}
+func (x *conmanExportContainerClient) Recv() (*CopyChunk, error) {
+	m := new(CopyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *conmanClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
-	out := new(VersionResponse)
-	err := grpc.Invoke(ctx, "/Conman/Version", in, out, c.cc, opts...)
+func (c *conmanClient) CommitContainer(ctx context.Context, in *CommitContainerRequest, opts ...grpc.CallOption) (*CommitContainerResponse, error) {
+	out := new(CommitContainerResponse)
+	err := grpc.Invoke(ctx, "/Conman/CommitContainer", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error) {
-	out := new(CreateContainerResponse)
-	err := grpc.Invoke(ctx, "/Conman/CreateContainer", in, out, c.cc, opts...)
+func (c *conmanClient) Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error) {
+	out := new(AttachResponse)
+	err := grpc.Invoke(ctx, "/Conman/Attach", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) StartContainer(ctx context.Context, in *StartContainerRequest, opts ...grpc.CallOption) (*StartContainerResponse, error) {
-	out := new(StartContainerResponse)
-	err := grpc.Invoke(ctx, "/Conman/StartContainer", in, out, c.cc, opts...)
+func (c *conmanClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
+	out := new(ListImagesResponse)
+	err := grpc.Invoke(ctx, "/Conman/ListImages", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error) {
-	out := new(StopContainerResponse)
-	err := grpc.Invoke(ctx, "/Conman/StopContainer", in, out, c.cc, opts...)
+func (c *conmanClient) ImportImage(ctx context.Context, opts ...grpc.CallOption) (Conman_ImportImageClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Conman_serviceDesc.Streams[3], c.cc, "/Conman/ImportImage", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &conmanImportImageClient{stream}
+	return x, nil
 }
 
-func (c *conmanClient) RemoveContainer(ctx context.Context, in *RemoveContainerRequest, opts ...grpc.CallOption) (*RemoveContainerResponse, error) {
-	out := new(RemoveContainerResponse)
-	err := grpc.Invoke(ctx, "/Conman/RemoveContainer", in, out, c.cc, opts...)
+type Conman_ImportImageClient interface {
+	Send(*ImportImageRequest) error
+	CloseAndRecv() (*ImportImageResponse, error)
+	grpc.ClientStream
+}
+
+type conmanImportImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *conmanImportImageClient) Send(m *ImportImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *conmanImportImageClient) CloseAndRecv() (*ImportImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *conmanClient) CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*CreateVolumeResponse, error) {
+	out := new(CreateVolumeResponse)
+	err := grpc.Invoke(ctx, "/Conman/CreateVolume", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
-	out := new(ListContainersResponse)
-	err := grpc.Invoke(ctx, "/Conman/ListContainers", in, out, c.cc, opts...)
+func (c *conmanClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	out := new(ListVolumesResponse)
+	err := grpc.Invoke(ctx, "/Conman/ListVolumes", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error) {
-	out := new(ContainerStatusResponse)
-	err := grpc.Invoke(ctx, "/Conman/ContainerStatus", in, out, c.cc, opts...)
+func (c *conmanClient) InspectVolume(ctx context.Context, in *InspectVolumeRequest, opts ...grpc.CallOption) (*InspectVolumeResponse, error) {
+	out := new(InspectVolumeResponse)
+	err := grpc.Invoke(ctx, "/Conman/InspectVolume", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *conmanClient) Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*AttachResponse, error) {
-	out := new(AttachResponse)
-	err := grpc.Invoke(ctx, "/Conman/Attach", in, out, c.cc, opts...)
+func (c *conmanClient) RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*RemoveVolumeResponse, error) {
+	out := new(RemoveVolumeResponse)
+	err := grpc.Invoke(ctx, "/Conman/RemoveVolume", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1027,11 +3770,36 @@ type ConmanServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	CreateContainer(context.Context, *CreateContainerRequest) (*CreateContainerResponse, error)
 	StartContainer(context.Context, *StartContainerRequest) (*StartContainerResponse, error)
+	RunContainer(context.Context, *RunContainerRequest) (*RunContainerResponse, error)
 	StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error)
 	RemoveContainer(context.Context, *RemoveContainerRequest) (*RemoveContainerResponse, error)
 	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
 	ContainerStatus(context.Context, *ContainerStatusRequest) (*ContainerStatusResponse, error)
+	// Long-poll: blocks until the requested condition is met.
+	WaitContainer(context.Context, *WaitContainerRequest) (*WaitContainerResponse, error)
+	// Streams a tar archive of a container path.
+	CopyFromContainer(*CopyFromContainerRequest, Conman_CopyFromContainerServer) error
+	// Extracts a streamed tar archive to a container path. The first
+	// message must specify the container and the path.
+	CopyToContainer(Conman_CopyToContainerServer) error
+	// Lists paths changed in the container rootfs since its creation.
+	ContainerChanges(context.Context, *ContainerChangesRequest) (*ContainerChangesResponse, error)
+	// Lists processes of a running container.
+	ContainerTop(context.Context, *ContainerTopRequest) (*ContainerTopResponse, error)
+	// Streams a tar archive of the whole container rootfs.
+	ExportContainer(*ExportContainerRequest, Conman_ExportContainerServer) error
+	// Creates a new image from the container rootfs.
+	CommitContainer(context.Context, *CommitContainerRequest) (*CommitContainerResponse, error)
 	Attach(context.Context, *AttachRequest) (*AttachResponse, error)
+	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
+	// Creates a single-layer image from a streamed (possibly gzip-ed)
+	// rootfs tar archive. The first message may specify the image name.
+	ImportImage(Conman_ImportImageServer) error
+	CreateVolume(context.Context, *CreateVolumeRequest) (*CreateVolumeResponse, error)
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	InspectVolume(context.Context, *InspectVolumeRequest) (*InspectVolumeResponse, error)
+	// Fails if the volume is referenced by a container (in any state).
+	RemoveVolume(context.Context, *RemoveVolumeRequest) (*RemoveVolumeResponse, error)
 }
 
 func RegisterConmanServer(s *grpc.Server, srv ConmanServer) {
@@ -1092,6 +3860,24 @@ func _Conman_StartContainer_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Conman_RunContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).RunContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/RunContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).RunContainer(ctx, req.(*RunContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Conman_StopContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StopContainerRequest)
 	if err := dec(in); err != nil {
@@ -1164,6 +3950,146 @@ func _Conman_ContainerStatus_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Conman_WaitContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).WaitContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/WaitContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).WaitContainer(ctx, req.(*WaitContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_CopyFromContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromContainerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConmanServer).CopyFromContainer(m, &conmanCopyFromContainerServer{stream})
+}
+
+type Conman_CopyFromContainerServer interface {
+	Send(*CopyChunk) error
+	grpc.ServerStream
+}
+
+type conmanCopyFromContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *conmanCopyFromContainerServer) Send(m *CopyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Conman_CopyToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConmanServer).CopyToContainer(&conmanCopyToContainerServer{stream})
+}
+
+type Conman_CopyToContainerServer interface {
+	SendAndClose(*CopyToContainerResponse) error
+	Recv() (*CopyToContainerRequest, error)
+	grpc.ServerStream
+}
+
+type conmanCopyToContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *conmanCopyToContainerServer) SendAndClose(m *CopyToContainerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *conmanCopyToContainerServer) Recv() (*CopyToContainerRequest, error) {
+	m := new(CopyToContainerRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Conman_ContainerChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).ContainerChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/ContainerChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).ContainerChanges(ctx, req.(*ContainerChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_ContainerTop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerTopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).ContainerTop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/ContainerTop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).ContainerTop(ctx, req.(*ContainerTopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_ExportContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportContainerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConmanServer).ExportContainer(m, &conmanExportContainerServer{stream})
+}
+
+type Conman_ExportContainerServer interface {
+	Send(*CopyChunk) error
+	grpc.ServerStream
+}
+
+type conmanExportContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *conmanExportContainerServer) Send(m *CopyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Conman_CommitContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).CommitContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/CommitContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).CommitContainer(ctx, req.(*CommitContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Conman_Attach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AttachRequest)
 	if err := dec(in); err != nil {
@@ -1182,6 +4108,122 @@ func _Conman_Attach_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Conman_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/ListImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_ImportImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConmanServer).ImportImage(&conmanImportImageServer{stream})
+}
+
+type Conman_ImportImageServer interface {
+	SendAndClose(*ImportImageResponse) error
+	Recv() (*ImportImageRequest, error)
+	grpc.ServerStream
+}
+
+type conmanImportImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *conmanImportImageServer) SendAndClose(m *ImportImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *conmanImportImageServer) Recv() (*ImportImageRequest, error) {
+	m := new(ImportImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Conman_CreateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).CreateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/CreateVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).CreateVolume(ctx, req.(*CreateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/ListVolumes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_InspectVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).InspectVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/InspectVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).InspectVolume(ctx, req.(*InspectVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Conman_RemoveVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConmanServer).RemoveVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Conman/RemoveVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConmanServer).RemoveVolume(ctx, req.(*RemoveVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Conman_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Conman",
 	HandlerType: (*ConmanServer)(nil),
@@ -1198,6 +4240,10 @@ var _Conman_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StartContainer",
 			Handler:    _Conman_StartContainer_Handler,
 		},
+		{
+			MethodName: "RunContainer",
+			Handler:    _Conman_RunContainer_Handler,
+		},
 		{
 			MethodName: "StopContainer",
 			Handler:    _Conman_StopContainer_Handler,
@@ -1214,69 +4260,262 @@ var _Conman_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ContainerStatus",
 			Handler:    _Conman_ContainerStatus_Handler,
 		},
+		{
+			MethodName: "WaitContainer",
+			Handler:    _Conman_WaitContainer_Handler,
+		},
+		{
+			MethodName: "ContainerChanges",
+			Handler:    _Conman_ContainerChanges_Handler,
+		},
+		{
+			MethodName: "ContainerTop",
+			Handler:    _Conman_ContainerTop_Handler,
+		},
+		{
+			MethodName: "CommitContainer",
+			Handler:    _Conman_CommitContainer_Handler,
+		},
 		{
 			MethodName: "Attach",
 			Handler:    _Conman_Attach_Handler,
 		},
+		{
+			MethodName: "ListImages",
+			Handler:    _Conman_ListImages_Handler,
+		},
+		{
+			MethodName: "CreateVolume",
+			Handler:    _Conman_CreateVolume_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _Conman_ListVolumes_Handler,
+		},
+		{
+			MethodName: "InspectVolume",
+			Handler:    _Conman_InspectVolume_Handler,
+		},
+		{
+			MethodName: "RemoveVolume",
+			Handler:    _Conman_RemoveVolume_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CopyFromContainer",
+			Handler:       _Conman_CopyFromContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyToContainer",
+			Handler:       _Conman_CopyToContainer_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportContainer",
+			Handler:       _Conman_ExportContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportImage",
+			Handler:       _Conman_ImportImage_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "conman.proto",
 }
 
-func init() { proto.RegisterFile("conman.proto", fileDescriptor_conman_882700af8b15a59a) }
-
-var fileDescriptor_conman_882700af8b15a59a = []byte{
-	// 826 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x4e, 0xe2, 0xac, 0x37, 0x39, 0xe9, 0x3a, 0xd6, 0xa8, 0x6b, 0xbb, 0x41, 0x15, 0xdb, 0x91,
-	0x2a, 0x56, 0x45, 0x9a, 0x8b, 0xe5, 0x0e, 0xb8, 0x20, 0xb8, 0x0b, 0x5a, 0x81, 0x52, 0xe4, 0xa5,
-	0x80, 0xb8, 0x89, 0x86, 0x78, 0xba, 0x6b, 0x69, 0xed, 0x09, 0x33, 0x93, 0x85, 0x3e, 0x02, 0xbc,
-	0x0e, 0x4f, 0xc3, 0xdb, 0xa0, 0xf9, 0x89, 0x13, 0x3b, 0x5e, 0xd4, 0xf6, 0x6e, 0xce, 0xf7, 0xcd,
-	0x9c, 0xbf, 0x9c, 0xf3, 0x39, 0xf0, 0x68, 0xc5, 0xab, 0x92, 0x56, 0x64, 0x2d, 0xb8, 0xe2, 0x38,
-	0x84, 0xe0, 0x27, 0x26, 0x64, 0xc1, 0xab, 0x8c, 0xfd, 0xbe, 0x61, 0x52, 0xe1, 0x3f, 0x60, 0x5a,
-	0x23, 0x72, 0xcd, 0x2b, 0xc9, 0x50, 0x02, 0xc7, 0xf7, 0x16, 0x4a, 0xfa, 0x67, 0xfd, 0xf3, 0x71,
-	0xb6, 0x35, 0xd1, 0x33, 0x78, 0x24, 0x36, 0x95, 0x2a, 0x4a, 0xb6, 0xac, 0x68, 0xc9, 0x92, 0x81,
-	0xa1, 0x27, 0x0e, 0x5b, 0xd0, 0x92, 0xa1, 0x4f, 0x60, 0xba, 0xbd, 0xb2, 0x75, 0xe2, 0x99, 0x5b,
-	0x81, 0x83, 0x5d, 0x34, 0xfc, 0x6f, 0x1f, 0xa2, 0x54, 0x30, 0xaa, 0x58, 0xca, 0x2b, 0x45, 0x8b,
-	0x8a, 0x09, 0x97, 0x13, 0x42, 0x30, 0x34, 0xee, 0x6d, 0x74, 0x73, 0x46, 0x1f, 0xc3, 0x44, 0x70,
-	0xae, 0xde, 0xc8, 0xe5, 0x9a, 0xaa, 0x5b, 0x17, 0x19, 0x2c, 0xf4, 0x03, 0x55, 0xb7, 0x26, 0xb0,
-	0xbd, 0x20, 0x18, 0xcd, 0x79, 0x75, 0xf7, 0xd6, 0x04, 0x1e, 0x65, 0x81, 0x85, 0x33, 0x87, 0xea,
-	0xf2, 0x56, 0xbc, 0x2c, 0x69, 0x95, 0x27, 0x43, 0x5b, 0x9e, 0x33, 0x75, 0x5c, 0x2a, 0x6e, 0x64,
-	0x72, 0x74, 0xe6, 0xe9, 0xb8, 0xfa, 0x8c, 0x1e, 0xc3, 0x91, 0x54, 0x79, 0x51, 0x25, 0xbe, 0x71,
-	0x66, 0x0d, 0xf4, 0x14, 0xc0, 0x1c, 0x96, 0xbc, 0x5a, 0xb1, 0xe4, 0xd8, 0x50, 0x63, 0x83, 0xbc,
-	0xaa, 0x56, 0x0c, 0x7f, 0x09, 0xf1, 0x41, 0x69, 0xae, 0xb9, 0xcf, 0xcc, 0x2f, 0x62, 0xc1, 0x65,
-	0x91, 0xbb, 0x1a, 0x27, 0x35, 0x76, 0x95, 0xe3, 0xcf, 0xe1, 0xf4, 0x5a, 0x51, 0xa1, 0x0e, 0xfa,
-	0xf2, 0x0e, 0x6f, 0x13, 0x88, 0xda, 0x6f, 0x6d, 0x60, 0x7c, 0x0d, 0x8f, 0xaf, 0x15, 0x5f, 0x7f,
-	0x80, 0x53, 0xdd, 0x31, 0xfd, 0xcb, 0xf1, 0x8d, 0x32, 0x7d, 0xf7, 0xb2, 0xad, 0x89, 0x63, 0x9d,
-	0x6a, 0xc3, 0xa9, 0x8b, 0xf6, 0x05, 0x44, 0x19, 0x2b, 0xf9, 0x3d, 0xfb, 0x90, 0x22, 0x9e, 0x40,
-	0x7c, 0xf0, 0xd8, 0xf9, 0x8d, 0xe1, 0xf4, 0xfb, 0x42, 0xee, 0xca, 0x93, 0xdb, 0x39, 0x7e, 0x09,
-	0x51, 0x9b, 0x70, 0x1d, 0x7f, 0x01, 0x50, 0x3b, 0x97, 0x49, 0xff, 0xcc, 0x3b, 0x9f, 0x5c, 0x00,
-	0xd9, 0xb9, 0xde, 0x63, 0x75, 0xda, 0x35, 0x71, 0xad, 0xa8, 0xda, 0xc8, 0xf7, 0x48, 0x3b, 0x85,
-	0xf8, 0xe0, 0xb1, 0xcb, 0xe1, 0x1c, 0x7c, 0x69, 0x10, 0xf3, 0x6e, 0x72, 0x11, 0x92, 0xf6, 0x4d,
-	0xc7, 0xe3, 0x0d, 0x8c, 0x6b, 0x0a, 0x05, 0x30, 0xa8, 0x43, 0x0d, 0x8a, 0xbc, 0x5e, 0x8c, 0xc1,
-	0xde, 0x62, 0x3c, 0x05, 0x58, 0x99, 0x59, 0xcb, 0x97, 0x54, 0x99, 0x91, 0xf7, 0xb2, 0xb1, 0x43,
-	0xe6, 0x0a, 0x3d, 0xd7, 0xf3, 0x4b, 0x15, 0x33, 0xb3, 0x1e, 0x5c, 0x4c, 0x9b, 0x81, 0x59, 0x66,
-	0x59, 0xfc, 0xcf, 0x00, 0xa6, 0xad, 0x94, 0xde, 0x65, 0x32, 0x9e, 0x43, 0xb0, 0xbb, 0xb2, 0x97,
-	0xda, 0x49, 0x8d, 0x1a, 0x51, 0xa8, 0x93, 0xf0, 0xfe, 0x2f, 0x89, 0x56, 0x29, 0xc3, 0x76, 0x29,
-	0x66, 0xe9, 0xa8, 0x70, 0xf4, 0x91, 0xa5, 0x1d, 0x32, 0x57, 0x5a, 0x21, 0xde, 0x14, 0x55, 0x21,
-	0x6f, 0x2d, 0xef, 0x1b, 0x1e, 0xb6, 0xd0, 0x5c, 0xa1, 0x8f, 0x60, 0xcc, 0xfe, 0x2c, 0xd4, 0x72,
-	0xc5, 0x73, 0xbb, 0xb3, 0x47, 0xd9, 0x48, 0x03, 0x29, 0xcf, 0x8d, 0xe8, 0x95, 0x4c, 0x4a, 0x7a,
-	0xc3, 0x92, 0x91, 0x55, 0x05, 0x67, 0xa2, 0x27, 0x30, 0xba, 0xe3, 0x37, 0x56, 0x76, 0xc6, 0x96,
-	0xba, 0xe3, 0x37, 0x5a, 0x73, 0xf0, 0xdf, 0x7d, 0x38, 0x99, 0x2b, 0x45, 0x57, 0xb7, 0xef, 0xb1,
-	0x4d, 0x21, 0x78, 0x4a, 0xbd, 0x35, 0x8d, 0x1a, 0x65, 0xfa, 0xb8, 0xd3, 0x18, 0x6f, 0x5f, 0x63,
-	0x22, 0x3d, 0x33, 0xb9, 0x5e, 0xba, 0xa1, 0x81, 0x9d, 0xe5, 0x70, 0x26, 0x84, 0x69, 0x81, 0xc5,
-	0x99, 0x10, 0x18, 0x43, 0xb0, 0xcd, 0xc5, 0x4d, 0x5d, 0x08, 0xde, 0x46, 0xdc, 0xb9, 0x1c, 0xf4,
-	0xf1, 0x45, 0x0a, 0x41, 0xb3, 0xf5, 0x68, 0x02, 0xc7, 0x69, 0x76, 0x39, 0xff, 0xf1, 0xf2, 0x65,
-	0xd8, 0xd3, 0x46, 0xf6, 0x7a, 0xb1, 0xb8, 0x5a, 0x7c, 0x1b, 0xf6, 0x11, 0x80, 0x7f, 0xf9, 0xcb,
-	0x95, 0x26, 0x06, 0x9a, 0x78, 0xbd, 0xf8, 0x6e, 0xf1, 0xea, 0xe7, 0x45, 0xe8, 0x5d, 0xfc, 0x35,
-	0x04, 0x3f, 0x35, 0x5f, 0x15, 0x44, 0xe0, 0xd8, 0xe9, 0x39, 0x9a, 0x92, 0xe6, 0x97, 0x65, 0x16,
-	0x92, 0xd6, 0x87, 0x05, 0xf7, 0xd0, 0x37, 0x30, 0x6d, 0x09, 0x23, 0x8a, 0x49, 0xf7, 0x57, 0x60,
-	0x96, 0x90, 0x07, 0x34, 0x14, 0xf7, 0x50, 0x0a, 0x41, 0x53, 0xe6, 0x50, 0x44, 0x3a, 0x35, 0x73,
-	0x16, 0x93, 0x07, 0xf4, 0xb0, 0x87, 0xbe, 0x82, 0x93, 0x86, 0x78, 0xa1, 0x53, 0xd2, 0xa5, 0x90,
-	0xb3, 0x88, 0x74, 0x6b, 0x9c, 0x29, 0xa7, 0x25, 0x54, 0x28, 0x26, 0xdd, 0xba, 0x37, 0x4b, 0xc8,
-	0x43, 0x9a, 0x66, 0xca, 0x69, 0x8a, 0x17, 0x8a, 0x48, 0xa7, 0xcc, 0xcd, 0x62, 0xd2, 0xad, 0x72,
-	0xae, 0xb7, 0xad, 0x0d, 0x8e, 0x49, 0xb7, 0x9a, 0xe9, 0xde, 0x76, 0x2b, 0x15, 0xee, 0xa1, 0x4f,
-	0xc1, 0xb7, 0x73, 0x84, 0x02, 0xd2, 0x18, 0xee, 0xd9, 0x94, 0x34, 0x07, 0x0c, 0xf7, 0xbe, 0x1e,
-	0xfd, 0xea, 0x4b, 0x26, 0xee, 0x99, 0xf8, 0xcd, 0x37, 0xff, 0x30, 0x3e, 0xfb, 0x2f, 0x00, 0x00,
-	0xff, 0xff, 0x0e, 0xd9, 0x40, 0x94, 0x71, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("conman.proto", fileDescriptor_conman_a4cc490026972f9a) }
+
+var fileDescriptor_conman_a4cc490026972f9a = []byte{
+	// 2999 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4f, 0x73, 0x1b, 0xc7,
+	0xb1, 0x27, 0x00, 0xe2, 0x5f, 0x83, 0x00, 0xc1, 0x01, 0x08, 0x2c, 0x21, 0x4b, 0xa2, 0xd6, 0x7f,
+	0x1e, 0x4d, 0xbf, 0xb7, 0xe5, 0xa2, 0xfd, 0xca, 0x7a, 0xb2, 0xa4, 0x32, 0x0c, 0x40, 0x32, 0x2c,
+	0x11, 0xe4, 0x5b, 0x42, 0x72, 0x2a, 0x39, 0x20, 0xab, 0xdd, 0x11, 0xb9, 0x16, 0xb0, 0xb3, 0xd9,
+	0x59, 0x50, 0x66, 0xce, 0xc9, 0x25, 0xc7, 0x54, 0xf9, 0x9a, 0x7c, 0x8b, 0x1c, 0x73, 0xcf, 0x77,
+	0xc8, 0x17, 0xc8, 0x17, 0xc8, 0x39, 0x35, 0x7f, 0x76, 0xb1, 0xff, 0x48, 0x51, 0x72, 0xaa, 0x52,
+	0x95, 0xdc, 0x66, 0x7a, 0xba, 0xa7, 0x67, 0xba, 0x7b, 0xba, 0x7b, 0x7f, 0x00, 0x6c, 0x98, 0xc4,
+	0x59, 0x18, 0x8e, 0xe6, 0x7a, 0xc4, 0x27, 0x6a, 0x13, 0x1a, 0xcf, 0xb1, 0x47, 0x6d, 0xe2, 0xe8,
+	0xf8, 0x57, 0x4b, 0x4c, 0x7d, 0xf5, 0x35, 0x6c, 0x86, 0x14, 0xea, 0x12, 0x87, 0x62, 0xa4, 0x40,
+	0xf9, 0x5c, 0x90, 0x94, 0xdc, 0x6e, 0x6e, 0xaf, 0xaa, 0x07, 0x53, 0x74, 0x07, 0x36, 0xbc, 0xa5,
+	0xe3, 0xdb, 0x0b, 0x3c, 0x73, 0x8c, 0x05, 0x56, 0xf2, 0x7c, 0xb9, 0x26, 0x69, 0x13, 0x63, 0x81,
+	0xd1, 0x7f, 0xc1, 0x66, 0xc0, 0x12, 0x6c, 0x52, 0xe0, 0x5c, 0x0d, 0x49, 0x96, 0xda, 0xd4, 0x3f,
+	0x01, 0x74, 0x06, 0x1e, 0x36, 0x7c, 0x3c, 0x20, 0x8e, 0x6f, 0xd8, 0x0e, 0xf6, 0xe4, 0x99, 0x10,
+	0x82, 0x75, 0xbe, 0xbd, 0xd0, 0xce, 0xc7, 0xe8, 0x36, 0xd4, 0x3c, 0x42, 0xfc, 0x97, 0x74, 0xe6,
+	0x1a, 0xfe, 0x99, 0xd4, 0x0c, 0x82, 0x74, 0x6c, 0xf8, 0x67, 0x5c, 0xb1, 0x60, 0xf0, 0xb0, 0x61,
+	0x11, 0x67, 0x7e, 0xc1, 0x15, 0x57, 0xf4, 0x86, 0x20, 0xeb, 0x92, 0xca, 0xae, 0x67, 0x92, 0xc5,
+	0xc2, 0x70, 0x2c, 0x65, 0x5d, 0x5c, 0x4f, 0x4e, 0x99, 0x5e, 0xc3, 0x3b, 0xa5, 0x4a, 0x71, 0xb7,
+	0xc0, 0xf4, 0xb2, 0x31, 0x6a, 0x43, 0x91, 0xfa, 0x96, 0xed, 0x28, 0x25, 0xbe, 0x99, 0x98, 0xa0,
+	0x9b, 0x00, 0x7c, 0x30, 0x23, 0x8e, 0x89, 0x95, 0x32, 0x5f, 0xaa, 0x72, 0xca, 0x91, 0x63, 0x62,
+	0xf4, 0x25, 0x94, 0xe6, 0xc6, 0x0b, 0x3c, 0xa7, 0x4a, 0x65, 0xb7, 0xb0, 0x57, 0x3b, 0x78, 0x5f,
+	0xcb, 0xbe, 0xa9, 0xf6, 0x94, 0x73, 0x8d, 0x1c, 0xdf, 0xbb, 0xd0, 0xa5, 0x08, 0xfa, 0x16, 0x6a,
+	0x86, 0xe3, 0x10, 0xdf, 0xf0, 0x6d, 0xe2, 0x50, 0xa5, 0xca, 0x77, 0xd8, 0xbb, 0x6c, 0x87, 0xfe,
+	0x8a, 0x55, 0x6c, 0x13, 0x15, 0x66, 0xa7, 0xb7, 0x17, 0xc6, 0x29, 0x56, 0x80, 0xdf, 0x54, 0x4c,
+	0xd0, 0x2d, 0x28, 0x2d, 0xc8, 0xd2, 0xf1, 0xa9, 0x52, 0xe3, 0x9b, 0x97, 0xb4, 0x43, 0x36, 0xd5,
+	0x25, 0x95, 0x99, 0x92, 0x62, 0xd3, 0x24, 0x0b, 0x77, 0xe6, 0x7a, 0xe4, 0xa5, 0x3d, 0xc7, 0xca,
+	0x86, 0xf0, 0xa1, 0x24, 0x1f, 0x0b, 0x2a, 0xea, 0x42, 0xd9, 0x34, 0xdc, 0x99, 0x61, 0x59, 0x4a,
+	0x9d, 0xdb, 0xac, 0x64, 0x1a, 0x6e, 0xdf, 0xb2, 0xd0, 0x0e, 0x54, 0xd8, 0x82, 0xe5, 0x11, 0x57,
+	0x69, 0xf0, 0x15, 0xc6, 0x38, 0xf4, 0x88, 0x8b, 0x6e, 0x01, 0xb8, 0x9e, 0x7d, 0x6e, 0xcf, 0xf1,
+	0x29, 0xb6, 0x94, 0x4d, 0x6e, 0xba, 0x08, 0x05, 0xed, 0xc3, 0x96, 0x43, 0x66, 0x0e, 0x7e, 0x3d,
+	0x0b, 0x89, 0x54, 0x69, 0x72, 0xb6, 0x4d, 0x87, 0x4c, 0xf0, 0xeb, 0xe3, 0x90, 0x8c, 0x3e, 0x84,
+	0xc6, 0x92, 0x62, 0x8f, 0x07, 0x23, 0x75, 0x0d, 0x13, 0x2b, 0x5b, 0x9c, 0xb1, 0xce, 0xa8, 0x93,
+	0x80, 0x88, 0xfe, 0x07, 0x36, 0x96, 0xb6, 0x35, 0x5b, 0x18, 0xae, 0x6b, 0x3b, 0xa7, 0x54, 0x41,
+	0xfc, 0xd6, 0xa0, 0x8d, 0x87, 0x87, 0x82, 0xa4, 0xd7, 0x96, 0xb6, 0x25, 0xc7, 0x94, 0xb1, 0x9f,
+	0x46, 0xd9, 0x5b, 0x69, 0xf6, 0xd3, 0x08, 0xfb, 0x03, 0xd8, 0x0a, 0xf5, 0xcf, 0x88, 0x2b, 0xbc,
+	0xd6, 0xde, 0xcd, 0xed, 0xd5, 0x0e, 0x9a, 0x5a, 0x78, 0x88, 0x23, 0xbe, 0xa0, 0x37, 0x9d, 0x38,
+	0x81, 0xb2, 0x37, 0xb5, 0x30, 0xe8, 0x2b, 0x6c, 0xf1, 0xc0, 0xa6, 0xca, 0x36, 0x37, 0x57, 0x4d,
+	0xd0, 0x58, 0x64, 0xf3, 0x6b, 0x06, 0x31, 0x2d, 0x99, 0x3a, 0x9c, 0xa9, 0x1e, 0x50, 0x05, 0xdb,
+	0xc7, 0xcc, 0xb2, 0xc4, 0x9c, 0x71, 0x2f, 0x2a, 0xdd, 0xdd, 0xdc, 0x5e, 0xe3, 0x00, 0xb4, 0x63,
+	0x8f, 0x98, 0xc2, 0xbd, 0x55, 0x37, 0x18, 0xa2, 0x87, 0x50, 0xa6, 0x17, 0xd4, 0xf4, 0xe7, 0x54,
+	0x51, 0xf8, 0xed, 0x3e, 0xb8, 0x2c, 0xbe, 0x4e, 0x04, 0x9b, 0x88, 0xad, 0x40, 0x08, 0xdd, 0x81,
+	0xb2, 0x85, 0xcf, 0x6d, 0x13, 0x53, 0x65, 0x87, 0xcb, 0x97, 0xb5, 0x21, 0x9f, 0xeb, 0x01, 0x9d,
+	0x3d, 0x58, 0xd3, 0xb2, 0x67, 0x01, 0x5b, 0x8f, 0x9f, 0x18, 0x4c, 0xcb, 0x1e, 0x4a, 0x86, 0x3b,
+	0x50, 0xf6, 0xe6, 0xf6, 0xc2, 0xf6, 0xa9, 0x72, 0x43, 0xee, 0xa1, 0xf3, 0xb9, 0x1e, 0xd0, 0x91,
+	0x0a, 0x75, 0x42, 0x16, 0x33, 0x6a, 0x12, 0x0f, 0xcf, 0x0c, 0xeb, 0x7b, 0xe5, 0xbd, 0xdd, 0xdc,
+	0x5e, 0x51, 0xaf, 0x11, 0xb2, 0x38, 0x61, 0xb4, 0xbe, 0xf5, 0x3d, 0xba, 0x0b, 0x8a, 0x8f, 0xbd,
+	0x85, 0xed, 0xf0, 0x90, 0x9f, 0x2d, 0x30, 0xa5, 0xc6, 0x29, 0x16, 0x59, 0xe2, 0x26, 0x8f, 0xda,
+	0x4e, 0x64, 0xfd, 0x50, 0x2c, 0x33, 0x83, 0xf5, 0xfe, 0x0f, 0x6a, 0x91, 0xf7, 0x87, 0x9a, 0x50,
+	0x78, 0x85, 0x2f, 0x64, 0xd2, 0x61, 0x43, 0xf6, 0x7a, 0xce, 0x8d, 0xf9, 0x32, 0xc8, 0x73, 0x62,
+	0x72, 0x2f, 0x7f, 0x37, 0xd7, 0x7b, 0x08, 0xcd, 0xe4, 0xc3, 0x7b, 0x2b, 0xf9, 0x7b, 0xb0, 0x11,
+	0x35, 0xec, 0xdb, 0xc8, 0xaa, 0x43, 0x28, 0x09, 0x3b, 0xb1, 0x7c, 0xe5, 0x5f, 0xb8, 0x61, 0x9e,
+	0x64, 0x63, 0x46, 0xa3, 0xe4, 0xa5, 0xcf, 0xc5, 0xd6, 0x75, 0x3e, 0x66, 0xb4, 0x33, 0xc3, 0xb3,
+	0x78, 0x3e, 0x5c, 0xd7, 0xf9, 0x58, 0x75, 0xa0, 0x24, 0x1c, 0xc1, 0xa2, 0xcb, 0x0c, 0xbc, 0x2e,
+	0xcc, 0x26, 0xf6, 0xab, 0x87, 0x54, 0x9e, 0x5f, 0x6f, 0x40, 0xf5, 0x8c, 0x50, 0x3f, 0x9a, 0x7e,
+	0x2b, 0x8c, 0xc0, 0x17, 0x77, 0xa1, 0xe6, 0x32, 0x23, 0x53, 0xca, 0xa3, 0x5f, 0x64, 0xfc, 0x28,
+	0x49, 0xfd, 0x05, 0x54, 0xc3, 0xf7, 0xc3, 0x62, 0x7e, 0xa5, 0xd2, 0xb6, 0xb8, 0xc2, 0xba, 0x5e,
+	0x0b, 0x69, 0x63, 0x8b, 0xa5, 0x16, 0xae, 0xce, 0xb6, 0xb8, 0xb2, 0xba, 0x5e, 0x62, 0xd3, 0x31,
+	0x4f, 0xd2, 0xd4, 0xfe, 0x35, 0xe6, 0x3a, 0xea, 0x3a, 0x1f, 0xab, 0x7f, 0xce, 0xc1, 0x66, 0xe2,
+	0xa5, 0xa1, 0x3d, 0x28, 0x3b, 0xd8, 0x7f, 0x4d, 0xbc, 0x57, 0x7c, 0xfb, 0xc6, 0x41, 0x63, 0xf5,
+	0x18, 0x0f, 0x89, 0x85, 0xf5, 0x60, 0x19, 0xed, 0x42, 0xc1, 0x95, 0x6a, 0xd2, 0x5c, 0x6c, 0x89,
+	0x71, 0xd8, 0xae, 0xa9, 0x14, 0xb2, 0x39, 0x6c, 0xd7, 0x64, 0xd6, 0xf1, 0x0d, 0xef, 0x14, 0xf3,
+	0x03, 0x8b, 0xb2, 0x52, 0x11, 0x84, 0x31, 0x17, 0x5f, 0xfa, 0xac, 0xac, 0x64, 0x8a, 0x2f, 0x7d,
+	0xaa, 0xfe, 0x3d, 0x07, 0x45, 0xf1, 0x32, 0x6f, 0x45, 0x7c, 0xca, 0x9e, 0x2f, 0xa7, 0x4e, 0x2f,
+	0x5c, 0x2c, 0xfd, 0xdb, 0x81, 0x12, 0x25, 0x4b, 0xcf, 0x0c, 0x02, 0x43, 0xce, 0x98, 0x07, 0x2c,
+	0x4c, 0x7d, 0x19, 0xe6, 0x81, 0x07, 0x22, 0x24, 0xd4, 0x83, 0x4a, 0x58, 0x19, 0xd7, 0x79, 0x9a,
+	0x0c, 0xe7, 0xe8, 0x33, 0xa8, 0xb9, 0x1e, 0x71, 0x8d, 0x53, 0x21, 0x2d, 0x4e, 0xba, 0x25, 0x94,
+	0x1f, 0xaf, 0x16, 0xf4, 0x28, 0x57, 0xe8, 0x09, 0x56, 0x19, 0x0b, 0xc2, 0x13, 0x48, 0x83, 0x96,
+	0x87, 0xcd, 0xa5, 0x47, 0xed, 0x73, 0xcc, 0x0b, 0xf1, 0x8c, 0xeb, 0x13, 0x15, 0x72, 0x2b, 0x5c,
+	0x62, 0xc5, 0xf8, 0xc8, 0x99, 0x5f, 0xa8, 0xf7, 0xa1, 0x9b, 0x4a, 0x3c, 0xb2, 0x0d, 0xc9, 0x0a,
+	0x92, 0x6a, 0x2c, 0x48, 0xd4, 0x7b, 0xb0, 0x7d, 0xe2, 0x1b, 0x9e, 0x9f, 0xea, 0x20, 0xae, 0x21,
+	0xab, 0x40, 0x27, 0x29, 0x2b, 0x14, 0xab, 0xbf, 0xcd, 0x41, 0x4b, 0x5f, 0x3a, 0xa9, 0x4d, 0xff,
+	0x17, 0xaa, 0xe1, 0x06, 0x7c, 0xc7, 0xda, 0x41, 0xf7, 0x92, 0xb4, 0xa9, 0xaf, 0x38, 0x99, 0xc7,
+	0x0c, 0xdf, 0x37, 0x4c, 0xf1, 0x6a, 0x2a, 0xba, 0x9c, 0xb1, 0x04, 0x69, 0x2c, 0x7d, 0x32, 0xf3,
+	0xf0, 0x82, 0x9c, 0x63, 0xd9, 0xac, 0x00, 0x23, 0xe9, 0x9c, 0xa2, 0x3e, 0x81, 0x76, 0xfc, 0x18,
+	0xd7, 0x36, 0x0c, 0xcb, 0x27, 0x4b, 0x6f, 0x2e, 0x43, 0x84, 0x0d, 0xd5, 0x13, 0x68, 0x9f, 0xf8,
+	0xc4, 0x7d, 0x07, 0x4b, 0xb1, 0x86, 0x89, 0x35, 0x6e, 0x64, 0x29, 0xb2, 0x4a, 0x41, 0x0f, 0xa6,
+	0x6a, 0x17, 0xb6, 0x13, 0x9b, 0x4a, 0x13, 0x7e, 0x09, 0x1d, 0x71, 0x89, 0x77, 0xf1, 0xcc, 0x0e,
+	0x74, 0x53, 0xc2, 0x72, 0xdf, 0x3e, 0x6c, 0x3f, 0xb5, 0xe9, 0xca, 0x67, 0x34, 0xd8, 0x76, 0x0f,
+	0x4a, 0x2f, 0xed, 0xb9, 0x1f, 0x3a, 0xa6, 0xa9, 0x85, 0x3c, 0x8f, 0x38, 0x5d, 0x97, 0xeb, 0xea,
+	0x8f, 0x79, 0xd8, 0x4c, 0xac, 0xa1, 0x06, 0xe4, 0xc3, 0xa3, 0xe4, 0x6d, 0xd6, 0x83, 0x14, 0xa9,
+	0x6f, 0xf8, 0xe2, 0x8d, 0xd5, 0x0e, 0xda, 0xab, 0xcd, 0x4e, 0x18, 0xf9, 0x39, 0xcb, 0xc5, 0xba,
+	0x60, 0x41, 0x1f, 0x40, 0xc3, 0x25, 0xd6, 0x8c, 0x1a, 0x8e, 0xf5, 0x82, 0xfc, 0xc0, 0xae, 0x24,
+	0xde, 0xde, 0x86, 0x4b, 0xac, 0x13, 0x41, 0x1c, 0x5b, 0xe8, 0x5b, 0x68, 0xf0, 0xf6, 0x6e, 0x46,
+	0xf1, 0x1c, 0x9b, 0x3e, 0xf1, 0x94, 0xf5, 0xa0, 0x33, 0x8c, 0x9f, 0x45, 0xb4, 0x84, 0x27, 0x92,
+	0x4b, 0x94, 0xdd, 0xfa, 0x3c, 0x4a, 0x0b, 0xdb, 0xe3, 0xe2, 0xaa, 0x3d, 0xee, 0x7d, 0x05, 0x28,
+	0x2d, 0xf8, 0x56, 0x65, 0xe5, 0x3e, 0xb4, 0x32, 0x6e, 0x89, 0x3e, 0x0c, 0x4c, 0x21, 0x12, 0xd2,
+	0x66, 0xc2, 0x14, 0xd2, 0x0a, 0xea, 0x10, 0x3a, 0x49, 0xc7, 0xc8, 0x68, 0xdd, 0x07, 0x08, 0x9d,
+	0x4b, 0x95, 0x9c, 0xec, 0xa5, 0x56, 0xae, 0x8d, 0xac, 0xb2, 0xb0, 0x89, 0x6d, 0xbf, 0xa4, 0x6f,
+	0x11, 0x36, 0x03, 0xe8, 0xa6, 0x84, 0xe5, 0x19, 0xf6, 0xa0, 0x44, 0x39, 0x25, 0x1d, 0x1d, 0x92,
+	0x53, 0xae, 0xab, 0x0b, 0x68, 0x7f, 0x67, 0xd8, 0xef, 0x92, 0x50, 0xd0, 0x01, 0x4f, 0x0f, 0x96,
+	0xcd, 0x33, 0xe8, 0x55, 0x81, 0xb3, 0x62, 0x53, 0xff, 0x96, 0x83, 0xed, 0x84, 0xbe, 0xeb, 0x3f,
+	0xf2, 0x0f, 0xa3, 0x51, 0x7a, 0xa9, 0x6b, 0x58, 0x69, 0xc2, 0x3f, 0xd8, 0xfe, 0xcc, 0x24, 0x96,
+	0xc8, 0x32, 0x45, 0xbd, 0xc2, 0x08, 0x03, 0x62, 0x89, 0x72, 0x62, 0x9f, 0x3a, 0xc6, 0x9c, 0x97,
+	0x84, 0xa2, 0x2e, 0x67, 0x2c, 0x39, 0xbd, 0xb4, 0x1d, 0x9b, 0x9e, 0x61, 0x6b, 0x66, 0xf8, 0x3c,
+	0xd4, 0x0a, 0x3a, 0x04, 0xa4, 0xbe, 0xcf, 0x04, 0x3d, 0x6c, 0x50, 0x22, 0x3e, 0x8c, 0xaa, 0xba,
+	0x9c, 0xb1, 0x64, 0x21, 0x5b, 0x30, 0x9e, 0xf4, 0xab, 0x7a, 0x30, 0x55, 0xff, 0x1f, 0x94, 0x01,
+	0x71, 0x2f, 0x1e, 0x79, 0x64, 0xf1, 0x2e, 0xe6, 0x45, 0xb0, 0x1e, 0x69, 0x3d, 0xf8, 0x58, 0xbd,
+	0x0d, 0x55, 0xb6, 0xe5, 0xe0, 0x6c, 0xe9, 0xbc, 0x62, 0x0c, 0x96, 0xe1, 0x1b, 0x5c, 0x76, 0x43,
+	0xe7, 0x63, 0xd5, 0x64, 0x01, 0xe5, 0x5e, 0x4c, 0xc9, 0x3f, 0x49, 0x63, 0xa8, 0xa4, 0x10, 0x51,
+	0xb2, 0x03, 0xdd, 0x94, 0x12, 0x99, 0xaf, 0xee, 0x47, 0x62, 0x72, 0x70, 0x66, 0x38, 0xa7, 0xf8,
+	0x6d, 0x22, 0xfa, 0x11, 0xb3, 0x58, 0x52, 0x3a, 0x7c, 0x56, 0x65, 0x53, 0x90, 0xe4, 0x9b, 0x6a,
+	0x6a, 0x09, 0x5e, 0x3d, 0x60, 0x50, 0x1f, 0xc1, 0x66, 0x62, 0x2d, 0xbc, 0x5b, 0x2e, 0x72, 0xb7,
+	0xdb, 0xb0, 0xfe, 0xca, 0x76, 0x82, 0x46, 0xa8, 0xa6, 0x09, 0xd6, 0x27, 0xb6, 0x63, 0xe9, 0x7c,
+	0x41, 0xbd, 0x1b, 0x49, 0x11, 0x53, 0xe2, 0xbe, 0xc5, 0x4d, 0x1e, 0x42, 0x3b, 0x2e, 0x29, 0x6f,
+	0xf1, 0x11, 0xf0, 0x8f, 0x12, 0x4c, 0x69, 0x78, 0x8f, 0x0a, 0xff, 0x62, 0xc1, 0x94, 0xea, 0xab,
+	0x25, 0xf5, 0x8f, 0x39, 0x28, 0x4b, 0x32, 0x6a, 0x8a, 0x76, 0x2d, 0xc7, 0xe3, 0x95, 0x0d, 0xd1,
+	0x36, 0x94, 0x1c, 0x3a, 0x0b, 0x7a, 0xb8, 0xa2, 0x5e, 0x74, 0xe8, 0xb1, 0x2d, 0x8a, 0xa0, 0x4c,
+	0xc7, 0x75, 0x9d, 0x0d, 0xd9, 0xad, 0xd9, 0x97, 0xa1, 0x6c, 0xd0, 0xf8, 0x98, 0x7f, 0xaa, 0xba,
+	0xcb, 0x19, 0x2b, 0x69, 0x32, 0xcc, 0xcb, 0xa6, 0xbb, 0x9c, 0xda, 0x0b, 0xcc, 0x36, 0xf0, 0x28,
+	0x95, 0xfd, 0x0d, 0x1b, 0x46, 0xb1, 0x83, 0x72, 0x0c, 0x3b, 0x60, 0xa9, 0x6b, 0xf4, 0x83, 0x4b,
+	0xde, 0xad, 0x17, 0xf9, 0x4d, 0x9e, 0xc5, 0xe9, 0x62, 0xf1, 0x6e, 0x89, 0xe7, 0x26, 0x00, 0xff,
+	0xae, 0x8f, 0x62, 0x32, 0x55, 0x4e, 0xe1, 0x88, 0xcc, 0x0a, 0x8c, 0x28, 0x84, 0x25, 0x27, 0x4b,
+	0x55, 0x26, 0x18, 0xc1, 0x9a, 0x97, 0xa5, 0x7f, 0x46, 0x02, 0x9b, 0xc9, 0x59, 0xf4, 0x99, 0x17,
+	0x63, 0xcf, 0xfc, 0x27, 0x7c, 0x55, 0xa9, 0x9f, 0x43, 0x37, 0x75, 0x34, 0x19, 0x28, 0x3b, 0x50,
+	0x11, 0x77, 0x0c, 0x4d, 0x50, 0xe6, 0xf3, 0xb1, 0xa5, 0xb6, 0x60, 0x8b, 0x95, 0x9e, 0xf1, 0xc2,
+	0x58, 0xbd, 0x2e, 0xf5, 0x73, 0x40, 0x51, 0xa2, 0xdc, 0xe5, 0x16, 0x94, 0xb8, 0x54, 0x10, 0x6b,
+	0x25, 0x8d, 0x33, 0xe8, 0x92, 0xaa, 0x3e, 0x06, 0x34, 0x5e, 0x30, 0x27, 0x0a, 0xb2, 0x74, 0x41,
+	0xdc, 0xbe, 0xb9, 0xa4, 0x7d, 0x83, 0x94, 0x90, 0x8f, 0xa4, 0x84, 0x4f, 0xa1, 0x15, 0xdb, 0xe8,
+	0xcd, 0xb7, 0xf8, 0x25, 0x14, 0x39, 0x6f, 0xaa, 0x17, 0x69, 0x43, 0x91, 0xe9, 0xa5, 0x4a, 0x9e,
+	0x7f, 0x41, 0x8b, 0x09, 0x3b, 0x93, 0xc9, 0x3b, 0x4f, 0x9e, 0x9e, 0x0b, 0x3c, 0x42, 0xab, 0x92,
+	0xd2, 0xf7, 0xc3, 0xd6, 0x7c, 0x7d, 0xd5, 0x9a, 0xab, 0xbf, 0x2f, 0x40, 0x35, 0x34, 0x6c, 0x4a,
+	0x4d, 0xd0, 0x54, 0xe4, 0x23, 0x98, 0xdb, 0x1b, 0x94, 0x84, 0xf5, 0x67, 0xfd, 0xca, 0xfa, 0xa3,
+	0x85, 0xf1, 0x57, 0xe4, 0x46, 0xef, 0xac, 0xf8, 0x32, 0x43, 0xee, 0x41, 0x1c, 0xff, 0x2a, 0x71,
+	0xa1, 0x1b, 0x11, 0xa1, 0xab, 0x21, 0xaf, 0x58, 0xb9, 0x2b, 0x27, 0xca, 0x5d, 0x88, 0x87, 0x55,
+	0x22, 0x78, 0xd8, 0xbf, 0x10, 0x08, 0x50, 0xff, 0x02, 0xb0, 0x19, 0x33, 0xdb, 0x92, 0x5e, 0xaf,
+	0xf4, 0x47, 0xbe, 0xd9, 0x23, 0x7e, 0x5b, 0x7d, 0xb3, 0xf3, 0xd0, 0x0c, 0x3d, 0x54, 0xb8, 0xd2,
+	0x43, 0x71, 0x3f, 0xaf, 0x27, 0xfd, 0xcc, 0xc1, 0x4e, 0xc3, 0xf3, 0xa3, 0xad, 0x40, 0x55, 0x52,
+	0xfa, 0x7e, 0xb2, 0x55, 0x28, 0xa5, 0x5a, 0x85, 0x2b, 0x3d, 0x12, 0x49, 0x24, 0x95, 0x58, 0x22,
+	0x61, 0x8f, 0x65, 0x4e, 0x4e, 0x05, 0xde, 0x50, 0x15, 0x4b, 0x73, 0x72, 0xca, 0xe1, 0x86, 0xcf,
+	0xc3, 0x90, 0x02, 0x1e, 0x1d, 0xef, 0x25, 0xfb, 0xb9, 0xcc, 0xc0, 0x1a, 0xc4, 0x03, 0x4b, 0x60,
+	0x9f, 0x77, 0x52, 0xa2, 0xd7, 0x44, 0x54, 0x37, 0xb2, 0x11, 0xd5, 0xfa, 0x75, 0x11, 0xd5, 0x46,
+	0x26, 0xa2, 0xaa, 0xc2, 0x86, 0x69, 0xb8, 0xc6, 0x0b, 0x7b, 0x6e, 0xfb, 0x36, 0xa6, 0xca, 0x26,
+	0x7f, 0xf4, 0x31, 0x5a, 0x02, 0x41, 0x6d, 0x5e, 0x0f, 0x41, 0xdd, 0xca, 0x46, 0x50, 0xff, 0x43,
+	0xa0, 0xd1, 0x2f, 0x56, 0x78, 0x67, 0x97, 0x1f, 0xf9, 0x66, 0xca, 0xed, 0x6f, 0x04, 0x3a, 0x95,
+	0xeb, 0x01, 0x9d, 0x3b, 0x57, 0x01, 0x9d, 0xbd, 0xeb, 0x02, 0x9d, 0x37, 0xd2, 0x40, 0xe7, 0xaa,
+	0xe3, 0x7e, 0x2f, 0xd6, 0x71, 0xaf, 0x5a, 0xf8, 0x9b, 0xb1, 0x16, 0xfe, 0x2a, 0x60, 0xf4, 0xd6,
+	0xbf, 0x23, 0x30, 0xfa, 0xbb, 0x1c, 0xd4, 0xfb, 0x1c, 0x5b, 0x79, 0x8b, 0xe6, 0xa9, 0x09, 0x05,
+	0xdf, 0xbf, 0x90, 0xd0, 0x0c, 0x1b, 0xae, 0x7e, 0xf1, 0x29, 0x44, 0x7f, 0xf1, 0x61, 0x56, 0xf6,
+	0x2d, 0xb2, 0x14, 0xf9, 0xb1, 0xa2, 0xcb, 0x99, 0xa4, 0x63, 0xcf, 0x53, 0x8a, 0x21, 0x1d, 0x7b,
+	0x9e, 0xaa, 0x42, 0x23, 0x38, 0x8b, 0x2c, 0xfe, 0x12, 0x93, 0xc9, 0xad, 0x30, 0x99, 0xbf, 0xe6,
+	0xa0, 0xf4, 0x9c, 0xcc, 0x97, 0xa2, 0x89, 0x48, 0xfd, 0xe4, 0x15, 0x4f, 0xcb, 0xf9, 0x64, 0x5a,
+	0xfe, 0x24, 0xd1, 0xd7, 0xb5, 0x34, 0xb1, 0x57, 0x66, 0xee, 0x0b, 0xfa, 0xfd, 0xf5, 0x48, 0xbf,
+	0xff, 0x3e, 0xd4, 0xa3, 0xd6, 0x09, 0x7e, 0xf7, 0xda, 0x88, 0x98, 0x87, 0xfe, 0x94, 0x76, 0xee,
+	0x0f, 0x39, 0x68, 0x09, 0x78, 0x4c, 0x1c, 0xec, 0xaa, 0x9f, 0xf7, 0xee, 0x86, 0x97, 0xc9, 0xf3,
+	0xcb, 0xec, 0x6a, 0x19, 0x92, 0x59, 0x37, 0xfb, 0x29, 0x07, 0xfc, 0x02, 0xda, 0x71, 0x2d, 0xd2,
+	0x53, 0xb7, 0xa1, 0x74, 0xce, 0x29, 0x12, 0x2e, 0x28, 0x4b, 0xcb, 0xea, 0x92, 0xac, 0xb6, 0x45,
+	0x77, 0x29, 0xa8, 0x61, 0xcf, 0x79, 0x17, 0x5a, 0x31, 0x6a, 0xf8, 0x25, 0x5f, 0x16, 0x62, 0x41,
+	0xd7, 0x19, 0x6e, 0x17, 0xd0, 0xd5, 0x7d, 0x68, 0x8f, 0x1d, 0xea, 0x62, 0xd3, 0x7f, 0xa3, 0xa5,
+	0xd4, 0xbb, 0xb0, 0x9d, 0xe0, 0xbd, 0xee, 0xa9, 0x3f, 0x86, 0x96, 0xc0, 0xd5, 0xde, 0xac, 0xa4,
+	0x03, 0xed, 0x38, 0xab, 0xd0, 0xb1, 0xff, 0x11, 0x54, 0xc3, 0xdf, 0x93, 0x50, 0x0d, 0xca, 0xc3,
+	0xd1, 0xa3, 0xfe, 0xb3, 0xa7, 0xd3, 0xe6, 0x1a, 0xda, 0x80, 0xca, 0xb3, 0xc9, 0x61, 0xff, 0xe4,
+	0xc9, 0x68, 0xd8, 0xcc, 0xed, 0xff, 0x37, 0x54, 0x43, 0xe0, 0x1a, 0x55, 0x60, 0xfd, 0xeb, 0xf1,
+	0x64, 0xd8, 0x5c, 0x43, 0x00, 0xa5, 0xe7, 0x47, 0x4f, 0x9f, 0x1d, 0x8e, 0x9a, 0x39, 0x54, 0x85,
+	0xe2, 0xf4, 0xf0, 0xf8, 0xd1, 0x49, 0x33, 0xbf, 0xff, 0x00, 0xea, 0x31, 0x4c, 0x1c, 0x95, 0xa1,
+	0x70, 0x7c, 0xc4, 0x04, 0xea, 0x50, 0x1d, 0x1c, 0x4d, 0xa6, 0xfd, 0xf1, 0x64, 0xa4, 0x37, 0x73,
+	0x6c, 0xa7, 0xc9, 0xd1, 0x70, 0xd4, 0xcc, 0xb3, 0x9d, 0xa6, 0x7d, 0xfd, 0xf1, 0x68, 0xda, 0x2c,
+	0xec, 0x2f, 0xa0, 0x99, 0x04, 0xaa, 0x51, 0x17, 0x5a, 0xc7, 0xfa, 0xd1, 0x71, 0xff, 0x71, 0x7f,
+	0x3a, 0x3e, 0x9a, 0xcc, 0x8e, 0xf5, 0xf1, 0xf3, 0xfe, 0x74, 0xd4, 0x5c, 0x43, 0x77, 0xe0, 0x66,
+	0x74, 0xe1, 0x9b, 0xa3, 0x93, 0xe9, 0x6c, 0x7a, 0x34, 0x8b, 0x6a, 0xb9, 0x09, 0x3b, 0x51, 0x96,
+	0xaf, 0xc7, 0xc3, 0xb1, 0x3e, 0x1a, 0xb0, 0x71, 0xff, 0x69, 0x33, 0xbf, 0x7f, 0x00, 0xb0, 0xfa,
+	0x32, 0x66, 0xf7, 0x3e, 0x3c, 0x1a, 0x8e, 0x1f, 0x8d, 0x47, 0xec, 0xbc, 0x55, 0x28, 0xf6, 0x87,
+	0x43, 0x66, 0x02, 0x61, 0x9d, 0xa7, 0xa3, 0xe9, 0x68, 0xd8, 0xcc, 0xef, 0x0f, 0xa0, 0x11, 0x6f,
+	0xbd, 0xd8, 0xf2, 0x40, 0x1f, 0xf5, 0xa7, 0x5c, 0xac, 0x06, 0x65, 0xfd, 0xd9, 0x64, 0x32, 0x9e,
+	0x3c, 0x6e, 0xe6, 0xd8, 0xd5, 0x46, 0x3f, 0x1b, 0x73, 0x39, 0xb6, 0xf0, 0x6c, 0xf2, 0x64, 0x72,
+	0xf4, 0xdd, 0xa4, 0x59, 0x38, 0xf8, 0xb1, 0x06, 0xa5, 0x01, 0xff, 0x35, 0x1f, 0x69, 0x50, 0x96,
+	0xbf, 0xa3, 0xa3, 0x4d, 0x2d, 0xfe, 0x8b, 0x7e, 0xaf, 0xa9, 0x25, 0x7e, 0xd0, 0x57, 0xd7, 0x10,
+	0x43, 0x00, 0xe2, 0x40, 0x35, 0xba, 0x0c, 0xba, 0xee, 0x29, 0xda, 0x25, 0x88, 0xbc, 0xba, 0x86,
+	0x06, 0xd0, 0x88, 0x83, 0xe6, 0xa8, 0xa3, 0x65, 0x22, 0xf0, 0xbd, 0xae, 0x76, 0x09, 0xba, 0xbe,
+	0x86, 0x1e, 0xc0, 0x46, 0x14, 0xd7, 0x46, 0x6d, 0x2d, 0x03, 0x6d, 0xef, 0x6d, 0x6b, 0x59, 0xe0,
+	0xb7, 0xba, 0x86, 0xbe, 0x82, 0x7a, 0x0c, 0x74, 0x46, 0xdb, 0x5a, 0x16, 0xb2, 0xdd, 0xeb, 0x68,
+	0xd9, 0xd8, 0x34, 0xb7, 0x46, 0x02, 0x60, 0x46, 0x5d, 0x2d, 0x1b, 0xaf, 0xee, 0x29, 0xda, 0x65,
+	0x58, 0x34, 0xb7, 0x46, 0x1c, 0xf4, 0x44, 0x1d, 0x2d, 0x13, 0x9e, 0xee, 0x75, 0xb5, 0x6c, 0x74,
+	0x54, 0xba, 0x26, 0xf1, 0x01, 0xd0, 0xd5, 0xb2, 0x51, 0xd0, 0x9e, 0x92, 0x5e, 0x88, 0x9a, 0x25,
+	0x86, 0x24, 0xa2, 0x6d, 0x2d, 0x0b, 0xc9, 0xec, 0x75, 0xb4, 0x4c, 0xc0, 0x51, 0x5d, 0x43, 0x0f,
+	0x61, 0x2b, 0x05, 0xd0, 0xa1, 0x1d, 0xed, 0x32, 0xd0, 0xae, 0x07, 0x5a, 0x08, 0xbe, 0xa9, 0x6b,
+	0x9f, 0xe6, 0xd0, 0x37, 0xb0, 0x99, 0xc0, 0xc1, 0xf8, 0x4d, 0xb2, 0xe0, 0xb7, 0x9e, 0x92, 0x5e,
+	0x08, 0xce, 0xb1, 0x97, 0x43, 0x63, 0x68, 0x26, 0x81, 0x2f, 0xa4, 0x68, 0x97, 0x20, 0x69, 0xbd,
+	0x1d, 0xed, 0x32, 0x94, 0x4c, 0x04, 0x5b, 0x14, 0x79, 0x42, 0x6d, 0x2d, 0x0e, 0x44, 0x05, 0xc1,
+	0x96, 0x05, 0x4f, 0xa9, 0x6b, 0xe8, 0x1e, 0x6c, 0x26, 0x60, 0x1d, 0xd4, 0xd5, 0xb2, 0x81, 0x9e,
+	0x94, 0x3d, 0xb8, 0x67, 0x63, 0x70, 0x06, 0xb7, 0x47, 0x16, 0xf6, 0xd2, 0x53, 0xd2, 0x0b, 0xe1,
+	0x19, 0x3e, 0x81, 0x92, 0x68, 0x25, 0x50, 0x43, 0x8b, 0xf5, 0x37, 0xbd, 0x4d, 0x2d, 0xde, 0x63,
+	0xa8, 0x6b, 0xe8, 0x0b, 0x80, 0x15, 0xf0, 0x81, 0x90, 0x96, 0x82, 0x46, 0x7a, 0x2d, 0x2d, 0x8d,
+	0x8c, 0xa8, 0x6b, 0xe8, 0x3e, 0xd4, 0x22, 0x90, 0x05, 0x6a, 0x69, 0x69, 0x24, 0xa4, 0xd7, 0xd6,
+	0x32, 0x50, 0x0d, 0xee, 0x31, 0x66, 0xe6, 0x48, 0x29, 0x65, 0x66, 0x4e, 0xd7, 0xef, 0xde, 0x76,
+	0x82, 0x1a, 0x31, 0x73, 0x2d, 0x52, 0x3a, 0x51, 0x4b, 0x8b, 0xcc, 0x56, 0xca, 0x33, 0xaa, 0xab,
+	0x08, 0xfc, 0x58, 0x41, 0x44, 0xdb, 0x5a, 0x56, 0x31, 0xed, 0x75, 0xb4, 0xcc, 0xba, 0x29, 0x13,
+	0x52, 0xa4, 0xda, 0xb1, 0x84, 0x94, 0xae, 0x93, 0xbd, 0xed, 0x04, 0x35, 0x10, 0xff, 0xba, 0xf2,
+	0xf3, 0x12, 0xc5, 0xde, 0x39, 0xf6, 0x5e, 0x94, 0xf8, 0xbf, 0xac, 0x3e, 0xfb, 0xc7, 0x00, 0x14,
+	0x6e, 0xe8, 0x03, 0x75, 0x25, 0x00, 0x00,
 }
@@ -21,15 +21,18 @@ type Server interface {
 // Protobuf stuctures are completely hidden behind this abstraction.
 type conmanServer struct {
 	runtimeSrv   cri.RuntimeService
+	imageSrv     cri.ImageService
 	streamingSrv streaming.Server
 }
 
 func New(
 	runtimeSrv cri.RuntimeService,
+	imageSrv cri.ImageService,
 	streamingSrv streaming.Server,
 ) Server {
 	return &conmanServer{
 		runtimeSrv:   runtimeSrv,
+		imageSrv:     imageSrv,
 		streamingSrv: streamingSrv,
 	}
 }
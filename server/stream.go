@@ -0,0 +1,62 @@
+package server
+
+import (
+	"io"
+)
+
+// CopyChunkSize is the max size of the data field of the copy messages.
+const CopyChunkSize = 32 * 1024
+
+// chunkWriter turns a stream of outgoing messages into an io.Writer.
+type chunkWriter struct {
+	send func([]byte) error
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > CopyChunkSize {
+			n = CopyChunkSize
+		}
+		if err := w.send(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// chunkReader turns a stream of incoming messages into an io.Reader.
+// The stream end (io.EOF) is the end of data.
+type chunkReader struct {
+	buf  []byte
+	recv func() ([]byte, error)
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		data, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// NewChunkWriter splits the written data into CopyChunkSize chunks
+// and passes them to send. Used by the streaming clients.
+func NewChunkWriter(send func([]byte) error) io.Writer {
+	return &chunkWriter{send: send}
+}
+
+// NewChunkReader reads the chunks returned by recv until it fails.
+// Used by the streaming clients.
+func NewChunkReader(recv func() ([]byte, error)) io.Reader {
+	return &chunkReader{recv: recv}
+}
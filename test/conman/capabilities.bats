@@ -0,0 +1,72 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "default capabilities" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- grep -E 'Cap(Eff|Bnd)|NoNewPrivs' /proc/self/status
+    [ $status -eq 0 ]
+    [[ "${output}" =~ CapEff:[[:space:]]+00000000a80425fb ]]
+    [[ "${output}" =~ CapBnd:[[:space:]]+00000000a80425fb ]]
+    [[ "${output}" =~ NoNewPrivs:[[:space:]]+0 ]]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "14" = $(jq -r '.status.capabilities | length' <<< $output) ]
+    [ "false" = $(jq -r '.status.privileged' <<< $output) ]
+}
+
+@test "add and drop capabilities" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --cap-drop ALL --cap-add net_admin,CAP_KILL \
+        --no-new-privileges \
+        cont1 -- grep -E 'CapEff|NoNewPrivs' /proc/self/status
+    [ $status -eq 0 ]
+    [[ "${output}" =~ CapEff:[[:space:]]+0000000000001020 ]]
+    [[ "${output}" =~ NoNewPrivs:[[:space:]]+1 ]]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "CAP_KILL,CAP_NET_ADMIN" = $(jq -r '.status.capabilities | join(",")' <<< $output) ]
+    [ "true" = $(jq -r '.status.noNewPrivileges' <<< $output) ]
+}
+
+@test "unknown capability" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --cap-add FOO \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
+
+@test "privileged container" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'mount -t tmpfs none /mnt'
+    [ $status -ne 0 ]
+
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --privileged \
+        cont2 -- /bin/sh -c 'mount -t tmpfs none /mnt && touch /sys/.rw 2>&1; grep Seccomp: /proc/self/status; ls /dev'
+    [ $status -eq 0 ]
+    [[ "${output}" != *"Read-only file system"* ]]
+    [[ "${output}" =~ Seccomp:[[:space:]]+0 ]]
+    [[ "${output}" == *"mem"* ]]
+
+    run conmanctl container status cont2
+    [ $status -eq 0 ]
+    [ "true" = $(jq -r '.status.privileged' <<< $output) ]
+    [ "unconfined" = $(jq -r '.status.seccompProfile' <<< $output) ]
+}
@@ -0,0 +1,43 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "container commit" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        build1 -- /bin/sh -c 'echo artifact > /artifact.txt && rm /etc/motd'
+    [ $status -eq 0 ]
+
+    run conmanctl container commit build1 built:v1
+    [ $status -eq 0 ]
+    local image_id=$(jq -r '.imageId' <<< $output)
+
+    run conmanctl image list -q
+    [ $status -eq 0 ]
+    [ "${output}" = "${image_id}" ]
+
+    run conmanctl run -R=false --image built:v1 build2 -- /bin/cat /artifact.txt
+    [ $status -eq 0 ]
+    [[ "${output}" == *"artifact"* ]]
+
+    run conmanctl run -R=false --image built:v1 build3 -- /bin/ls /etc/motd
+    [ $status -ne 0 ]
+
+    # Layered on top of the first image.
+    run conmanctl container commit build3 built:v2
+    [ $status -eq 0 ]
+
+    run conmanctl image list --format '{{range .Names}}{{.}}{{end}}'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "built:v1" ]
+    [ "${lines[1]}" = "built:v2" ]
+}
@@ -0,0 +1,84 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "container list filters" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --label app=web \
+        cont1 -- /bin/sleep 100
+    [ $status -eq 0 ]
+
+    local cont_id1=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont2 -- /bin/sleep 200
+    [ $status -eq 0 ]
+
+    local cont_id2=$(jq -r '.containerId' <<< $output)
+    run conmanctl container start "${cont_id2}"
+    [ $status -eq 0 ]
+
+    run conmanctl container list -q
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run conmanctl container list -q --state running
+    [ $status -eq 0 ]
+    [ "${output}" = "${cont_id2}" ]
+
+    run conmanctl container list -q --name cont1
+    [ $status -eq 0 ]
+    [ "${output}" = "${cont_id1}" ]
+
+    run conmanctl container list -q --label app=web
+    [ $status -eq 0 ]
+    [ "${output}" = "${cont_id1}" ]
+
+    run conmanctl container list -q --label foo=bar
+    [ $status -eq 0 ]
+    [ "${output}" = "" ]
+
+    run conmanctl container list --state bogus
+    [ $status -ne 0 ]
+
+    run conmanctl container list --state unknown
+    [ $status -ne 0 ]
+
+    # TODO: kill all runc spawned by conmand
+    run conmanctl container stop "${cont_id1}"
+    run conmanctl container stop "${cont_id2}"
+}
+
+@test "container list output formats" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sleep 100
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container list -o table
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ ^ID\ +NAME\ +STATE\ +CREATED ]]
+    [[ "${lines[1]}" =~ ^${cont_id:0:12}\ +cont1\ +created ]]
+
+    run conmanctl container list --format '{{.Name}} {{.State}}'
+    [ $status -eq 0 ]
+    [ "${output}" = "cont1 CREATED" ]
+
+    run conmanctl container list -o yaml
+    [ $status -eq 0 ]
+    [[ "${output}" =~ "name: cont1" ]]
+}
@@ -30,3 +30,45 @@ function teardown() {
     [ $status -eq 0 ]
 }
 
+
+@test "container labels and annotations" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --label app=web \
+        --annotation com.example.note=hi \
+        cont1 -- /bin/sleep 999
+    [ $status -eq 0 ]
+
+    local cont_id=$(echo $output | jq -r '.containerId')
+
+    run conmanctl container status "${cont_id}"
+    [ $status -eq 0 ]
+    [ "web" = $(echo $output | jq -r '.status.labels.app') ]
+    [ "hi" = $(echo $output | jq -r '.status.annotations["com.example.note"]') ]
+
+    run conmanctl container stop "${cont_id}"
+    [ $status -eq 0 ]
+}
+
+@test "container status by name and ID prefix" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sleep 999
+    [ $status -eq 0 ]
+
+    local cont_id=$(echo $output | jq -r '.containerId')
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "${cont_id}" = $(echo $output | jq -r '.status.containerId') ]
+
+    run conmanctl container status "${cont_id:0:12}"
+    [ $status -eq 0 ]
+    [ "${cont_id}" = $(echo $output | jq -r '.status.containerId') ]
+
+    run conmanctl container status no_such_container
+    [ $status -ne 0 ]
+
+    run conmanctl container stop cont1
+    [ $status -eq 0 ]
+}
@@ -0,0 +1,58 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+    LOCAL_DIR=$(mktemp --directory --tmpdir="/tmp" conman-test-cp.XXXXXX)
+}
+
+function teardown() {
+    conmand_stop
+    rm -rf "${LOCAL_DIR}"
+}
+
+@test "container cp out of and into a container" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'mkdir /out && echo artifact > /out/result.txt'
+    [ $status -eq 0 ]
+
+    run conmanctl container cp cont1:/out "${LOCAL_DIR}/"
+    [ $status -eq 0 ]
+    [ "$(cat ${LOCAL_DIR}/out/result.txt)" = "artifact" ]
+
+    run conmanctl container cp cont1:/out/result.txt "${LOCAL_DIR}/renamed.txt"
+    [ $status -eq 0 ]
+    [ "$(cat ${LOCAL_DIR}/renamed.txt)" = "artifact" ]
+
+    echo input > "${LOCAL_DIR}/input.txt"
+    run conmanctl container cp "${LOCAL_DIR}/input.txt" cont1:/tmp/
+    [ $status -eq 0 ]
+
+    run conmanctl container cp cont1:/tmp/input.txt -
+    [ $status -eq 0 ]
+    [[ "${output}" == *"input"* ]]
+}
+
+@test "container cp does not follow symlinks to the host" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/true
+    [ $status -eq 0 ]
+
+    ln -s /etc "${LOCAL_DIR}/hostetc"
+    run conmanctl container cp "${LOCAL_DIR}/hostetc" cont1:/hostetc
+    [ $status -eq 0 ]
+
+    # /hostetc -> /etc is resolved within the container rootfs.
+    run conmanctl container cp cont1:/hostetc/alpine-release "${LOCAL_DIR}/release"
+    [ $status -eq 0 ]
+    [ "$(cat ${LOCAL_DIR}/release)" = "$(cat ${TEST_ROOT}/data/rootfs_alpine/etc/alpine-release)" ]
+
+    echo secret > "${LOCAL_DIR}/secret"
+    run conmanctl container cp "cont1:/../../../..${LOCAL_DIR}/secret" "${LOCAL_DIR}/stolen"
+    [ $status -ne 0 ]
+    [ ! -e "${LOCAL_DIR}/stolen" ]
+}
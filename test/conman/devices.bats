@@ -0,0 +1,71 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+
+    CDI_DIR=$(mktemp --directory --tmpdir="/tmp" conman-test-cdi.XXXXXX)
+    cat > "${CDI_DIR}/vendor.yaml" <<SPEC
+cdiVersion: "0.5.0"
+kind: vendor.com/null
+devices:
+  - name: null0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/vendor-null
+          hostPath: /dev/null
+      env:
+        - VENDOR_DEVICE=null0
+SPEC
+    CONMAND_FLAGS="--cdi-spec-dirs ${CDI_DIR}"
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+    rm -rf "${CDI_DIR}"
+}
+
+@test "host device" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --device /dev/null:/dev/mynull:rw \
+        cont1 -- /bin/sh -c 'stat -c %t:%T /dev/mynull; echo hi > /dev/mynull && echo written'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "1:3" ]
+    [ "${lines[1]}" = "written" ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "/dev/mynull" = $(jq -r '.status.devices[0].containerPath' <<< $output) ]
+    [ "rw" = $(jq -r '.status.devices[0].permissions' <<< $output) ]
+}
+
+@test "CDI device" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --device vendor.com/null=null0 \
+        cont1 -- /bin/sh -c 'stat -c %t:%T /dev/vendor-null; echo ${VENDOR_DEVICE}'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "1:3" ]
+    [ "${lines[1]}" = "null0" ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "vendor.com/null=null0" = $(jq -r '.status.cdiDevices[0]' <<< $output) ]
+}
+
+@test "unknown devices" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --device vendor.com/null=null1 \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --device /dev/does-not-exist \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
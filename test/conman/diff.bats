@@ -0,0 +1,25 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "container diff" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'echo new > /added.txt; echo x >> /etc/hostname; rm /etc/motd'
+    [ $status -eq 0 ]
+
+    run conmanctl container diff cont1 -o table
+    [ $status -eq 0 ]
+    [[ "${output}" =~ A\ +/added.txt ]]
+    [[ "${output}" =~ C\ +/etc/hostname ]]
+    [[ "${output}" =~ D\ +/etc/motd ]]
+}
@@ -0,0 +1,35 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+    LOCAL_DIR=$(mktemp --directory --tmpdir="/tmp" conman-test-export.XXXXXX)
+}
+
+function teardown() {
+    conmand_stop
+    rm -rf "${LOCAL_DIR}"
+}
+
+@test "container export and image import-rootfs" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'echo exported > /marker.txt'
+    [ $status -eq 0 ]
+
+    run conmanctl container export cont1 -f "${LOCAL_DIR}/rootfs.tar"
+    [ $status -eq 0 ]
+
+    run tar -tf "${LOCAL_DIR}/rootfs.tar" ./marker.txt
+    [ $status -eq 0 ]
+
+    gzip -k "${LOCAL_DIR}/rootfs.tar"
+    run conmanctl image import-rootfs "${LOCAL_DIR}/rootfs.tar.gz" imported:v1
+    [ $status -eq 0 ]
+
+    run conmanctl run --image imported:v1 cont2 -- /bin/cat /marker.txt
+    [ $status -eq 0 ]
+    [[ "${output}" == *"exported"* ]]
+}
@@ -14,6 +14,9 @@ CONMAND_PID=
 
 CONMANCTL_BINARY="${BIN_ROOT}/conmanctl"
 
+# Extra conmand flags (word-split), e.g. --subid-user conman.
+CONMAND_FLAGS=
+
 function conmand_start() {
     conmand_setup
 
@@ -24,6 +27,7 @@ function conmand_start() {
         --runtime-path "${RUNTIME_PATH}" \
         --runtime-root "${CONMAND_DIR}/${RUNTIME_ROOT}" \
         --listen "${CONMAND_DIR}/run/conmand.sock" \
+        ${CONMAND_FLAGS} \
         &> >(tee $CONMAND_LOG) & CONMAND_PID=$!
     debug "conmand PID ${CONMAND_PID}"
 
@@ -44,6 +48,7 @@ function conmand_restart() {
         --runtime-path "${RUNTIME_PATH}" \
         --runtime-root "${CONMAND_DIR}/${RUNTIME_ROOT}" \
         --listen "${CONMAND_DIR}/run/conmand.sock" \
+        ${CONMAND_FLAGS} \
         &> >(tee $CONMAND_LOG) & CONMAND_PID=$!
     debug "conmand PID ${CONMAND_PID}"
 
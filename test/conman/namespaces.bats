@@ -0,0 +1,79 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "host namespaces" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --network host --uts host --ipc host \
+        cont1 -- /bin/sh -c 'readlink /proc/self/ns/net /proc/self/ns/uts /proc/self/ns/ipc /proc/self/ns/pid'
+    [ $status -eq 0 ]
+    [[ "${lines[0]}" = $(readlink /proc/self/ns/net) ]]
+    [[ "${lines[1]}" = $(readlink /proc/self/ns/uts) ]]
+    [[ "${lines[2]}" = $(readlink /proc/self/ns/ipc) ]]
+    [[ "${lines[3]}" != $(readlink /proc/self/ns/pid) ]]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "NODE" = $(jq -r '.status.namespaceOptions.network' <<< $output) ]
+    [ "CONTAINER" = $(jq -r '.status.namespaceOptions.pid' <<< $output) ]
+}
+
+@test "container namespaces" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- sleep 1000
+    [ $status -eq 0 ]
+    local cont1_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --pid container:cont1 --network container:cont1 \
+        cont2 -- ps -o comm
+    [ $status -eq 0 ]
+    [[ "${output}" == *"sleep"* ]]
+
+    run conmanctl container status cont2
+    [ $status -eq 0 ]
+    [ "TARGET" = $(jq -r '.status.namespaceOptions.pid' <<< $output) ]
+    [ "${cont1_id}" = $(jq -r '.status.namespaceOptions.targetId' <<< $output) ]
+
+    # The namespace owner can't go away while it's shared.
+    run conmanctl container stop cont1
+    [ $status -eq 0 ]
+    run conmanctl container remove cont1
+    [ $status -ne 0 ]
+
+    conmand_restart
+
+    run conmanctl container remove cont1
+    [ $status -ne 0 ]
+
+    run conmanctl container remove cont2
+    [ $status -eq 0 ]
+    run conmanctl container remove cont1
+    [ $status -eq 0 ]
+}
+
+@test "bad namespace options" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --pid container:nosuchcontainer \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --network bridge \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
@@ -0,0 +1,75 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+# There are no container memory limits (yet), so the limit
+# is set on the container cgroup right after the start.
+function limit_memory() {
+    local pid=$(${RUNTIME_PATH} --root "${CONMAND_DIR}/${RUNTIME_ROOT}" state "$1" | jq -r '.pid')
+    if [ -f /sys/fs/cgroup/cgroup.controllers ]; then
+        local dir="/sys/fs/cgroup$(grep '^0::' /proc/${pid}/cgroup | cut -d: -f3)"
+        echo $2 > "${dir}/memory.max"
+        echo 0 > "${dir}/memory.swap.max" || true
+    else
+        local dir="/sys/fs/cgroup/memory$(grep ':memory:' /proc/${pid}/cgroup | cut -d: -f3)"
+        echo $2 > "${dir}/memory.limit_in_bytes"
+    fi
+}
+
+@test "OOM killed container" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'sleep 2; exec tail /dev/zero'
+    [ $status -eq 0 ]
+    local cont1_id=$(jq -r '.containerId' <<< $output)
+
+    limit_memory ${cont1_id} 33554432
+
+    run conmanctl container wait cont1
+    [ $status -eq 0 ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "EXITED" = $(jq -r '.status.state' <<< $output) ]
+    [ "OOMKilled" = $(jq -r '.status.reason' <<< $output) ]
+
+    grep -q "event=oom" $CONMAND_LOG
+}
+
+@test "not OOM killed container" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'kill -9 $$'
+    [ $status -ne 0 ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "Signaled" = "$(jq -r '.status.reason' <<< $output)" ]
+}
+
+@test "OOM killed container child" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'sleep 2; tail /dev/zero; exit 0'
+    [ $status -eq 0 ]
+    local cont1_id=$(jq -r '.containerId' <<< $output)
+
+    limit_memory ${cont1_id} 33554432
+
+    run conmanctl container wait cont1
+    [ $status -eq 0 ]
+
+    # Only the init process termination tells the container reason.
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "Completed" = $(jq -r '.status.reason' <<< $output) ]
+}
@@ -0,0 +1,52 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    CONMAND_FLAGS="--default-ulimits nofile=1024:4096,nproc=2048"
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "ulimits" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --ulimit nofile=65536 --oom-score-adj 500 \
+        cont1 -- /bin/sh -c 'ulimit -n; ulimit -u; cat /proc/self/oom_score_adj'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "65536" ]
+    [ "${lines[1]}" = "2048" ]
+    [ "${lines[2]}" = "500" ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "65536" = $(jq -r '.status.rlimits[] | select(.type == "RLIMIT_NOFILE") | .soft' <<< $output) ]
+    [ "500" = $(jq -r '.status.oomScoreAdj' <<< $output) ]
+}
+
+@test "default ulimits" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'ulimit -Sn; ulimit -Hn'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "1024" ]
+    [ "${lines[1]}" = "4096" ]
+}
+
+@test "bad ulimits" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --ulimit nofile=4096:1024 \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --oom-score-adj 1001 \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
@@ -0,0 +1,82 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "run propagates exit code" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'echo hello; exit 3'
+    debug "${output}"
+    [ $status -eq 3 ]
+    [[ "${output}" == *"hello"* ]]
+
+    run conmanctl container list -q --name cont1
+    [ $status -eq 0 ]
+    [ "${output}" != "" ]
+}
+
+@test "run --rm removes container" {
+    run conmanctl run --rm \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'exit 3'
+    [ $status -eq 3 ]
+
+    # Removed by conmand, even if the client is gone.
+    for i in $(seq 1 30); do
+        run conmanctl container list -q
+        [ "${output}" = "" ] && break
+        sleep 0.2
+    done
+    [ "${output}" = "" ]
+}
+
+@test "run detached" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sleep 100
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container status "${cont_id}"
+    [ $status -eq 0 ]
+    [ "RUNNING" = $(jq -r '.status.state' <<< $output) ]
+
+    run conmanctl container stop "${cont_id}"
+    [ $status -eq 0 ]
+}
+
+@test "run detached --rm removes container on exit" {
+    run conmanctl run -d --rm \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sleep 1
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container status "${cont_id}"
+    [ $status -eq 0 ]
+
+    for i in $(seq 1 30); do
+        run conmanctl container list -q
+        [ "${output}" = "" ] && break
+        sleep 0.2
+    done
+    [ "${output}" = "" ]
+}
+
+@test "run has no tty" {
+    run conmanctl run -t \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/true
+    [ $status -ne 0 ]
+}
@@ -0,0 +1,66 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+    PROFILE_DIR=$(mktemp --directory --tmpdir="/tmp" conman-test-seccomp.XXXXXX)
+}
+
+function teardown() {
+    conmand_stop
+    rm -rf "${PROFILE_DIR}"
+}
+
+@test "default seccomp profile" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- grep Seccomp: /proc/self/status
+    [ $status -eq 0 ]
+    [[ "${output}" =~ Seccomp:[[:space:]]+2 ]]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "runtime/default" = $(jq -r '.status.seccompProfile' <<< $output) ]
+}
+
+@test "unconfined seccomp profile" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --seccomp unconfined \
+        cont1 -- grep Seccomp: /proc/self/status
+    [ $status -eq 0 ]
+    [[ "${output}" =~ Seccomp:[[:space:]]+0 ]]
+}
+
+@test "custom seccomp profile" {
+    cat > "${PROFILE_DIR}/profile.json" <<'PROFILE'
+{
+    "defaultAction": "SCMP_ACT_ALLOW",
+    "syscalls": [{"names": ["mkdir", "mkdirat"], "action": "SCMP_ACT_ERRNO", "errnoRet": 13}]
+}
+PROFILE
+
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --seccomp "${PROFILE_DIR}/profile.json" \
+        cont1 -- mkdir /tmp/foo
+    [ $status -ne 0 ]
+    [[ "${output}" == *"Permission denied"* ]]
+}
+
+@test "seccomp profile with unknown syscall" {
+    echo '{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["nosuchcall"], "action": "SCMP_ACT_ERRNO"}]}' \
+        > "${PROFILE_DIR}/profile.json"
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --seccomp "localhost/${PROFILE_DIR}/profile.json" \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container list -q
+    [ $status -eq 0 ]
+    [ "${output}" = "" ]
+}
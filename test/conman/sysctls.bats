@@ -0,0 +1,72 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    CONMAND_FLAGS="--allowed-unsafe-sysctls net.core.*"
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "sysctls" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --sysctl net.ipv4.ip_unprivileged_port_start=0 \
+        --sysctl net.core.somaxconn=1234 \
+        cont1 -- cat /proc/sys/net/ipv4/ip_unprivileged_port_start /proc/sys/net/core/somaxconn
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "0" ]
+    [ "${lines[1]}" = "1234" ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "1234" = $(jq -r '.status.sysctls["net.core.somaxconn"]' <<< $output) ]
+}
+
+@test "unsafe sysctls" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --sysctl net.ipv4.ip_forward=1 \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --sysctl vm.swappiness=10 \
+        cont1 -- true
+    [ $status -ne 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --network host --sysctl net.core.somaxconn=1234 \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
+
+@test "masked paths" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'wc -c < /proc/kcore; touch /proc/sysrq-trigger || echo readonly'
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "0" ]
+    [ "${lines[1]}" = "readonly" ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "/proc/kcore" = $(jq -r '.status.maskedPaths | map(select(. == "/proc/kcore")) | .[0]' <<< $output) ]
+
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --proc-mount unmasked \
+        cont2 -- /bin/sh -c 'test -s /proc/kcore && echo unmasked'
+    [ $status -eq 0 ]
+    [[ "${output}" == *"unmasked"* ]]
+
+    run conmanctl container status cont2
+    [ $status -eq 0 ]
+    [ "0" = $(jq -r '.status.maskedPaths | length' <<< $output) ]
+}
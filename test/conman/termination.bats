@@ -0,0 +1,95 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "termination message" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'echo -n "bad config" > /dev/termination-log; exit 3'
+    [ $status -eq 3 ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "Error" = $(jq -r '.status.reason' <<< $output) ]
+    [ "bad config" = "$(jq -r '.status.message' <<< $output)" ]
+    [ "3" = $(jq -r '.status.exitCode' <<< $output) ]
+}
+
+@test "termination message path" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --termination-message-path /tmp/done \
+        cont1 -- /bin/sh -c 'echo -n "all good" > /tmp/done'
+    [ $status -eq 0 ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "Completed" = $(jq -r '.status.reason' <<< $output) ]
+    [ "all good" = "$(jq -r '.status.message' <<< $output)" ]
+    [ "/tmp/done" = $(jq -r '.status.terminationMessagePath' <<< $output) ]
+}
+
+@test "termination message log fallback" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'echo "something went wrong" >&2; exit 1'
+    [ $status -eq 1 ]
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    [ "Error" = $(jq -r '.status.reason' <<< $output) ]
+    [[ "$(jq -r '.status.message' <<< $output)" == *"something went wrong"* ]]
+}
+
+@test "termination signal" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sleep 999
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container stop "${cont_id}"
+    [ $status -eq 0 ]
+
+    run conmanctl container status "${cont_id}"
+    [ $status -eq 0 ]
+    [ "Signaled" = $(jq -r '.status.reason' <<< $output) ]
+    [ "9" = $(jq -r '.status.signal' <<< $output) ]
+    [ "136" = $(jq -r '.status.exitCode' <<< $output) ]
+}
+
+@test "termination on SIGTERM" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'trap "echo -n bye > /dev/termination-log; exit 0" TERM; while true; do sleep 0.1; done'
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container stop "${cont_id}"
+    [ $status -eq 0 ]
+
+    run conmanctl container status "${cont_id}"
+    [ $status -eq 0 ]
+    [ "Completed" = $(jq -r '.status.reason' <<< $output) ]
+    [ "bye" = "$(jq -r '.status.message' <<< $output)" ]
+    [ "0" = $(jq -r '.status.exitCode' <<< $output) ]
+}
+
+@test "bad termination message path" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --termination-message-path tmp/done \
+        cont1 -- true
+    [ $status -ne 0 ]
+}
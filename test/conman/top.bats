@@ -0,0 +1,32 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "container top" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- sleep 100
+    [ $status -eq 0 ]
+
+    run conmanctl container top cont1
+    [ $status -ne 0 ]
+
+    run conmanctl container start cont1
+    [ $status -eq 0 ]
+
+    run conmanctl container top cont1 -o table
+    [ $status -eq 0 ]
+    [[ "${output}" =~ \ 1\ +root\ .*sleep\ 100 ]]
+
+    run conmanctl container stop cont1
+    [ $status -eq 0 ]
+}
@@ -0,0 +1,81 @@
+#!/usr/bin/env bats
+
+load helpers
+
+SUBID_USER=containers
+
+function setup() {
+    setup_test
+    if ! grep -q "^${SUBID_USER}:" /etc/subuid || ! grep -q "^${SUBID_USER}:" /etc/subgid; then
+        skip "no subordinate IDs for ${SUBID_USER}"
+    fi
+    CONMAND_FLAGS="--subid-user ${SUBID_USER}"
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+    CONMAND_FLAGS=
+}
+
+@test "user namespace" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --userns \
+        cont1 -- /bin/sh -c 'cat /proc/self/uid_map; id -u; stat -c %u /etc/passwd; touch /etc/rw'
+    [ $status -eq 0 ]
+    local uid_map="${output}"
+
+    run conmanctl container status cont1
+    [ $status -eq 0 ]
+    local host_uid=$(jq -r '.status.uidMappings[0].hostId' <<< $output)
+    [ "${host_uid}" -gt 0 ]
+    [ "65536" = $(jq -r '.status.uidMappings[0].size' <<< $output) ]
+
+    [[ "${uid_map}" =~ 0[[:space:]]+${host_uid}[[:space:]]+65536 ]]
+    [[ "${uid_map}" =~ $'\n'0$'\n'0 ]]
+
+    # The rootfs in the bundle keeps the image ownership.
+    run conmanctl container diff cont1 -o table
+    [ $status -eq 0 ]
+    [[ "${output}" =~ A\ +/etc/rw ]]
+    [[ ! "${output}" =~ /etc/passwd ]]
+}
+
+@test "user namespace ID allocation" {
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --uidmap 0:0:1000 --uidmap 1000:2000:1 \
+        cont1 -- true
+    [ $status -eq 0 ]
+
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --userns \
+        cont2 -- true
+    [ $status -eq 0 ]
+
+    run conmanctl container list -q
+    [ $status -eq 0 ]
+
+    run conmanctl container status cont1
+    local cont1_base=$(jq -r '.status.uidMappings[0].hostId' <<< $output)
+    [ $(( cont1_base + 2000 )) = $(jq -r '.status.uidMappings[1].hostId' <<< $output) ]
+
+    run conmanctl container status cont2
+    local cont2_base=$(jq -r '.status.uidMappings[0].hostId' <<< $output)
+    [ "${cont2_base}" -ge $(( cont1_base + 2001 )) ]
+
+    conmand_restart
+
+    # Freed IDs are reused, the restored ones are not.
+    run conmanctl container remove cont1
+    [ $status -eq 0 ]
+    run conmanctl container create \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        --uidmap 0:0:1000 \
+        cont3 -- true
+    [ $status -eq 0 ]
+    run conmanctl container status cont3
+    [ "${cont1_base}" = $(jq -r '.status.uidMappings[0].hostId' <<< $output) ]
+}
@@ -0,0 +1,106 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+    HOST_DIR=$(mktemp --directory --tmpdir="/tmp" conman-test-volumes.XXXXXX)
+}
+
+function teardown() {
+    conmand_stop
+    rm -rf "${HOST_DIR}"
+}
+
+@test "bind and tmpfs mounts" {
+    echo "from host" > "${HOST_DIR}/host.txt"
+
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v "${HOST_DIR}:/host:ro" \
+        --tmpfs /scratch:size=1m \
+        cont1 -- /bin/sh -c 'cat /host/host.txt; touch /host/x || echo readonly; df -k /scratch | tail -1'
+    [ $status -eq 0 ]
+    [[ "${output}" == *"from host"* ]]
+    [[ "${output}" == *"readonly"* ]]
+    [[ "${output}" =~ tmpfs\ +1024\  ]]
+}
+
+@test "named volumes" {
+    run conmanctl run -R=false \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v data:/etc/apk \
+        cont1 -- /bin/sh -c 'echo persisted > /etc/apk/marker'
+    [ $status -eq 0 ]
+
+    # Seeded from the image, then modified by cont1.
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v data:/data \
+        cont2 -- /bin/sh -c 'cat /data/marker; ls /data/repositories'
+    [ $status -eq 0 ]
+    [[ "${output}" == *"persisted"* ]]
+    [[ "${output}" == *"/data/repositories"* ]]
+
+    run conmanctl volume inspect data
+    [ $status -eq 0 ]
+    [ "2" = $(jq -r '.volume.containerIds | length' <<< $output) ]
+
+    run conmanctl volume remove data
+    [ $status -ne 0 ]
+
+    conmand_restart
+
+    run conmanctl volume list -o table
+    [ $status -eq 0 ]
+    [[ "${output}" =~ data\ .*\ 2 ]]
+
+    run conmanctl container remove cont1
+    [ $status -eq 0 ]
+    run conmanctl container remove cont2
+    [ $status -eq 0 ]
+
+    run conmanctl volume remove data
+    [ $status -eq 0 ]
+
+    run conmanctl volume list -q
+    [ $status -eq 0 ]
+    [ "${output}" = "" ]
+}
+
+@test "mount denylist" {
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v /proc:/hostproc:ro \
+        cont1 -- /bin/true
+    [ $status -ne 0 ]
+    [[ "${output}" == *"/proc is denylisted"* ]]
+
+    # The parent directories of the daemon paths are denied too.
+    run conmanctl run \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v "${CONMAND_DIR}/var/lib:/hostlib:ro" \
+        cont1 -- /bin/true
+    [ $status -ne 0 ]
+    [[ "${output}" == *"/var/lib/conman is denylisted"* ]]
+}
+
+@test "mount propagation" {
+    mkdir "${HOST_DIR}/sub"
+
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        -v "${HOST_DIR}:/host:rslave" \
+        cont1 -- /bin/sh -c 'while [ ! -f /host/sub/late.txt ]; do sleep 0.1; done; cat /host/sub/late.txt'
+    [ $status -eq 0 ]
+
+    # A host mount made after the container start shows up inside.
+    mount -t tmpfs tmpfs "${HOST_DIR}/sub"
+    echo "propagated" > "${HOST_DIR}/sub/late.txt"
+
+    run conmanctl container wait cont1
+    umount "${HOST_DIR}/sub"
+    [ $status -eq 0 ]
+    [ "0" = $(jq -r '.exitCode' <<< $output) ]
+}
@@ -0,0 +1,27 @@
+#!/usr/bin/env bats
+
+load helpers
+
+function setup() {
+    setup_test
+    conmand_start
+}
+
+function teardown() {
+    conmand_stop
+}
+
+@test "container wait" {
+    run conmanctl run -d \
+        --image "${TEST_ROOT}/data/rootfs_alpine/" \
+        cont1 -- /bin/sh -c 'sleep 1; exit 5'
+    [ $status -eq 0 ]
+
+    local cont_id=$(jq -r '.containerId' <<< $output)
+
+    run conmanctl container wait "${cont_id}"
+    [ $status -eq 0 ]
+    [ "EXITED" = $(jq -r '.state' <<< $output) ]
+    [ "5" = $(jq -r '.exitCode' <<< $output) ]
+    [ "0" = $(jq -r '.signal' <<< $output) ]
+}
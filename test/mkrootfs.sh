@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+# Builds a test rootfs directory out of a rootfs tarball using conman itself:
+# the tarball is imported as an image, a container is created from it, and
+# then the container rootfs is exported and unpacked to the destination.
+#
+# Usage: mkrootfs.sh <rootfs-tarball-url> <dest-dir>
+set -euo pipefail
+
+ROOTFS_URL=$1
+DEST_DIR=$2
+
+PROJECT_ROOT=$(cd "$(dirname "$(readlink -f "$BASH_SOURCE")")/.."; pwd -P)
+WORK_DIR=$(mktemp --directory --tmpdir="/tmp" conman-mkrootfs.XXXXXX)
+SOCKET="${WORK_DIR}/run/conmand.sock"
+
+"${PROJECT_ROOT}/bin/conmand" \
+    --lib-root "${WORK_DIR}/var/lib/conman" \
+    --run-root "${WORK_DIR}/run/conman" \
+    --container-logs "${WORK_DIR}/var/log/conman" \
+    --runtime-root "${WORK_DIR}/run/conman-runc" \
+    --listen "${SOCKET}" \
+    &> "${WORK_DIR}/conmand.log" & CONMAND_PID=$!
+
+function cleanup() {
+    kill ${CONMAND_PID} && wait ${CONMAND_PID} || true
+    rm -rf "${WORK_DIR}"
+}
+trap cleanup EXIT
+
+function conmanctl() {
+    "${PROJECT_ROOT}/bin/conmanctl" --host "${SOCKET}" "$@"
+}
+
+for i in $(seq 10); do
+    conmanctl version &> /dev/null && break
+    sleep 1
+done
+
+curl -fsSL "${ROOTFS_URL}" | conmanctl image import-rootfs - mkrootfs
+conmanctl container create --image mkrootfs mkrootfs -- /bin/true
+mkdir -p "${DEST_DIR}"
+conmanctl container export mkrootfs | tar -C "${DEST_DIR}" -xf -
+conmanctl container remove mkrootfs